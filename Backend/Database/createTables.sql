@@ -12,7 +12,18 @@ CREATE TABLE league (
   signup_end      INT           NOT NULL                ,
   league_start    INT           NOT NULL                ,
   league_end      INT           NOT NULL                ,
-  game            VARCHAR(30)   NOT NULL
+  game            VARCHAR(30)   NOT NULL                ,
+  time_zone       VARCHAR(64)   NOT NULL DEFAULT 'UTC'  ,
+  result_confirmation_hours INT NOT NULL DEFAULT 0   , -- 0 never confirms submitted results automatically
+  require_team_approval BOOLEAN NOT NULL DEFAULT FALSE, -- teams registered by non administrators start pending
+  withdrawal_policy VARCHAR(10) NOT NULL DEFAULT 'forfeit', -- 'forfeit' or 'cancel' remaining games of withdrawn teams
+  win_points      SMALLINT      NOT NULL DEFAULT 3      ,
+  draw_points     SMALLINT      NOT NULL DEFAULT 1      ,
+  loss_points     SMALLINT      NOT NULL DEFAULT 0      ,
+  min_main_roster SMALLINT      NOT NULL DEFAULT 0      ,
+  max_main_roster SMALLINT      NOT NULL DEFAULT 0      , -- 0 places no limit on the main roster
+  max_substitutes SMALLINT      NOT NULL DEFAULT 0      , -- 0 places no limit on the substitutes
+  roster_lock     INT           NOT NULL DEFAULT 0        -- 0 never locks rosters
 );
 ALTER SEQUENCE league_id_seq OWNED BY league.league_id;
 
@@ -27,6 +38,17 @@ CREATE TABLE user_ (
 );
 ALTER SEQUENCE user_id_seq OWNED BY user_.user_id;
 
+DROP SEQUENCE IF EXISTS division_id_seq CASCADE;
+CREATE SEQUENCE division_id_seq;
+DROP TABLE IF EXISTS division CASCADE;
+CREATE TABLE division (
+  division_id     INT           PRIMARY KEY DEFAULT nextval('division_id_seq'),
+  league_id       INT           NOT NULL REFERENCES league(league_id) ON DELETE CASCADE,
+  name            VARCHAR(50)   NOT NULL         ,
+  UNIQUE (league_id, name)
+);
+ALTER SEQUENCE division_id_seq OWNED BY division.division_id;
+
 DROP SEQUENCE IF EXISTS team_id_seq CASCADE;
 CREATE SEQUENCE team_id_seq;
 DROP TABLE IF EXISTS team CASCADE;
@@ -40,6 +62,9 @@ CREATE TABLE team (
   losses          INT           NOT NULL         ,
   icon_small      VARCHAR(20)   NOT NULL         ,
   icon_large      VARCHAR(20)   NOT NULL         ,
+  pending         BOOLEAN       NOT NULL DEFAULT FALSE, -- awaiting approval by a league administrator
+  archived        BOOLEAN       NOT NULL DEFAULT FALSE, -- withdrawn, kept so the results of its games are preserved
+  division_id     INT                    REFERENCES division(division_id) ON DELETE SET NULL,
   UNIQUE (league_id, name)                       ,
   UNIQUE (league_id, tag)
 );
@@ -52,17 +77,66 @@ CREATE TABLE player (
   player_id       INT           PRIMARY KEY DEFAULT nextval('player_id_seq'),
   team_id         INT           NOT NULL REFERENCES team(team_id),
   league_id       INT           NOT NULL REFERENCES league(league_id),
-  user_id         INT           UNIQUE           ,
+  user_id         INT           REFERENCES user_(user_id) ON DELETE SET NULL,
   game_identifier VARCHAR(50)   NOT NULL         ,
   name            VARCHAR(50)   NOT NULL         ,
   external_id     VARCHAR(50)                    ,
   main_roster     BOOLEAN       NOT NULL         ,
   position        VARCHAR(20)                    ,
   UNIQUE (league_id, game_identifier)            ,
-  UNIQUE (league_id, external_id)
+  UNIQUE (league_id, external_id)                ,
+  UNIQUE (league_id, user_id)
 );
 ALTER SEQUENCE player_id_seq OWNED BY player.player_id;
 
+DROP SEQUENCE IF EXISTS roster_request_id_seq CASCADE;
+CREATE SEQUENCE roster_request_id_seq;
+DROP TABLE IF EXISTS roster_request CASCADE;
+CREATE TABLE roster_request (
+  request_id      INT           PRIMARY KEY DEFAULT nextval('roster_request_id_seq'),
+  team_id         INT           NOT NULL REFERENCES team(team_id) ON DELETE CASCADE,
+  user_id         INT           NOT NULL REFERENCES user_(user_id) ON DELETE CASCADE,
+  player_id       INT           REFERENCES player(player_id) ON DELETE CASCADE, -- NULL adds a new player
+  name            VARCHAR(50)                    ,
+  game_identifier VARCHAR(50)                    ,
+  main_roster     BOOLEAN       NOT NULL         ,
+  from_team       BOOLEAN       NOT NULL         , -- invites from the team are answered by the user
+  created_at      INT           NOT NULL         ,
+  UNIQUE (team_id, user_id)
+);
+ALTER SEQUENCE roster_request_id_seq OWNED BY roster_request.request_id;
+
+DROP SEQUENCE IF EXISTS roster_change_id_seq CASCADE;
+CREATE SEQUENCE roster_change_id_seq;
+DROP TABLE IF EXISTS roster_change CASCADE;
+CREATE TABLE roster_change (
+  roster_change_id INT          PRIMARY KEY DEFAULT nextval('roster_change_id_seq'),
+  team_id         INT           NOT NULL REFERENCES team(team_id) ON DELETE CASCADE,
+  user_id         INT           NOT NULL REFERENCES user_(user_id) ON DELETE CASCADE, -- who made the change
+  player_id       INT           REFERENCES player(player_id) ON DELETE CASCADE, -- NULL adds a new player
+  name            VARCHAR(50)                    ,
+  game_identifier VARCHAR(50)                    ,
+  external_id     VARCHAR(50)                    ,
+  main_roster     BOOLEAN       NOT NULL         ,
+  position        VARCHAR(20)                    ,
+  created_at      INT           NOT NULL
+);
+ALTER SEQUENCE roster_change_id_seq OWNED BY roster_change.roster_change_id;
+
+DROP SEQUENCE IF EXISTS transfer_id_seq CASCADE;
+CREATE SEQUENCE transfer_id_seq;
+DROP TABLE IF EXISTS player_transfer CASCADE;
+CREATE TABLE player_transfer (
+  transfer_id     INT           PRIMARY KEY DEFAULT nextval('transfer_id_seq'),
+  league_id       INT           NOT NULL REFERENCES league(league_id) ON DELETE CASCADE,
+  player_id       INT           NOT NULL REFERENCES player(player_id) ON DELETE CASCADE,
+  from_team_id    INT           NOT NULL REFERENCES team(team_id) ON DELETE CASCADE,
+  to_team_id      INT           NOT NULL REFERENCES team(team_id) ON DELETE CASCADE,
+  user_id         INT           REFERENCES user_(user_id) ON DELETE SET NULL, -- who made the transfer
+  transferred_at  INT           NOT NULL
+);
+ALTER SEQUENCE transfer_id_seq OWNED BY player_transfer.transfer_id;
+
 DROP TABLE IF EXISTS league_permissions;
 CREATE TABLE league_permissions (
   user_id         INT           NOT NULL REFERENCES user_(user_id),
@@ -73,6 +147,22 @@ CREATE TABLE league_permissions (
   edit_games      BOOLEAN       NOT NULL
 );
 
+DROP SEQUENCE IF EXISTS invite_id_seq CASCADE;
+CREATE SEQUENCE invite_id_seq;
+DROP TABLE IF EXISTS league_invite CASCADE;
+CREATE TABLE league_invite (
+  invite_id       INT           PRIMARY KEY DEFAULT nextval('invite_id_seq'),
+  league_id       INT           NOT NULL REFERENCES league(league_id) ON DELETE CASCADE,
+  token           CHAR(64)      UNIQUE NOT NULL  ,
+  email           VARCHAR(256)                   , -- NULL allows anyone with the token to join
+  max_uses        INT           NOT NULL         , -- 0 allows unlimited uses
+  uses            INT           NOT NULL DEFAULT 0,
+  expires_at      INT           NOT NULL         ,
+  revoked         BOOLEAN       NOT NULL DEFAULT FALSE,
+  created_by      INT           NOT NULL REFERENCES user_(user_id) ON DELETE CASCADE
+);
+ALTER SEQUENCE invite_id_seq OWNED BY league_invite.invite_id;
+
 DROP TABLE IF EXISTS team_permissions;
 CREATE TABLE team_permissions (
   user_id         INT           NOT NULL REFERENCES user_(user_id) ON DELETE CASCADE,
@@ -82,6 +172,43 @@ CREATE TABLE team_permissions (
   games           BOOLEAN       NOT NULL
 );
 
+DROP SEQUENCE IF EXISTS season_id_seq CASCADE;
+CREATE SEQUENCE season_id_seq;
+DROP TABLE IF EXISTS season CASCADE;
+CREATE TABLE season (
+  season_id       INT           PRIMARY KEY DEFAULT nextval('season_id_seq'),
+  league_id       INT           NOT NULL REFERENCES league(league_id) ON DELETE CASCADE,
+  name            VARCHAR(50)   NOT NULL                ,
+  season_start    INT           NOT NULL                ,
+  season_end      INT           NOT NULL                ,
+  current         BOOLEAN       NOT NULL DEFAULT FALSE  , -- games, availabilities and standings default to the current season
+  UNIQUE (league_id, name)
+);
+ALTER SEQUENCE season_id_seq OWNED BY season.season_id;
+CREATE UNIQUE INDEX season_current_idx ON season(league_id) WHERE current;
+
+-- Teams taking part in a season. Teams and their players carry over when a league rolls over to a new season
+DROP TABLE IF EXISTS season_team CASCADE;
+CREATE TABLE season_team (
+  season_id       INT           NOT NULL REFERENCES season(season_id) ON DELETE CASCADE,
+  team_id         INT           NOT NULL REFERENCES team(team_id) ON DELETE CASCADE,
+  withdrawn       BOOLEAN       NOT NULL DEFAULT FALSE, -- withdrew during the season, its games still count
+  PRIMARY KEY (season_id, team_id)
+);
+
+-- Stage that follows the group stage of a season. Its bracket is created once every group stage game is complete
+DROP TABLE IF EXISTS playoff_stage CASCADE;
+CREATE TABLE playoff_stage (
+  season_id       INT           PRIMARY KEY REFERENCES season(season_id) ON DELETE CASCADE,
+  tournament_type VARCHAR(20)   NOT NULL                , -- 'singleelimination' or 'doubleelimination'
+  advancing_per_group SMALLINT  NOT NULL                ,
+  rounds_per_week SMALLINT      NOT NULL                ,
+  concurrent_game_num SMALLINT  NOT NULL                ,
+  game_duration   SMALLINT      NOT NULL                ,
+  best_of         SMALLINT      NOT NULL DEFAULT 1      ,
+  generated       BOOLEAN       NOT NULL DEFAULT FALSE    -- set once the bracket games have been created
+);
+
 DROP SEQUENCE IF EXISTS game_id_seq CASCADE;
 CREATE SEQUENCE game_id_seq;
 DROP TABLE IF EXISTS game CASCADE;
@@ -89,24 +216,87 @@ CREATE TABLE game (
   game_id         INT           PRIMARY KEY DEFAULT nextval('game_id_seq'),
   external_id     VARCHAR(64)                           , -- For use by external applications
   league_id       INT                      NOT NULL REFERENCES league(league_id),
-  team1_id        INT                      NOT NULL REFERENCES team(team_id),
-  team2_id        INT                      NOT NULL REFERENCES team(team_id),
+  season_id       INT                      NOT NULL REFERENCES season(season_id),
+  team1_id        INT                               REFERENCES team(team_id), -- NULL until decided in brackets
+  team2_id        INT                               REFERENCES team(team_id),
   game_time       INT                      NOT NULL      ,
   complete        BOOLEAN                  NOT NULL      ,
-  winner_id       INT                      NOT NULL      ,
+  outcome         VARCHAR(14)              NOT NULL DEFAULT 'win', -- 'win', 'draw', 'forfeit' or 'doubleForfeit'
+  winner_id       INT                      NOT NULL      , -- 0 for draws and double forfeits
   loser_id        INT                      NOT NULL      ,
   score_team1     INT                      NOT NULL      ,
   score_team2     INT                      NOT NULL      ,
+  best_of         SMALLINT                 NOT NULL DEFAULT 1,
+  duration        SMALLINT                 NOT NULL DEFAULT 0, -- minutes the game was scheduled for, 0 if created by hand
+  playoff         BOOLEAN                  NOT NULL DEFAULT FALSE, -- game of the playoff stage of its season
   UNIQUE (league_id, external_id)
 );
 ALTER SEQUENCE game_id_seq OWNED BY game.game_id;
 
+DROP TABLE IF EXISTS series_game CASCADE;
+CREATE TABLE series_game (
+  game_id         INT           NOT NULL REFERENCES game(game_id) ON DELETE CASCADE,
+  game_number     SMALLINT      NOT NULL         ,
+  external_id     VARCHAR(64)                    , -- id of the played game in the external application
+  winner_id       INT           NOT NULL         ,
+  loser_id        INT           NOT NULL         ,
+  UNIQUE (game_id, game_number)                  ,
+  UNIQUE (game_id, external_id)
+);
+
+DROP TABLE IF EXISTS game_progression CASCADE;
+CREATE TABLE game_progression (
+  game_id         INT           NOT NULL REFERENCES game(game_id) ON DELETE CASCADE,
+  outcome         VARCHAR(6)    NOT NULL         , -- 'winner' or 'loser' of game_id advances
+  next_game_id    INT           NOT NULL REFERENCES game(game_id) ON DELETE CASCADE,
+  next_game_slot  SMALLINT      NOT NULL         , -- 1 for team1, 2 for team2
+  UNIQUE (next_game_id, next_game_slot)
+);
+
+DROP TABLE IF EXISTS game_result_submission CASCADE;
+CREATE TABLE game_result_submission (
+  game_id         INT           PRIMARY KEY REFERENCES game(game_id) ON DELETE CASCADE,
+  team_id         INT           NOT NULL REFERENCES team(team_id) ON DELETE CASCADE, -- team that submitted
+  user_id         INT           NOT NULL REFERENCES user_(user_id),
+  submitted_at    INT           NOT NULL         ,
+  outcome         VARCHAR(14)   NOT NULL         ,
+  winner_id       INT           NOT NULL         ,
+  loser_id        INT           NOT NULL         ,
+  score_team1     INT           NOT NULL         ,
+  score_team2     INT           NOT NULL         ,
+  disputed        BOOLEAN       NOT NULL DEFAULT FALSE,
+  dispute_reason  VARCHAR(500)
+);
+
+DROP SEQUENCE IF EXISTS amendment_id_seq CASCADE;
+CREATE SEQUENCE amendment_id_seq;
+DROP TABLE IF EXISTS game_result_amendment CASCADE;
+CREATE TABLE game_result_amendment (
+  amendment_id    INT           PRIMARY KEY DEFAULT nextval('amendment_id_seq'),
+  game_id         INT           NOT NULL REFERENCES game(game_id) ON DELETE CASCADE,
+  user_id         INT           NOT NULL REFERENCES user_(user_id),
+  reason          VARCHAR(500)  NOT NULL         ,
+  amended_at      INT           NOT NULL         ,
+  old_outcome     VARCHAR(14)   NOT NULL         ,
+  old_winner_id   INT           NOT NULL         ,
+  old_loser_id    INT           NOT NULL         ,
+  old_score_team1 INT           NOT NULL         ,
+  old_score_team2 INT           NOT NULL         ,
+  outcome         VARCHAR(14)   NOT NULL         ,
+  winner_id       INT           NOT NULL         ,
+  loser_id        INT           NOT NULL         ,
+  score_team1     INT           NOT NULL         ,
+  score_team2     INT           NOT NULL
+);
+ALTER SEQUENCE amendment_id_seq OWNED BY game_result_amendment.amendment_id;
+
 DROP SEQUENCE IF EXISTS availability_id_seq CASCADE;
 CREATE SEQUENCE availability_id_seq;
 DROP TABLE IF EXISTS availability CASCADE;
 CREATE TABLE availability (
   availability_id           INT           PRIMARY KEY DEFAULT nextval('availability_id_seq'),
   league_id                 INT           NOT NULL REFERENCES league(league_id),
+  season_id                 INT           NOT NULL REFERENCES season(season_id),
   start_time                INT           NOT NULL                ,
   end_time                  INT           NOT NULL                ,
   is_recurring_weekly       BOOLEAN       NOT NULL
@@ -122,3 +312,29 @@ CREATE TABLE weekly_recurrence (
   minute                    SMALLINT      NOT NULL                ,
   duration                  SMALLINT      NOT NULL
 );
+
+DROP SEQUENCE IF EXISTS blackout_id_seq CASCADE;
+CREATE SEQUENCE blackout_id_seq;
+DROP TABLE IF EXISTS team_blackout CASCADE;
+CREATE TABLE team_blackout (
+  blackout_id               INT           PRIMARY KEY DEFAULT nextval('blackout_id_seq'),
+  team_id                   INT           NOT NULL REFERENCES team(team_id) ON DELETE CASCADE,
+  start_time                INT           NOT NULL                ,
+  end_time                  INT           NOT NULL
+);
+ALTER SEQUENCE blackout_id_seq OWNED BY team_blackout.blackout_id;
+
+DROP TABLE IF EXISTS league_tiebreaker CASCADE;
+CREATE TABLE league_tiebreaker (
+  league_id                 INT           NOT NULL REFERENCES league(league_id) ON DELETE CASCADE,
+  priority                  SMALLINT      NOT NULL                ,
+  tiebreaker                VARCHAR(30)   NOT NULL                ,
+  PRIMARY KEY (league_id, priority)
+);
+
+DROP TABLE IF EXISTS league_required_position CASCADE;
+CREATE TABLE league_required_position (
+  league_id                 INT           NOT NULL REFERENCES league(league_id) ON DELETE CASCADE,
+  position                  VARCHAR(20)   NOT NULL                ,
+  PRIMARY KEY (league_id, position)
+);
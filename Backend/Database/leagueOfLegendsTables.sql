@@ -10,9 +10,22 @@ CREATE TABLE lol_tournament (
   UNIQUE (league_id, tournament_id)
 );
 
+-- How the tournament codes of the games of a league are created, leagues without a row use the defaults
+DROP TABLE IF EXISTS lol_tournament_settings CASCADE;
+CREATE TABLE lol_tournament_settings (
+  league_id           INT           PRIMARY KEY REFERENCES league(league_id),
+  region              VARCHAR(4)    NOT NULL DEFAULT 'NA'              ,
+  map_type            VARCHAR(32)   NOT NULL DEFAULT 'SUMMONERS_RIFT'  ,
+  pick_type           VARCHAR(32)   NOT NULL DEFAULT 'TOURNAMENT_DRAFT',
+  spectator_type      VARCHAR(32)   NOT NULL DEFAULT 'LOBBYONLY'       ,
+  team_size           SMALLINT      NOT NULL DEFAULT 5                 ,
+  restrict_to_rosters BOOLEAN       NOT NULL DEFAULT false
+);
+
 DROP TABLE IF EXISTS lol_champion_stats CASCADE;
 CREATE TABLE lol_champion_stats (
   league_id     INT           NOT NULL REFERENCES league(league_id),
+  season_id     INT           NOT NULL REFERENCES season(season_id),
   name          VARCHAR(16)   NOT NULL                ,
   picks         INT           NOT NULL                ,
   wins          INT           NOT NULL                ,
@@ -23,7 +36,8 @@ DROP TABLE IF EXISTS lol_player_stats CASCADE;
 CREATE TABLE lol_player_stats (
   id                VARCHAR(50)   NOT NULL                ,
   name              VARCHAR(16)   NOT NULL                ,
-  game_id           INT           NOT NULL REFERENCES game(game_id),
+  game_id           INT           NOT NULL REFERENCES game(game_id) ON DELETE CASCADE,
+  game_number       SMALLINT      NOT NULL DEFAULT 1      , -- game of the series the stats are from
   team_id           INT           NOT NULL REFERENCES team(team_id),
   league_id         INT           NOT NULL REFERENCES league(league_id),
   duration          FLOAT         NOT NULL                ,
@@ -41,7 +55,8 @@ CREATE TABLE lol_player_stats (
 DROP TABLE IF EXISTS lol_team_stats CASCADE;
 CREATE TABLE lol_team_stats (
   team_id           INT           NOT NULL REFERENCES team(team_id),
-  game_id           INT           NOT NULL REFERENCES game(game_id),
+  game_id           INT           NOT NULL REFERENCES game(game_id) ON DELETE CASCADE,
+  game_number       SMALLINT      NOT NULL DEFAULT 1      , -- game of the series the stats are from
   league_id         INT           NOT NULL REFERENCES league(league_id),
   duration          FLOAT         NOT NULL                ,
   side              INT           NOT NULL                , -- 100 blue 200 red
@@ -50,9 +65,39 @@ CREATE TABLE lol_team_stats (
   win               BOOLEAN       NOT NULL
 );
 
+-- Bans of each played game, kept so that champion stats can be reversed when the result of the game is amended
+DROP TABLE IF EXISTS lol_ban CASCADE;
+CREATE TABLE lol_ban (
+  game_id           INT           NOT NULL REFERENCES game(game_id) ON DELETE CASCADE,
+  game_number       SMALLINT      NOT NULL DEFAULT 1      ,
+  champion          VARCHAR(16)   NOT NULL
+);
+
 DROP TABLE IF EXISTS lol_game CASCADE;
 CREATE TABLE lol_game (
-  game_id           INT           NOT NULL REFERENCES game(game_id),
+  game_id           INT           NOT NULL REFERENCES game(game_id) ON DELETE CASCADE,
   tournament_code   VARCHAR(64)   NOT NULL         , -- unique in production
-  match_id          VARCHAR(64)
-);
\ No newline at end of file
+  match_id          VARCHAR(64)   UNIQUE           , -- riot match of the code that has been reported
+  team1_id          INT                            , -- teams and time of the game the code was created for,
+  team2_id          INT                            , -- the code is replaced once they change
+  game_time         INT
+);
+
+-- Tournament callbacks that could not be ingested, retried until max attempts
+DROP TABLE IF EXISTS lol_tournament_callback CASCADE;
+CREATE TABLE lol_tournament_callback (
+  match_id          VARCHAR(64)   PRIMARY KEY             ,
+  callback          TEXT          NOT NULL                , -- body of the callback as received
+  attempts          INT           NOT NULL DEFAULT 1      ,
+  next_attempt      INT           NOT NULL                , -- unix time
+  last_error        TEXT          NOT NULL DEFAULT ''
+);
+
+DROP TABLE IF EXISTS lol_summoner CASCADE;
+CREATE TABLE lol_summoner (
+  external_id       VARCHAR(50)   PRIMARY KEY             ,
+  game_identifier   VARCHAR(50)   NOT NULL                ,
+  rank              VARCHAR(4)    NOT NULL                ,
+  tier              VARCHAR(16)   NOT NULL                ,
+  updated           INT           NOT NULL                  -- unix time the summoner was fetched from the riot api
+);
@@ -9,6 +9,10 @@ create_league(
   league_start INT,
   league_end   INT,
   game         VARCHAR(30),
+  time_zone    VARCHAR(64),
+  result_confirmation_hours INT,
+  require_team_approval BOOLEAN,
+  withdrawal_policy VARCHAR(10),
   user_id      INT
 )
 RETURNS INT AS $$
@@ -23,7 +27,11 @@ RETURNS INT AS $$
       signup_end,
       league_start,
       league_end,
-      game
+      game,
+      time_zone,
+      result_confirmation_hours,
+      require_team_approval,
+      withdrawal_policy
     )
     VALUES (
       name,
@@ -35,7 +43,25 @@ RETURNS INT AS $$
       signup_end,
       league_start,
       league_end,
-      game
+      game,
+      time_zone,
+      result_confirmation_hours,
+      require_team_approval,
+      withdrawal_policy
+    );
+    INSERT INTO season(
+      league_id,
+      name,
+      season_start,
+      season_end,
+      current
+    )
+    VALUES (
+      currval('league_id_seq'),
+      'Season 1',
+      league_start,
+      league_end,
+      true
     );
     INSERT INTO league_permissions(
       user_id,
@@ -58,6 +84,7 @@ RETURNS INT AS $$
 $$ LANGUAGE plpgsql;
 
 
+-- Teams registered by users that are not league administrators await approval if the league requires it
 CREATE OR REPLACE FUNCTION
 create_team(
   league_id       INT,
@@ -69,7 +96,15 @@ create_team(
   user_id         INT
 )
 RETURNS INT AS $$
+  DECLARE pending BOOLEAN;
   BEGIN
+    SELECT league.require_team_approval AND NOT COALESCE((
+        SELECT league_permissions.administrator FROM league_permissions
+        WHERE league_permissions.league_id = create_team.league_id
+          AND league_permissions.user_id = create_team.user_id), FALSE)
+      INTO pending
+      FROM league WHERE league.league_id = create_team.league_id;
+
     INSERT INTO team(
       league_id,
       name,
@@ -78,7 +113,8 @@ RETURNS INT AS $$
       wins,
       losses,
       icon_small,
-      icon_large
+      icon_large,
+      pending
     )
     VALUES (
       league_id,
@@ -88,7 +124,8 @@ RETURNS INT AS $$
       0,
       0,
       icon_small,
-      icon_large
+      icon_large,
+      pending
     );
     INSERT INTO team_permissions(
       user_id,
@@ -104,14 +141,47 @@ RETURNS INT AS $$
       true,
       true
     );
+    INSERT INTO season_team(season_id, team_id)
+      SELECT season.season_id, currval('team_id_seq') FROM season
+      WHERE season.league_id = create_team.league_id AND season.current;
     RETURN currval('team_id_seq');
   END;
 $$ LANGUAGE plpgsql;
 
 
+CREATE OR REPLACE FUNCTION
+advance_bracket(
+  game_id         INT,
+  winner_id       INT,
+  loser_id        INT
+)
+RETURNS VOID AS $$
+  BEGIN
+    UPDATE game SET
+      team1_id = CASE WHEN progression.outcome = 'winner'
+        THEN advance_bracket.winner_id ELSE advance_bracket.loser_id END
+    FROM game_progression AS progression
+    WHERE progression.game_id = advance_bracket.game_id
+      AND progression.next_game_slot = 1
+      AND game.game_id = progression.next_game_id;
+
+    UPDATE game SET
+      team2_id = CASE WHEN progression.outcome = 'winner'
+        THEN advance_bracket.winner_id ELSE advance_bracket.loser_id END
+    FROM game_progression AS progression
+    WHERE progression.game_id = advance_bracket.game_id
+      AND progression.next_game_slot = 2
+      AND game.game_id = progression.next_game_id;
+  END;
+$$ LANGUAGE plpgsql;
+
+
+-- Draws and double forfeits have no winner or loser, and a double forfeit counts as a loss for both teams. The records
+-- of the teams only count games of the current season, so results of earlier seasons are changed without them
 CREATE OR REPLACE FUNCTION
 report_game(
   game_id         INT,
+  outcome         VARCHAR(14),
   winner_id       INT,
   loser_id        INT,
   score_team1     INT,
@@ -119,36 +189,194 @@ report_game(
 )
 RETURNS VOID AS $$
   DECLARE game_complete BOOLEAN;
+  DECLARE old_outcome VARCHAR(14);
   DECLARE old_winner_id INT;
   DECLARE old_loser_id INT;
+  DECLARE team1_id INT;
+  DECLARE team2_id INT;
+  DECLARE in_current_season BOOLEAN;
   BEGIN
-    SELECT game.complete, game.winner_id, game.loser_id INTO game_complete, old_winner_id, old_loser_id
-      FROM game WHERE game.game_id = report_game.game_id;
-    IF (game_complete = TRUE) THEN
+    SELECT game.complete, game.outcome, game.winner_id, game.loser_id, game.team1_id, game.team2_id, season.current
+      INTO game_complete, old_outcome, old_winner_id, old_loser_id, team1_id, team2_id, in_current_season
+      FROM game JOIN season ON season.season_id = game.season_id
+      WHERE game.game_id = report_game.game_id;
+    IF (game_complete = TRUE AND in_current_season = TRUE) THEN
       UPDATE team
         SET wins = wins - 1
       WHERE team_id = old_winner_id;
 
       UPDATE team
         SET losses = losses - 1
-      WHERE team_id = old_loser_id;
+      WHERE team_id = old_loser_id
+        OR (old_outcome = 'doubleForfeit' AND team.team_id IN (team1_id, team2_id));
     END IF;
 
     UPDATE game SET
       complete = TRUE,
+      outcome = report_game.outcome,
       winner_id = report_game.winner_id,
       loser_id = report_game.loser_id,
       score_team1 = report_game.score_team1,
       score_team2 = report_game.score_team2
     WHERE game.game_id = report_game.game_id;
 
-    UPDATE team
-      SET wins = wins + 1
-    WHERE team_id = winner_id;
+    IF (in_current_season = TRUE) THEN
+      UPDATE team
+        SET wins = wins + 1
+      WHERE team_id = winner_id;
+
+      UPDATE team
+        SET losses = losses + 1
+      WHERE team_id = loser_id
+        OR (report_game.outcome = 'doubleForfeit' AND team.team_id IN (team1_id, team2_id));
+    END IF;
+
+    DELETE FROM game_result_submission WHERE game_result_submission.game_id = report_game.game_id;
+
+    PERFORM advance_bracket(report_game.game_id, report_game.winner_id, report_game.loser_id);
+  END;
+$$ LANGUAGE plpgsql;
+
+-- The result submitted by one team becomes final once the opposing team confirms it
+CREATE OR REPLACE FUNCTION
+confirm_result_submission(
+  game_id         INT
+)
+RETURNS VOID AS $$
+  DECLARE submission game_result_submission%ROWTYPE;
+  BEGIN
+    SELECT * INTO submission FROM game_result_submission
+      WHERE game_result_submission.game_id = confirm_result_submission.game_id;
 
-    UPDATE team
-      SET losses = losses + 1
-    WHERE team_id = loser_id;
+    PERFORM report_game(submission.game_id, submission.outcome, submission.winner_id, submission.loser_id,
+                        submission.score_team1, submission.score_team2);
+  END;
+$$ LANGUAGE plpgsql;
+
+-- Confirms the results that have not been confirmed or disputed within the confirmation window of their league
+CREATE OR REPLACE FUNCTION
+confirm_expired_result_submissions()
+RETURNS INT AS $$
+  DECLARE expired_game_id INT;
+  DECLARE confirmed INT := 0;
+  BEGIN
+    FOR expired_game_id IN
+      SELECT submission.game_id FROM game_result_submission AS submission
+        JOIN game ON game.game_id = submission.game_id
+        JOIN league ON league.league_id = game.league_id
+      WHERE submission.disputed = FALSE
+        AND league.result_confirmation_hours > 0
+        AND submission.submitted_at + league.result_confirmation_hours * 3600 <= EXTRACT(EPOCH FROM NOW())
+    LOOP
+      PERFORM confirm_result_submission(expired_game_id);
+      confirmed := confirmed + 1;
+    END LOOP;
+    RETURN confirmed;
+  END;
+$$ LANGUAGE plpgsql;
+
+-- Withdrawn teams are archived instead of deleted so that the results of their games are kept. Their remaining
+-- games of the current season against a known opponent are forfeited to the opponent or cancelled according to the policy of the league,
+-- except for bracket games which are always forfeited so that the opponent advances. The team stays in the standings
+-- of the current season marked as withdrawn, so its results still count for its opponents
+CREATE OR REPLACE FUNCTION
+withdraw_team(
+  team_id         INT
+)
+RETURNS VOID AS $$
+  DECLARE policy VARCHAR(10);
+  DECLARE remaining RECORD;
+  BEGIN
+    SELECT league.withdrawal_policy INTO policy FROM league
+      JOIN team ON team.league_id = league.league_id
+    WHERE team.team_id = withdraw_team.team_id;
+
+    FOR remaining IN
+      SELECT game.game_id, game.team1_id, game.team2_id,
+          EXISTS(SELECT 1 FROM game_progression WHERE game_progression.game_id = game.game_id) AS bracket
+        FROM game
+      WHERE game.complete = FALSE
+        AND game.team1_id IS NOT NULL AND game.team2_id IS NOT NULL
+        AND withdraw_team.team_id IN (game.team1_id, game.team2_id)
+        AND game.season_id IN (SELECT season.season_id FROM season
+                               WHERE season.current AND season.league_id = game.league_id)
+    LOOP
+      IF (policy = 'cancel' AND NOT remaining.bracket) THEN
+        DELETE FROM game WHERE game.game_id = remaining.game_id;
+      ELSE
+        PERFORM report_game(remaining.game_id, 'forfeit',
+                            CASE WHEN remaining.team1_id = withdraw_team.team_id
+                              THEN remaining.team2_id ELSE remaining.team1_id END,
+                            withdraw_team.team_id, 0, 0);
+      END IF;
+    END LOOP;
+
+    UPDATE team SET archived = TRUE WHERE team.team_id = withdraw_team.team_id;
+    UPDATE season_team SET withdrawn = TRUE WHERE season_team.team_id = withdraw_team.team_id
+      AND season_team.season_id IN (SELECT season.season_id FROM season WHERE season.current);
+    DELETE FROM team_permissions WHERE team_permissions.team_id = withdraw_team.team_id;
+    DELETE FROM roster_request WHERE roster_request.team_id = withdraw_team.team_id;
+  END;
+$$ LANGUAGE plpgsql;
+
+-- Replaces the result of a completed game, logging the previous result. Statistics recorded for the played
+-- games no longer match the result so they are removed along with their champion picks, wins and bans, and
+-- report_game reverses the previous wins and losses. The new result has no played games, so it adds no champion
+-- statistics
+CREATE OR REPLACE FUNCTION
+amend_game_result(
+  game_id         INT,
+  user_id         INT,
+  reason          VARCHAR(500),
+  outcome         VARCHAR(14),
+  winner_id       INT,
+  loser_id        INT,
+  score_team1     INT,
+  score_team2     INT
+)
+RETURNS INT AS $$
+  DECLARE v_amendment_id INT;
+  BEGIN
+    INSERT INTO game_result_amendment(game_id, user_id, reason, amended_at, old_outcome, old_winner_id, old_loser_id,
+                                      old_score_team1, old_score_team2, outcome, winner_id, loser_id,
+                                      score_team1, score_team2)
+      SELECT game.game_id, amend_game_result.user_id, amend_game_result.reason, EXTRACT(EPOCH FROM NOW())::INT,
+             game.outcome, game.winner_id, game.loser_id, game.score_team1, game.score_team2,
+             amend_game_result.outcome, amend_game_result.winner_id, amend_game_result.loser_id,
+             amend_game_result.score_team1, amend_game_result.score_team2
+      FROM game WHERE game.game_id = amend_game_result.game_id
+    RETURNING game_result_amendment.amendment_id INTO v_amendment_id;
+
+    UPDATE lol_champion_stats SET
+      picks = lol_champion_stats.picks - reversed.picks,
+      wins = lol_champion_stats.wins - reversed.wins
+    FROM (
+      SELECT lol_player_stats.champion_picked AS name, COUNT(*) AS picks,
+             COUNT(*) FILTER (WHERE lol_player_stats.win) AS wins
+        FROM lol_player_stats WHERE lol_player_stats.game_id = amend_game_result.game_id
+      GROUP BY lol_player_stats.champion_picked
+    ) AS reversed
+    WHERE lol_champion_stats.name = reversed.name
+      AND lol_champion_stats.season_id = (SELECT game.season_id FROM game WHERE game.game_id = amend_game_result.game_id);
+
+    UPDATE lol_champion_stats SET
+      bans = lol_champion_stats.bans - reversed.bans
+    FROM (
+      SELECT lol_ban.champion AS name, COUNT(*) AS bans
+        FROM lol_ban WHERE lol_ban.game_id = amend_game_result.game_id
+      GROUP BY lol_ban.champion
+    ) AS reversed
+    WHERE lol_champion_stats.name = reversed.name
+      AND lol_champion_stats.season_id = (SELECT game.season_id FROM game WHERE game.game_id = amend_game_result.game_id);
+
+    DELETE FROM lol_ban WHERE lol_ban.game_id = amend_game_result.game_id;
+    DELETE FROM lol_player_stats WHERE lol_player_stats.game_id = amend_game_result.game_id;
+    DELETE FROM lol_team_stats WHERE lol_team_stats.game_id = amend_game_result.game_id;
+    DELETE FROM series_game WHERE series_game.game_id = amend_game_result.game_id;
+
+    PERFORM report_game(amend_game_result.game_id, amend_game_result.outcome, amend_game_result.winner_id,
+                        amend_game_result.loser_id, amend_game_result.score_team1, amend_game_result.score_team2);
+    RETURN v_amendment_id;
   END;
 $$ LANGUAGE plpgsql;
 
@@ -164,39 +392,60 @@ report_game_by_external_id(
 RETURNS SETOF league_game_ids AS $$
   DECLARE league_id INT;
   DECLARE game_id INT;
-  DECLARE game_complete BOOLEAN;
-  DECLARE old_winner_id INT;
-  DECLARE old_loser_id INT;
   BEGIN
-    SELECT game.league_id, game.game_id, game.complete, game.winner_id, game.loser_id INTO league_id, game_id, game_complete, old_winner_id, old_loser_id
+    SELECT game.league_id, game.game_id INTO league_id, game_id
       FROM game WHERE game.external_id = report_game_by_external_id.external_id;
-    IF (game_complete = TRUE) THEN
-      UPDATE team
-        SET wins = wins - 1
-      WHERE team_id = old_winner_id;
 
-      UPDATE team
-        SET losses = losses - 1
-      WHERE team_id = old_loser_id;
-    END IF;
+    PERFORM report_game(game_id, 'win', report_game_by_external_id.winner_id, report_game_by_external_id.loser_id,
+                        report_game_by_external_id.score_team1, report_game_by_external_id.score_team2);
 
-    UPDATE game SET
-      complete = TRUE,
-      winner_id = report_game_by_external_id.winner_id,
-      loser_id = report_game_by_external_id.loser_id,
-      score_team1 = report_game_by_external_id.score_team1,
-      score_team2 = report_game_by_external_id.score_team2
-    WHERE game.external_id = report_game_by_external_id.external_id;
+    RETURN QUERY (SELECT league_id, game_id);
+  END;
+$$ LANGUAGE plpgsql;
 
-    UPDATE team
-      SET wins = wins + 1
-    WHERE team_id = winner_id;
+CREATE TYPE series_game_result AS (league_id INT, game_id INT, game_number INT, complete BOOLEAN);
+CREATE OR REPLACE FUNCTION
+report_series_game(
+  external_id          VARCHAR(64),
+  series_external_id   VARCHAR(64),
+  winner_id            INT,
+  loser_id             INT
+)
+RETURNS SETOF series_game_result AS $$
+  DECLARE v_league_id INT;
+  DECLARE v_game_id INT;
+  DECLARE v_team1_id INT;
+  DECLARE v_best_of SMALLINT;
+  DECLARE v_game_number INT;
+  DECLARE v_wins_team1 INT;
+  DECLARE v_wins_team2 INT;
+  BEGIN
+    SELECT game.league_id, game.game_id, game.team1_id, game.best_of
+      INTO v_league_id, v_game_id, v_team1_id, v_best_of
+      FROM game WHERE game.external_id = report_series_game.external_id;
 
-    UPDATE team
-      SET losses = losses + 1
-    WHERE team_id = loser_id;
+    SELECT COUNT(*) + 1 INTO v_game_number FROM series_game WHERE series_game.game_id = v_game_id;
 
-    RETURN QUERY (SELECT league_id, game_id);
+    INSERT INTO series_game(game_id, game_number, external_id, winner_id, loser_id)
+      VALUES (v_game_id, v_game_number, report_series_game.series_external_id,
+              report_series_game.winner_id, report_series_game.loser_id);
+
+    SELECT COUNT(*) FILTER (WHERE series_game.winner_id = v_team1_id),
+           COUNT(*) FILTER (WHERE series_game.winner_id <> v_team1_id)
+      INTO v_wins_team1, v_wins_team2
+      FROM series_game WHERE series_game.game_id = v_game_id;
+
+    -- The match is decided once a team has won more than half of the games it can last
+    IF (GREATEST(v_wins_team1, v_wins_team2) > v_best_of / 2) THEN
+      PERFORM report_game(v_game_id, 'win', report_series_game.winner_id, report_series_game.loser_id,
+                          v_wins_team1, v_wins_team2);
+      RETURN QUERY (SELECT v_league_id, v_game_id, v_game_number, TRUE);
+    ELSE
+      UPDATE game SET
+        score_team1 = v_wins_team1,
+        score_team2 = v_wins_team2
+      WHERE game.game_id = v_game_id;
+      RETURN QUERY (SELECT v_league_id, v_game_id, v_game_number, FALSE);
+    END IF;
   END;
 $$ LANGUAGE plpgsql;
-
@@ -100,6 +100,46 @@ class League:
         })
         t.assertEqual(200, r.status_code)
 
+    def update_withdrawal_policy(self, t, withdrawal_policy):
+        r = t.http.put("http://localhost:8080/api/v1/leagues", json={
+            "name": self.name,
+            "description": self.description,
+            "game": self.game,
+            "publicView": self.public_view,
+            "publicJoin": self.public_join,
+            "signupStart": int(self.signup_start.timestamp()),
+            "signupEnd": int(self.signup_end.timestamp()),
+            "leagueStart": int(self.league_start.timestamp()),
+            "leagueEnd": int(self.league_end.timestamp()),
+            "withdrawalPolicy": withdrawal_policy
+        })
+        t.assertEqual(200, r.status_code)
+
+    def rollover_to_new_season(self, t, team_ids=None):
+        season_start = self.league_end + timedelta(weeks=1)
+        season_end = self.league_end + timedelta(weeks=5)
+        r = t.http.post("http://localhost:8080/api/v1/seasons", json={
+            "name": fake.slug(),
+            "seasonStart": int(season_start.timestamp()),
+            "seasonEnd": int(season_end.timestamp())
+        })
+        t.assertEqual(201, r.status_code)
+        season_id = r.json()["seasonId"]
+
+        r = t.http.post("http://localhost:8080/api/v1/seasons/{}/rollover".format(season_id), json={
+            "teamIds": team_ids if team_ids is not None else []
+        })
+        t.assertEqual(200, r.status_code)
+
+        # the new season becomes the competition period, and carried over teams start it without a record
+        self.league_start = season_start
+        self.league_end = season_end
+        self.teams = [team for team in self.teams if team_ids is None or team.team_id in team_ids]
+        for team in self.teams:
+            team.wins = 0
+            team.losses = 0
+        return season_id
+
     def create_team(self, t, manager, name=None, tag=None):
         new_team = Team(t, self, manager, random.randint(0, 100), name, tag)
         self.teams.append(new_team)
@@ -356,6 +356,99 @@ class TestElmApi(unittest.TestCase):
         # can reschedule correctly
         game1.reschedule(self, epoch_time + 7200)
 
+    def test_season_rollover(self):
+        # set up league
+        league_owner = User(self)
+        self.login(league_owner)
+        league = League(self)
+        self.set_active_league(league)
+        league.update_to_middle_of_competition_time(self)
+
+        # play two games in the current season
+        team1 = league.create_team(self, league_owner, "TEAM1", "TAG1")
+        team2 = league.create_team(self, league_owner, "TEAM2", "TAG2")
+        team3 = league.create_team(self, league_owner, "TEAM3", "TAG3")
+        epoch_time = int(datetime.utcnow().timestamp())
+        game1 = league.create_game(self, team1.team_id, team2.team_id, epoch_time - 7200)
+        game2 = league.create_game(self, team2.team_id, team3.team_id, epoch_time - 3600)
+        for game in [game1, game2]:
+            game.decide_result_and_report(self, league.teams)
+            league.get_team(game.winner_id).wins += 1
+            league.get_team(game.loser_id).losses += 1
+        self.check_all_teams(league)
+
+        # roll over to the next season without team3
+        league.rollover_to_new_season(self, [team1.team_id, team2.team_id])
+
+        # teams carried over start the season without a record, and the team left behind is archived
+        self.check_all_teams(league)
+        league.assert_server_data_consistent(self)
+
+        r = self.http.get("http://localhost:8080/api/v1/archivedTeams")
+        self.assertEqual(200, r.status_code)
+        self.assertEqual([team3.team_id], [team["teamId"] for team in r.json()])
+
+        # the previous season keeps its results
+        r = self.http.get("http://localhost:8080/api/v1/games/{}".format(game1.game_id))
+        self.assertEqual(200, r.status_code)
+        self.assertEqual(game1.winner_id, r.json()["winnerId"])
+
+    def withdraw_team_in_new_season(self, withdrawal_policy):
+        # set up league
+        league_owner = User(self)
+        self.login(league_owner)
+        league = League(self)
+        self.set_active_league(league)
+        league.update_to_middle_of_competition_time(self)
+        league.update_withdrawal_policy(self, withdrawal_policy)
+
+        # team1 has a game left over from the previous season and one in the new season
+        team1 = league.create_team(self, league_owner, "TEAM1", "TAG1")
+        team2 = league.create_team(self, league_owner, "TEAM2", "TAG2")
+        team3 = league.create_team(self, league_owner, "TEAM3", "TAG3")
+        epoch_time = int(datetime.utcnow().timestamp())
+        previous_game = league.create_game(self, team1.team_id, team2.team_id, epoch_time + 3600)
+        league.rollover_to_new_season(self)
+        current_game = league.create_game(self, team1.team_id, team3.team_id,
+                                          int(league.league_start.timestamp()) + 3600)
+
+        r = self.http.delete("http://localhost:8080/api/v1/teams/{}".format(team1.team_id))
+        self.assertEqual(200, r.status_code)
+
+        # the game of the previous season is left as it was
+        r = self.http.get("http://localhost:8080/api/v1/games/{}".format(previous_game.game_id))
+        self.assertEqual(200, r.status_code)
+        self.assertEqual(False, r.json()["complete"])
+
+        r = self.http.get("http://localhost:8080/api/v1/archivedTeams")
+        self.assertEqual(200, r.status_code)
+        self.assertEqual([team1.team_id], [team["teamId"] for team in r.json()])
+        return current_game, team3
+
+    def test_withdraw_team_forfeit(self):
+        current_game, opponent = self.withdraw_team_in_new_season("forfeit")
+
+        r = self.http.get("http://localhost:8080/api/v1/games/{}".format(current_game.game_id))
+        self.assertEqual(200, r.status_code)
+        self.assertEqual(True, r.json()["complete"])
+        self.assertEqual("forfeit", r.json()["outcome"])
+        self.assertEqual(opponent.team_id, r.json()["winnerId"])
+
+        r = self.http.get("http://localhost:8080/api/v1/teams/{}".format(opponent.team_id))
+        self.assertEqual(200, r.status_code)
+        self.assertEqual(1, r.json()["wins"])
+
+    def test_withdraw_team_cancel(self):
+        current_game, opponent = self.withdraw_team_in_new_season("cancel")
+
+        r = self.http.get("http://localhost:8080/api/v1/games")
+        self.assertEqual(200, r.status_code)
+        self.assertNotIn(current_game.game_id, [game["gameId"] for game in r.json()])
+
+        r = self.http.get("http://localhost:8080/api/v1/teams/{}".format(opponent.team_id))
+        self.assertEqual(200, r.status_code)
+        self.assertEqual(0, r.json()["wins"])
+
     def test_game_permissions(self):
         # Create league
         league_owner = User(self)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 )
 
 type Config interface {
@@ -16,6 +17,13 @@ type Config interface {
 	GetKeys() ([]byte, []byte)
 
 	GetLeagueOfLegendsApiKey() string
+	GetLeagueOfLegendsApiUrl() string
+	GetDataDragonUrl() string
+	GetSummonerCacheTtl() time.Duration
+	GetPersistSummonerCache() bool
+	GetTournamentCallbackUrl() string
+	GetTournamentCallbackSecret() string
+	GetTournamentCodeLeadTime() time.Duration
 }
 
 type Configuration struct {
@@ -29,6 +37,14 @@ type Configuration struct {
 	EncryptionKey string `json:"encryptionKey"`
 
 	LeagueOfLegendsApiKey string `json:"leagueOfLegendsApiKey"`
+	LeagueOfLegendsApiUrl string `json:"leagueOfLegendsApiUrl"`
+	DataDragonUrl         string `json:"dataDragonUrl"`
+	SummonerCacheTtl      int    `json:"summonerCacheTtl"`
+	PersistSummonerCache  bool   `json:"persistSummonerCache"`
+
+	TournamentCallbackUrl    string `json:"tournamentCallbackUrl"`
+	TournamentCallbackSecret string `json:"tournamentCallbackSecret"`
+	TournamentCodeLeadTime   int    `json:"tournamentCodeLeadTime"`
 }
 
 func (c *Configuration) GetDbConnString() string {
@@ -57,6 +73,53 @@ func (c *Configuration) GetLeagueOfLegendsApiKey() string {
 	return c.LeagueOfLegendsApiKey
 }
 
+// Base url of the regional Riot API platform, for example https://euw1.api.riotgames.com
+func (c *Configuration) GetLeagueOfLegendsApiUrl() string {
+	if c.LeagueOfLegendsApiUrl == "" {
+		return "https://na1.api.riotgames.com"
+	}
+	return c.LeagueOfLegendsApiUrl
+}
+
+// Base url of Data Dragon, which maps champion ids to champion names
+func (c *Configuration) GetDataDragonUrl() string {
+	if c.DataDragonUrl == "" {
+		return "https://ddragon.leagueoflegends.com"
+	}
+	return c.DataDragonUrl
+}
+
+// Number of seconds the rank and tier of a summoner are reused before they are fetched from the Riot API again
+func (c *Configuration) GetSummonerCacheTtl() time.Duration {
+	if c.SummonerCacheTtl <= 0 {
+		return time.Hour
+	}
+	return time.Duration(c.SummonerCacheTtl) * time.Second
+}
+
+// Whether cached summoners are also stored in the database, so that they survive restarts
+func (c *Configuration) GetPersistSummonerCache() bool {
+	return c.PersistSummonerCache
+}
+
+// Public url of the tournament callback endpoint, registered with the Riot tournament API as the provider url
+func (c *Configuration) GetTournamentCallbackUrl() string {
+	return c.TournamentCallbackUrl
+}
+
+// Secret the provider url is registered with, which tournament callbacks must present to be accepted
+func (c *Configuration) GetTournamentCallbackSecret() string {
+	return c.TournamentCallbackSecret
+}
+
+// Number of hours before a game of a league registered as a tournament that its tournament code is created
+func (c *Configuration) GetTournamentCodeLeadTime() time.Duration {
+	if c.TournamentCodeLeadTime <= 0 {
+		return 48 * time.Hour
+	}
+	return time.Duration(c.TournamentCodeLeadTime) * time.Hour
+}
+
 func GetConfig(location string) Config {
 	file, err := os.Open(location)
 	if err != nil {
@@ -0,0 +1,135 @@
+package dataModel
+
+import "strings"
+
+// A group of teams within a league. Round robin schedules are generated within each division, and standings and
+// games can be filtered by division
+type DivisionCore struct {
+	Name string `json:"name"`
+}
+
+type Division struct {
+	DivisionId int            `json:"divisionId"`
+	Name       string         `json:"name"`
+	Teams      []*TeamDisplay `json:"teams"`
+}
+
+// Replaces the teams of a division. Teams in another division are moved to this one
+type DivisionTeams struct {
+	TeamIds []int `json:"teamIds"`
+}
+
+func (division *DivisionCore) validate(leagueId, divisionId int, leagueDao LeagueDAO) (bool, string, error) {
+	return validate(
+		division.name(),
+		division.uniqueness(leagueId, divisionId, leagueDao))
+}
+
+func (division *DivisionCore) ValidateNew(leagueId int, leagueDao LeagueDAO) (bool, string, error) {
+	return division.validate(leagueId, 0, leagueDao)
+}
+
+func (division *DivisionCore) ValidateEdit(leagueId, divisionId int, leagueDao LeagueDAO) (bool, string, error) {
+	return division.validate(leagueId, divisionId, leagueDao)
+}
+
+func (division *DivisionCore) name() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if len(division.Name) > MaxNameLength {
+			*problemDest = NameTooLong
+			return false
+		} else if len(division.Name) < MinInformationLength {
+			*problemDest = NameTooShort
+			return false
+		}
+		return true
+	}
+}
+
+func (division *DivisionCore) uniqueness(leagueId, divisionId int, leagueDao LeagueDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		inUse, err := leagueDao.IsDivisionNameInUse(leagueId, divisionId, division.Name)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if inUse {
+			*problemDest = DivisionNameInUse
+			return false
+		}
+		return true
+	}
+}
+
+func (teams *DivisionTeams) Validate(leagueId int, teamDao TeamDAO) (bool, string, error) {
+	return validate(teams.teams(leagueId, teamDao))
+}
+
+func (teams *DivisionTeams) teams(leagueId int, teamDao TeamDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		selected := make(map[int]bool)
+		for _, teamId := range teams.TeamIds {
+			if selected[teamId] {
+				*problemDest = DivisionTeamRepeated
+				return false
+			}
+			selected[teamId] = true
+
+			exists, err := teamDao.DoesTeamExistInLeague(leagueId, teamId)
+			if err != nil {
+				*errorDest = err
+				return false
+			} else if !exists {
+				*problemDest = DivisionTeamDoesNotExist
+				return false
+			}
+
+			archived, err := teamDao.IsTeamArchived(leagueId, teamId)
+			if err != nil {
+				*errorDest = err
+				return false
+			} else if archived {
+				*problemDest = DivisionTeamArchived
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Leagues with divisions schedule round robins and seed playoffs within each division, so a team outside of every
+// division would have no one to play. The problem lists the names of those teams
+func validateTeamsInDivisions(leagueId int, leagueDao LeagueDAO, teamDao TeamDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		divisions, err := leagueDao.GetDivisions(leagueId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if len(divisions) == 0 {
+			return true
+		}
+
+		inDivision := make(map[int]bool)
+		for _, division := range divisions {
+			for _, team := range division.Teams {
+				inDivision[team.TeamId] = true
+			}
+		}
+
+		teams, err := teamDao.GetAllTeamDisplaysInLeague(leagueId)
+		if err != nil {
+			*errorDest = err
+			return false
+		}
+		var unassigned []string
+		for _, team := range teams {
+			if !inDivision[team.TeamId] {
+				unassigned = append(unassigned, team.Name)
+			}
+		}
+		if len(unassigned) > 0 {
+			*problemDest = TeamsWithoutDivision + strings.Join(unassigned, ", ")
+			return false
+		}
+		return true
+	}
+}
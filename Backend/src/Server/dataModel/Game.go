@@ -1,25 +1,46 @@
 package dataModel
 
+import (
+	"strings"
+	"time"
+)
+
 type GameDAO interface {
 	// Modify Games
 	CreateGame(leagueId int, gameInformation GameCreationInformation) (int, error)
+	CreateGames(leagueId int, games []GameCreationInformation) ([]int, error)
+	CreateBracket(leagueId int, games []BracketGameCreationInformation) ([]int, error)
 	ReportGame(gameId int, gameResult GameResult) error
 	ReportGameByExternalId(externalId string, gameResult GameResult) (int, int, error)
+	AmendGameResult(gameId, userId int, amendment GameResultAmendment) (int, error)
 	DeleteGame(gameId int) error
 	RescheduleGame(gameId, gameTime int) error
 	AddExternalId(gameId int, externalId string) error
 
 	// Get Game Information
 	GetAllGamesInLeague(leagueId int) ([]*Game, error)
-	GetSortedGames(leagueId, teamId, limit int) (*SortedGames, error)
-	GetGamesByWeek(leagueId, timeZone int) ([]*CompetitionWeek, error)
+	GetAllGamesInSeason(seasonId int) ([]*Game, error)
+	GetSortedGames(seasonId, teamId, limit int) (*SortedGames, error)
+	GetGamesByWeek(seasonId, divisionId int, location *time.Location) ([]*CompetitionWeek, error)
 	GetGameInformation(gameId int) (*Game, error)
 	GetGameInformationFromExternalId(externalId string) (*Game, error)
+	GetSeriesGames(gameId int) ([]*SeriesGame, error)
+	GetResultAmendments(gameId int) ([]*ResultAmendment, error)
 	DoesGameExistInLeague(leagueId, gameId int) (bool, error)
 
 	// Get Information for Games Management
 	DoesExistConflict(team1Id, team2Id, gameTime int) (bool, error)
 	HasReportResultPermissions(leagueId, gameId, userId int) (bool, error)
+	HasCompletedNextGame(gameId int) (bool, error)
+	IsBracketGame(gameId int) (bool, error)
+
+	// Result Confirmation
+	SubmitGameResult(gameId, teamId, userId int, gameResult GameResult) error
+	GetResultSubmission(gameId int) (*ResultSubmission, error)
+	ConfirmResultSubmission(gameId int) error
+	DisputeResultSubmission(gameId int, reason string) error
+	GetDisputedResultSubmissions(leagueId int) ([]*ResultSubmission, error)
+	ConfirmExpiredResultSubmissions() (int, error)
 }
 
 type GameTime struct {
@@ -39,18 +60,32 @@ func (gameTime *GameTime) Validate(leagueId, gameId int, leagueDao LeagueDAO, te
 	}
 }
 
+// Duration and Playoff are set by the scheduler for the games it creates
 type GameCreationInformation struct {
-	Team1Id  int `json:"team1Id"`
-	Team2Id  int `json:"team2Id"`
-	GameTime int `json:"gameTime"`
+	Team1Id  int  `json:"team1Id"`
+	Team2Id  int  `json:"team2Id"`
+	GameTime int  `json:"gameTime"`
+	BestOf   int  `json:"bestOf"`
+	Duration int  `json:"-"`
+	Playoff  bool `json:"-"`
+}
+
+// Games without a series length are played as a single game
+func (game GameCreationInformation) SeriesLength() int {
+	if game.BestOf == 0 {
+		return 1
+	}
+	return game.BestOf
 }
 
 func (game GameCreationInformation) validate(leagueId, gameId int, leagueDao LeagueDAO, teamDao TeamDAO, gameDao GameDAO) (bool, string, error) {
 	return validate(
 		game.differentTeams(),
 		game.teamsExist(leagueId, teamDao),
+		game.teamsParticipating(leagueId, teamDao),
 		game.noConflict(leagueId, gameId, gameDao),
-		validateDuringLeague(leagueId, game.GameTime, leagueDao, GameNotDuringLeague))
+		validateDuringLeague(leagueId, game.GameTime, leagueDao, GameNotDuringLeague),
+		game.bestOf())
 }
 
 func (game GameCreationInformation) Validate(leagueId int, leagueDao LeagueDAO, teamDao TeamDAO, gameDao GameDAO) (bool, string, error) {
@@ -61,6 +96,18 @@ func (game GameCreationInformation) ValidateReschedule(leagueId, gameId int, lea
 	return game.validate(leagueId, gameId, leagueDao, teamDao, gameDao)
 }
 
+func (game *GameCreationInformation) bestOf() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		switch game.SeriesLength() {
+		case 1, 3, 5:
+			return true
+		default:
+			*problemDest = InvalidBestOf
+			return false
+		}
+	}
+}
+
 func (game *GameCreationInformation) differentTeams() ValidateFunc {
 	return func(problemDest *string, _ *error) bool {
 		if game.Team1Id == game.Team2Id {
@@ -90,6 +137,31 @@ func (game *GameCreationInformation) teamsExist(leagueId int, teamDao TeamDAO) V
 	}
 }
 
+func (game *GameCreationInformation) teamsParticipating(leagueId int, teamDao TeamDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		for _, teamId := range []int{game.Team1Id, game.Team2Id} {
+			pending, err := teamDao.IsTeamPending(leagueId, teamId)
+			if err != nil {
+				*errorDest = err
+				return false
+			} else if pending {
+				*problemDest = TeamInGamePending
+				return false
+			}
+
+			archived, err := teamDao.IsTeamArchived(leagueId, teamId)
+			if err != nil {
+				*errorDest = err
+				return false
+			} else if archived {
+				*problemDest = TeamInGameWithdrawn
+				return false
+			}
+		}
+		return true
+	}
+}
+
 func (game *GameCreationInformation) noConflict(leagueId, gameId int, gameDao GameDAO) ValidateFunc {
 	return func(problemDest *string, errorDest *error) bool {
 		allGames, err := gameDao.GetAllGamesInLeague(leagueId)
@@ -112,17 +184,53 @@ func (game *GameCreationInformation) noConflict(leagueId, gameId int, gameDao Ga
 	}
 }
 
+// A team id of 0 means the slot is filled by the result of the game referenced in the matching source
+type BracketGameCreationInformation struct {
+	GameCreationInformation
+	Team1Source *GameSource
+	Team2Source *GameSource
+}
+
 type GameCore struct {
 	GameTime int         `json:"gameTime"`
 	Team1    TeamDisplay `json:"team1"`
 	Team2    TeamDisplay `json:"team2"`
 }
 
+// Outcomes of a game. Draws and double forfeits have no winner or loser, and forfeits are reported without scores
+const (
+	WinOutcome           = "win"
+	DrawOutcome          = "draw"
+	ForfeitOutcome       = "forfeit"
+	DoubleForfeitOutcome = "doubleForfeit"
+)
+
 type GameResult struct {
-	WinnerId   int `json:"winnerId"`
-	LoserId    int `json:"loserId"`
-	ScoreTeam1 int `json:"scoreTeam1"`
-	ScoreTeam2 int `json:"scoreTeam2"`
+	Outcome    string `json:"outcome"`
+	WinnerId   int    `json:"winnerId"`
+	LoserId    int    `json:"loserId"`
+	ScoreTeam1 int    `json:"scoreTeam1"`
+	ScoreTeam2 int    `json:"scoreTeam2"`
+}
+
+func (gameResult *GameResult) OutcomeName() string {
+	if gameResult.Outcome == "" {
+		return WinOutcome
+	} else {
+		return gameResult.Outcome
+	}
+}
+
+func (gameResult *GameResult) HasWinner() bool {
+	return gameResult.OutcomeName() == WinOutcome || gameResult.OutcomeName() == ForfeitOutcome
+}
+
+func (gameResult *GameResult) Scores() (int, int) {
+	if gameResult.OutcomeName() == ForfeitOutcome || gameResult.OutcomeName() == DoubleForfeitOutcome {
+		return 0, 0
+	} else {
+		return gameResult.ScoreTeam1, gameResult.ScoreTeam2
+	}
 }
 
 func (gameResult *GameResult) Validate(gameId int, gameDao GameDAO) (bool, string, error) {
@@ -130,7 +238,14 @@ func (gameResult *GameResult) Validate(gameId int, gameDao GameDAO) (bool, strin
 	if err != nil {
 		return false, "", err
 	} else {
-		return validate(gameResult.hasReportedTeams(gameInformation))
+		return validate(
+			gameResult.notComplete(gameInformation),
+			gameResult.outcome(),
+			gameResult.teamsDetermined(gameInformation),
+			gameResult.hasReportedTeams(gameInformation),
+			gameResult.seriesScore(gameInformation),
+			gameResult.drawScore(),
+			gameResult.outcomeAllowed(gameInformation, gameDao))
 	}
 }
 
@@ -139,13 +254,44 @@ func (gameResult *GameResult) ValidateByExternalId(externalGameId string, gameDa
 	if err != nil {
 		return false, "", err
 	} else {
-		return validate(gameResult.hasReportedTeams(gameInformation))
+		return validate(
+			gameResult.teamsDetermined(gameInformation),
+			gameResult.hasReportedTeams(gameInformation),
+			gameResult.seriesNotDecided(gameInformation))
+	}
+}
+
+func (gameResult *GameResult) teamsDetermined(gameInformation *Game) ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if gameInformation.Team1.TeamId == 0 || gameInformation.Team2.TeamId == 0 {
+			*problemDest = GameTeamsNotDetermined
+			return false
+		} else {
+			return true
+		}
+	}
+}
+
+func (gameResult *GameResult) outcome() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		switch gameResult.OutcomeName() {
+		case WinOutcome, DrawOutcome, ForfeitOutcome, DoubleForfeitOutcome:
+			return true
+		default:
+			*problemDest = InvalidOutcome
+			return false
+		}
 	}
 }
 
 func (gameResult *GameResult) hasReportedTeams(gameInformation *Game) ValidateFunc {
 	return func(problemDest *string, _ *error) bool {
-		if (gameInformation.Team1.TeamId == gameResult.WinnerId &&
+		if !gameResult.HasWinner() && (gameResult.WinnerId != 0 || gameResult.LoserId != 0) {
+			*problemDest = OutcomeHasNoWinner
+			return false
+		} else if !gameResult.HasWinner() {
+			return true
+		} else if (gameInformation.Team1.TeamId == gameResult.WinnerId &&
 			gameInformation.Team2.TeamId == gameResult.LoserId) ||
 			(gameInformation.Team2.TeamId == gameResult.WinnerId &&
 				gameInformation.Team1.TeamId == gameResult.LoserId) {
@@ -157,6 +303,261 @@ func (gameResult *GameResult) hasReportedTeams(gameInformation *Game) ValidateFu
 	}
 }
 
+// The winner of a series must have won the majority of its games, and the loser fewer
+func (gameResult *GameResult) seriesScore(gameInformation *Game) ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if gameInformation.BestOf <= 1 || gameResult.OutcomeName() != WinOutcome {
+			return true
+		}
+		winnerScore, loserScore := gameResult.ScoreTeam1, gameResult.ScoreTeam2
+		if gameResult.WinnerId == gameInformation.Team2.TeamId {
+			winnerScore, loserScore = loserScore, winnerScore
+		}
+		if winnerScore != gameInformation.BestOf/2+1 || loserScore < 0 || loserScore >= winnerScore {
+			*problemDest = SeriesScoreInvalid
+			return false
+		} else {
+			return true
+		}
+	}
+}
+
+func (gameResult *GameResult) drawScore() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if gameResult.OutcomeName() == DrawOutcome && gameResult.ScoreTeam1 != gameResult.ScoreTeam2 {
+			*problemDest = DrawScoresNotEqual
+			return false
+		} else {
+			return true
+		}
+	}
+}
+
+// A series has an odd number of games so it can not be drawn, and bracket games need a winner to advance
+func (gameResult *GameResult) outcomeAllowed(gameInformation *Game, gameDao GameDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		if gameResult.HasWinner() {
+			return true
+		} else if gameResult.OutcomeName() == DrawOutcome && gameInformation.BestOf > 1 {
+			*problemDest = SeriesCanNotBeDrawn
+			return false
+		}
+		bracketGame, err := gameDao.IsBracketGame(gameInformation.GameId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if bracketGame {
+			*problemDest = BracketGameNeedsWinner
+			return false
+		} else {
+			return true
+		}
+	}
+}
+
+// Games of a series are reported one at a time until the series is decided
+func (gameResult *GameResult) seriesNotDecided(gameInformation *Game) ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if gameInformation.Complete {
+			*problemDest = SeriesAlreadyDecided
+			return false
+		} else {
+			return true
+		}
+	}
+}
+
+// A reported result can only be changed by amending it
+func (gameResult *GameResult) notComplete(gameInformation *Game) ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if gameInformation.Complete {
+			*problemDest = GameAlreadyReported
+			return false
+		} else {
+			return true
+		}
+	}
+}
+
+type GameResultAmendment struct {
+	GameResult
+	Reason string `json:"reason"`
+}
+
+func (amendment *GameResultAmendment) Validate(gameId int, gameDao GameDAO) (bool, string, error) {
+	gameInformation, err := gameDao.GetGameInformation(gameId)
+	if err != nil {
+		return false, "", err
+	} else {
+		return validate(
+			amendment.complete(gameInformation),
+			amendment.reason(),
+			amendment.outcome(),
+			amendment.teamsDetermined(gameInformation),
+			amendment.hasReportedTeams(gameInformation),
+			amendment.seriesScore(gameInformation),
+			amendment.drawScore(),
+			amendment.outcomeAllowed(gameInformation, gameDao),
+			amendment.bracketNotAdvanced(gameInformation, gameDao))
+	}
+}
+
+func (amendment *GameResultAmendment) complete(gameInformation *Game) ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if !gameInformation.Complete {
+			*problemDest = GameNotReported
+			return false
+		} else {
+			return true
+		}
+	}
+}
+
+func (amendment *GameResultAmendment) reason() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		valid := false
+		if len(amendment.Reason) > MaxDescriptionLength {
+			*problemDest = AmendmentReasonTooLong
+		} else if len(strings.TrimSpace(amendment.Reason)) == 0 {
+			*problemDest = AmendmentReasonMissing
+		} else {
+			valid = true
+		}
+		return valid
+	}
+}
+
+// Changing the winner of a bracket game changes the teams of the games it advances to, which can not be done
+// once those games have been played
+func (amendment *GameResultAmendment) bracketNotAdvanced(gameInformation *Game, gameDao GameDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		if amendment.WinnerId == gameInformation.WinnerId {
+			return true
+		}
+		advanced, err := gameDao.HasCompletedNextGame(gameInformation.GameId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if advanced {
+			*problemDest = NextGameAlreadyReported
+			return false
+		} else {
+			return true
+		}
+	}
+}
+
+type ResultAmendment struct {
+	AmendmentId int        `json:"amendmentId"`
+	GameId      int        `json:"gameId"`
+	UserId      int        `json:"userId"`
+	Reason      string     `json:"reason"`
+	AmendedAt   int        `json:"amendedAt"`
+	OldResult   GameResult `json:"oldResult"`
+	NewResult   GameResult `json:"newResult"`
+}
+
+// Results reported by a team only become final once confirmed by the opposing team
+func (gameResult *GameResult) ValidateSubmission(gameId, teamId int, gameDao GameDAO) (bool, string, error) {
+	valid, problem, err := gameResult.Validate(gameId, gameDao)
+	if !valid || problem != "" || err != nil {
+		return valid, problem, err
+	} else {
+		return validate(gameResult.notAwaitingConfirmation(gameId, teamId, gameDao))
+	}
+}
+
+// A team can correct its own submission until it is disputed, but can not replace the submission of the
+// opposing team which it should confirm or dispute instead
+func (gameResult *GameResult) notAwaitingConfirmation(gameId, teamId int, gameDao GameDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		submission, err := gameDao.GetResultSubmission(gameId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if submission == nil {
+			return true
+		} else if submission.Disputed {
+			*problemDest = ResultDisputed
+			return false
+		} else if submission.TeamId != teamId {
+			*problemDest = ResultAwaitingConfirmation
+			return false
+		} else {
+			return true
+		}
+	}
+}
+
+type ResultSubmission struct {
+	GameId        int        `json:"gameId"`
+	TeamId        int        `json:"teamId"`
+	UserId        int        `json:"userId"`
+	SubmittedAt   int        `json:"submittedAt"`
+	Result        GameResult `json:"result"`
+	Disputed      bool       `json:"disputed"`
+	DisputeReason string     `json:"disputeReason"`
+}
+
+func ValidateResultConfirmation(gameId int, gameDao GameDAO) (bool, string, error) {
+	return validate(validateResultSubmitted(gameId, gameDao))
+}
+
+func validateResultSubmitted(gameId int, gameDao GameDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		submission, err := gameDao.GetResultSubmission(gameId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if submission == nil {
+			*problemDest = NoResultSubmitted
+			return false
+		} else {
+			return true
+		}
+	}
+}
+
+type ResultDispute struct {
+	Reason string `json:"reason"`
+}
+
+func (dispute *ResultDispute) Validate(gameId int, gameDao GameDAO) (bool, string, error) {
+	return validate(
+		validateResultSubmitted(gameId, gameDao),
+		dispute.notDisputed(gameId, gameDao),
+		dispute.reason())
+}
+
+func (dispute *ResultDispute) notDisputed(gameId int, gameDao GameDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		submission, err := gameDao.GetResultSubmission(gameId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if submission.Disputed {
+			*problemDest = ResultDisputed
+			return false
+		} else {
+			return true
+		}
+	}
+}
+
+func (dispute *ResultDispute) reason() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		valid := false
+		if len(dispute.Reason) > MaxDescriptionLength {
+			*problemDest = DisputeReasonTooLong
+		} else if len(strings.TrimSpace(dispute.Reason)) == 0 {
+			*problemDest = DisputeReasonMissing
+		} else {
+			valid = true
+		}
+		return valid
+	}
+}
+
 type Game struct {
 	GameId     int         `json:"gameId"`
 	GameTime   int         `json:"gameTime"`
@@ -167,6 +568,17 @@ type Game struct {
 	ScoreTeam1 int         `json:"scoreTeam1"`
 	ScoreTeam2 int         `json:"scoreTeam2"`
 	Complete   bool        `json:"complete"`
+	Outcome    string      `json:"outcome"`
+	BestOf     int         `json:"bestOf"`
+	Duration   int         `json:"duration"`
+	Playoff    bool        `json:"playoff"`
+}
+
+type SeriesGame struct {
+	GameNumber int    `json:"gameNumber"`
+	ExternalId string `json:"externalId"`
+	WinnerId   int    `json:"winnerId"`
+	LoserId    int    `json:"loserId"`
 }
 
 type SortedGames struct {
@@ -0,0 +1,110 @@
+package dataModel
+
+import (
+	"strings"
+	"time"
+)
+
+// An invite bound to an email can only be used by the user with that email, and a MaxUses of 0 allows unlimited uses
+type LeagueInviteCore struct {
+	Email     string `json:"email"`
+	MaxUses   int    `json:"maxUses"`
+	ExpiresAt int    `json:"expiresAt"`
+}
+
+type LeagueInvite struct {
+	InviteId  int    `json:"inviteId"`
+	LeagueId  int    `json:"leagueId"`
+	Token     string `json:"token"`
+	Email     string `json:"email"`
+	MaxUses   int    `json:"maxUses"`
+	Uses      int    `json:"uses"`
+	ExpiresAt int    `json:"expiresAt"`
+	Revoked   bool   `json:"revoked"`
+	CreatedBy int    `json:"createdBy"`
+}
+
+func (invite *LeagueInviteCore) Validate() (bool, string, error) {
+	return validate(
+		invite.email(),
+		invite.maxUses(),
+		invite.expiry())
+}
+
+func (invite *LeagueInviteCore) email() ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		if invite.Email == "" {
+			return true
+		}
+		return validateEmailFormat(invite.Email)(problemDest, errorDest)
+	}
+}
+
+func (invite *LeagueInviteCore) maxUses() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if invite.MaxUses < 0 || invite.MaxUses > MaxInviteUses {
+			*problemDest = InvalidInviteUses
+			return false
+		}
+		return true
+	}
+}
+
+func (invite *LeagueInviteCore) expiry() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if int64(invite.ExpiresAt) <= time.Now().Unix() {
+			*problemDest = InviteExpiryInPast
+			return false
+		}
+		return true
+	}
+}
+
+// Checks that the invite can still be used by the user with this email to join its league
+func (invite *LeagueInvite) ValidateUse(userId int, email string, leagueDao LeagueDAO) (bool, string, error) {
+	return validate(
+		invite.outstanding(),
+		invite.recipient(email),
+		invite.notMember(userId, leagueDao))
+}
+
+func (invite *LeagueInvite) outstanding() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if invite.Revoked {
+			*problemDest = InviteRevoked
+			return false
+		} else if int64(invite.ExpiresAt) <= time.Now().Unix() {
+			*problemDest = InviteExpired
+			return false
+		} else if invite.MaxUses > 0 && invite.Uses >= invite.MaxUses {
+			*problemDest = InviteUsedUp
+			return false
+		}
+		return true
+	}
+}
+
+func (invite *LeagueInvite) recipient(email string) ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if invite.Email != "" && !strings.EqualFold(invite.Email, email) {
+			*problemDest = InviteForDifferentEmail
+			return false
+		}
+		return true
+	}
+}
+
+func (invite *LeagueInvite) notMember(userId int, leagueDao LeagueDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		member, err := leagueDao.IsLeagueMember(invite.LeagueId, userId)
+		if err != nil {
+			*errorDest = err
+			return false
+		}
+		if member {
+			*problemDest = AlreadyLeagueMember
+			return false
+		}
+		return true
+	}
+}
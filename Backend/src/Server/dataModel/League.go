@@ -1,5 +1,7 @@
 package dataModel
 
+import "time"
+
 type LeagueDAO interface {
 	// Modify League
 	CreateLeague(userId int, leagueInfo LeagueCore) (int, error)
@@ -12,6 +14,7 @@ type LeagueDAO interface {
 	GetTeamManagerInformation(leagueId int) ([]*TeamWithManagers, error)
 	IsLeagueViewable(leagueId, userId int) (bool, error)
 	CanJoinLeague(leagueId, userId int) (bool, error)
+	IsLeagueMember(leagueId, userId int) (bool, error)
 
 	// Get Information About Leagues
 	DoesLeagueExist(leagueId int) (bool, error)
@@ -23,6 +26,49 @@ type LeagueDAO interface {
 	GetMarkdownFile(leagueId int) (string, error)
 	SetMarkdownFile(leagueId int, fileName string) error
 
+	// Invites
+	CreateInvite(leagueId, userId int, token string, invite LeagueInviteCore) (int, error)
+	GetInvite(token string) (*LeagueInvite, error)
+	GetInvites(leagueId int) ([]*LeagueInvite, error)
+	UseInvite(inviteId, userId int) error
+	RevokeInvite(inviteId int) error
+	DoesInviteExistInLeague(leagueId, inviteId int) (bool, error)
+
+	// Standings
+	GetStandingsConfiguration(leagueId int) (*StandingsConfiguration, error)
+	SetStandingsConfiguration(leagueId int, standingsConfiguration StandingsConfiguration) error
+
+	// Roster Rules
+	GetRosterRules(leagueId int) (*RosterRules, error)
+	SetRosterRules(leagueId int, rules RosterRules) error
+
+	// Seasons
+	CreateSeason(leagueId int, season SeasonCore) (int, error)
+	GetSeasons(leagueId int) ([]*Season, error)
+	GetSeason(seasonId int) (*Season, error)
+	GetCurrentSeasonId(leagueId int) (int, error)
+	RolloverSeason(leagueId, seasonId int, rollover SeasonRolloverCore) error
+	DoesSeasonExistInLeague(leagueId, seasonId int) (bool, error)
+	IsSeasonNameInUse(leagueId int, name string) (bool, error)
+
+	// Divisions
+	CreateDivision(leagueId int, division DivisionCore) (int, error)
+	UpdateDivision(divisionId int, division DivisionCore) error
+	DeleteDivision(divisionId int) error
+	SetDivisionTeams(leagueId, divisionId int, teams DivisionTeams) error
+	GetDivisions(leagueId int) ([]*Division, error)
+	GetDivision(divisionId int) (*Division, error)
+	DoesDivisionExistInLeague(leagueId, divisionId int) (bool, error)
+	IsDivisionNameInUse(leagueId, divisionId int, name string) (bool, error)
+
+	// Playoff Stages
+	SetPlayoffStage(seasonId int, stage PlayoffStageCore) error
+	GetPlayoffStage(seasonId int) (*PlayoffStage, error)
+	DeletePlayoffStage(seasonId int) error
+	ClaimPlayoffStage(seasonId int) (bool, error)
+	ReleasePlayoffStage(seasonId int) error
+	GetLeaguesAwaitingPlayoffs() ([]int, error)
+
 	// Availabilities
 	AddAvailability(leagueId int, availability AvailabilityCore) (int, error)
 	GetAvailabilities(leagueId int) ([]*Availability, error)
@@ -37,28 +83,36 @@ type LeagueDAO interface {
 }
 
 type LeagueCore struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Game        string `json:"game"`
-	PublicView  bool   `json:"publicView"`
-	PublicJoin  bool   `json:"publicJoin"`
-	SignupStart int    `json:"signupStart"`
-	SignupEnd   int    `json:"signupEnd"`
-	LeagueStart int    `json:"leagueStart"`
-	LeagueEnd   int    `json:"leagueEnd"`
+	Name                    string `json:"name"`
+	Description             string `json:"description"`
+	Game                    string `json:"game"`
+	PublicView              bool   `json:"publicView"`
+	PublicJoin              bool   `json:"publicJoin"`
+	SignupStart             int    `json:"signupStart"`
+	SignupEnd               int    `json:"signupEnd"`
+	LeagueStart             int    `json:"leagueStart"`
+	LeagueEnd               int    `json:"leagueEnd"`
+	TimeZone                string `json:"timeZone"`
+	ResultConfirmationHours int    `json:"resultConfirmationHours"`
+	RequireTeamApproval     bool   `json:"requireTeamApproval"`
+	WithdrawalPolicy        string `json:"withdrawalPolicy"`
 }
 
 type League struct {
-	LeagueId    int    `json:"leagueId"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Game        string `json:"game"`
-	PublicView  bool   `json:"publicView"`
-	PublicJoin  bool   `json:"publicJoin"`
-	SignupStart int    `json:"signupStart"`
-	SignupEnd   int    `json:"signupEnd"`
-	LeagueStart int    `json:"leagueStart"`
-	LeagueEnd   int    `json:"leagueEnd"`
+	LeagueId                int    `json:"leagueId"`
+	Name                    string `json:"name"`
+	Description             string `json:"description"`
+	Game                    string `json:"game"`
+	PublicView              bool   `json:"publicView"`
+	PublicJoin              bool   `json:"publicJoin"`
+	SignupStart             int    `json:"signupStart"`
+	SignupEnd               int    `json:"signupEnd"`
+	LeagueStart             int    `json:"leagueStart"`
+	LeagueEnd               int    `json:"leagueEnd"`
+	TimeZone                string `json:"timeZone"`
+	ResultConfirmationHours int    `json:"resultConfirmationHours"`
+	RequireTeamApproval     bool   `json:"requireTeamApproval"`
+	WithdrawalPolicy        string `json:"withdrawalPolicy"`
 }
 
 func (league *LeagueCore) validate(leagueId int, leagueDao LeagueDAO) (bool, string, error) {
@@ -68,7 +122,10 @@ func (league *LeagueCore) validate(leagueId int, leagueDao LeagueDAO) (bool, str
 		league.description(),
 		league.game(),
 		league.permissions(),
-		league.timestamps())
+		league.timestamps(),
+		league.timeZone(),
+		league.resultConfirmationHours(),
+		league.withdrawalPolicy())
 }
 
 func (league *LeagueCore) ValidateNew(leagueDao LeagueDAO) (bool, string, error) {
@@ -98,7 +155,7 @@ func (league *LeagueCore) ValidateEdit(leagueId int, leagueDao LeagueDAO, gameDa
 			validateDuringLeague(leagueId, game.GameTime, leagueDao, GamesNotDuringLeague))
 	}
 
-	valid, problem, err := validate(leagueDateBoundsValidators...)
+	valid, problem, err := validate(append(leagueDateBoundsValidators, league.seasonDates(leagueId, leagueDao))...)
 	if !valid || problem != "" || err != nil {
 		return valid, problem, err
 	} else {
@@ -106,6 +163,19 @@ func (league *LeagueCore) ValidateEdit(leagueId int, leagueDao LeagueDAO, gameDa
 	}
 }
 
+// The league competition period is the period of its current season, so it can not overlap its other seasons
+func (league *LeagueCore) seasonDates(leagueId int, leagueDao LeagueDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		currentSeasonId, err := leagueDao.GetCurrentSeasonId(leagueId)
+		if err != nil {
+			*errorDest = err
+			return false
+		}
+		return validateSeasonDates(leagueId, currentSeasonId, league.LeagueStart, league.LeagueEnd,
+			leagueDao)(problemDest, errorDest)
+	}
+}
+
 func (league *LeagueCore) name() ValidateFunc {
 	return func(problemDest *string, _ *error) bool {
 		valid := false
@@ -184,6 +254,83 @@ func (league *LeagueCore) timestamps() ValidateFunc {
 	}
 }
 
+func (league *LeagueCore) timeZone() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if _, err := time.LoadLocation(league.TimeZoneName()); err != nil {
+			*problemDest = InvalidTimeZone
+			return false
+		} else {
+			return true
+		}
+	}
+}
+
+// Hours after which a result submitted by a team is confirmed if the opposing team has not responded,
+// results are never confirmed automatically if 0
+func (league *LeagueCore) resultConfirmationHours() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if league.ResultConfirmationHours < 0 || league.ResultConfirmationHours > MaxResultConfirmationHours {
+			*problemDest = InvalidResultConfirmationHours
+			return false
+		} else {
+			return true
+		}
+	}
+}
+
+// What happens to the remaining games of a team that withdraws from the league
+const (
+	ForfeitWithdrawnGames = "forfeit"
+	CancelWithdrawnGames  = "cancel"
+)
+
+func (league *LeagueCore) withdrawalPolicy() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		switch league.WithdrawalPolicyName() {
+		case ForfeitWithdrawnGames, CancelWithdrawnGames:
+			return true
+		default:
+			*problemDest = InvalidWithdrawalPolicy
+			return false
+		}
+	}
+}
+
+// Leagues without a withdrawal policy forfeit the remaining games of withdrawn teams
+func (league *LeagueCore) WithdrawalPolicyName() string {
+	if league.WithdrawalPolicy == "" {
+		return ForfeitWithdrawnGames
+	}
+	return league.WithdrawalPolicy
+}
+
+// Leagues without a time zone use UTC
+func (league *LeagueCore) TimeZoneName() string {
+	if league.TimeZone == "" {
+		return "UTC"
+	}
+	return league.TimeZone
+}
+
+// The IANA time zone that weekly availabilities and game weeks of the league are in
+func (league *League) Location() (*time.Location, error) {
+	if league.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(league.TimeZone)
+}
+
+// The location that game weeks are shown to a viewer in, which is the time zone of the league unless the viewer
+// requested another IANA time zone. Returns false if the requested time zone does not exist
+func (league *League) ViewerLocation(timeZone string) (*time.Location, bool, error) {
+	if timeZone == "" {
+		location, err := league.Location()
+		return location, true, err
+	}
+	location, err := time.LoadLocation(timeZone)
+	return location, err == nil, nil
+}
+
 type Markdown struct {
 	Markdown string `json:"markdown"`
 }
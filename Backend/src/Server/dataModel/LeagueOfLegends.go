@@ -8,18 +8,30 @@ type LeagueOfLegendsDAO interface {
 	GetLoLTeamStub(teamId int) (*LoLTeamStub, error)
 	GetAllLoLTeamStubInLeague(leagueId int) ([]*LoLTeamStub, error)
 
-	ReportEndGameStats(leagueId, gameId int, match *LoLMatchInformation) error
-	GetPlayerStats(leagueId int) ([]*LoLPlayerStats, error)
-	GetTeamStats(leagueId int) ([]*LoLTeamStats, error)
-	GetChampionStats(leagueId int) ([]*LoLChampionStats, error)
+	GetPlayerStats(seasonId int) ([]*LoLPlayerStats, error)
+	GetTeamStats(seasonId int) ([]*LoLTeamStats, error)
+	GetChampionStats(seasonId int) ([]*LoLChampionStats, error)
 
 	RegisterTournamentProvider(leagueId, providerId, tournamentId int) error
 	LeagueHasRegisteredTournament(leagueId int) (bool, error)
 	GetTournamentId(leagueId int) (int, error)
+	GetTournamentSettings(leagueId int) (*LoLTournamentSettings, error)
+	SetTournamentSettings(leagueId int, settings LoLTournamentSettings) error
 
-	HasTournamentCode(gameId int) (bool, error)
 	GetTournamentCode(gameId int) (string, error)
-	CreateTournamentCode(gameId int, tournamentCode string) error
+	CreateTournamentCode(gameId int, externalId, tournamentCode string) error
+	GetGamesAwaitingTournamentCodes(from, to int) ([]*LoLTournamentGame, error)
+	IsTournamentCodeOfGame(tournamentCode string, gameId int, externalGameId string) (bool, error)
+	IsMatchProcessed(matchId string) (bool, error)
+	ReportTournamentMatch(tournamentCode string, match *LoLMatchInformation, gameResult GameResult) (int, bool, error)
+
+	QueueTournamentCallback(callback *LoLQueuedCallback) error
+	GetDueTournamentCallbacks(now, maxAttempts int) ([]*LoLQueuedCallback, error)
+	UpdateTournamentCallback(callback *LoLQueuedCallback) error
+	DeleteTournamentCallback(matchId string) error
+
+	GetCachedSummoners(externalIds []string) ([]*LoLCachedSummoner, error)
+	CacheSummoner(summoner *LoLCachedSummoner) error
 }
 
 type LoLTeamWithPlayersCore struct {
@@ -28,15 +40,18 @@ type LoLTeamWithPlayersCore struct {
 	Players []*LoLPlayerCore `json:"players"`
 }
 
-func (team *LoLTeamWithPlayersCore) Validate(leagueId int, teamDao TeamDAO) (bool, string, error) {
-	valid, problem, err := team.Team.ValidateNew(leagueId, teamDao)
+func (team *LoLTeamWithPlayersCore) Validate(leagueId int, administrator bool, leagueDao LeagueDAO,
+	teamDao TeamDAO) (bool, string, error) {
+	valid, problem, err := team.Team.ValidateNew(leagueId, administrator, leagueDao, teamDao)
 	if !valid || problem != "" || err != nil {
 		return valid, problem, err
 	}
 
 	playersToCheck := make([]PlayerCore, 0)
+	roster := make([]*RosterSlot, 0)
 	for _, player := range team.Players {
 		playersToCheck = append(playersToCheck, PlayerCore{GameIdentifier: player.GameIdentifier})
+		roster = append(roster, &RosterSlot{MainRoster: player.MainRoster, Position: player.Position})
 	}
 
 	// Check that each player is unique from the other non-existing players
@@ -57,7 +72,121 @@ func (team *LoLTeamWithPlayersCore) Validate(leagueId int, teamDao TeamDAO) (boo
 		}
 	}
 
-	return true, "", nil
+	return validate(validateCompleteRoster(leagueId, roster, leagueDao))
+}
+
+// Results of tournament games are only reported for the game that the tournament code was created for, so that a
+// callback can not report the result of another game
+func ValidateTournamentCode(tournamentCode string, gameId int, externalGameId string,
+	leagueOfLegendsDao LeagueOfLegendsDAO) (bool, string, error) {
+	return validate(validateTournamentCodeOfGame(tournamentCode, gameId, externalGameId, leagueOfLegendsDao))
+}
+
+func validateTournamentCodeOfGame(tournamentCode string, gameId int, externalGameId string,
+	leagueOfLegendsDao LeagueOfLegendsDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		ofGame, err := leagueOfLegendsDao.IsTournamentCodeOfGame(tournamentCode, gameId, externalGameId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if !ofGame {
+			*problemDest = TournamentCodeNotOfGame
+			return false
+		}
+		return true
+	}
+}
+
+// How the tournament codes of the games of a league are created. The region is the platform the league is registered
+// on as a tournament, and when RestrictToRosters is set only the players on the rosters of the two teams can join
+// the lobby of a game
+type LoLTournamentSettings struct {
+	Region            string `json:"region"`
+	MapType           string `json:"mapType"`
+	PickType          string `json:"pickType"`
+	SpectatorType     string `json:"spectatorType"`
+	TeamSize          int    `json:"teamSize"`
+	RestrictToRosters bool   `json:"restrictToRosters"`
+}
+
+// The settings of leagues that have not set their own, which are those tournament codes were created with before
+// they could be set
+func NewLoLTournamentSettings() *LoLTournamentSettings {
+	return &LoLTournamentSettings{
+		Region:        "NA",
+		MapType:       "SUMMONERS_RIFT",
+		PickType:      "TOURNAMENT_DRAFT",
+		SpectatorType: "LOBBYONLY",
+		TeamSize:      MaxLoLTeamSize,
+	}
+}
+
+func (settings *LoLTournamentSettings) Validate(leagueId int, leagueOfLegendsDao LeagueOfLegendsDAO) (bool, string, error) {
+	return validate(
+		settings.choices(),
+		settings.teamSize(),
+		settings.region(leagueId, leagueOfLegendsDao))
+}
+
+func (settings *LoLTournamentSettings) choices() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if _, ok := LoLRegions[settings.Region]; !ok {
+			*problemDest = InvalidLoLRegion
+			return false
+		} else if !isOneOf(settings.MapType, ValidLoLMapTypes[:]) {
+			*problemDest = InvalidLoLMapType
+			return false
+		} else if !isOneOf(settings.PickType, ValidLoLPickTypes[:]) {
+			*problemDest = InvalidLoLPickType
+			return false
+		} else if !isOneOf(settings.SpectatorType, ValidLoLSpectatorTypes[:]) {
+			*problemDest = InvalidLoLSpectatorType
+			return false
+		}
+		return true
+	}
+}
+
+func (settings *LoLTournamentSettings) teamSize() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if settings.TeamSize < 1 || settings.TeamSize > MaxLoLTeamSize {
+			*problemDest = InvalidLoLTeamSize
+			return false
+		}
+		return true
+	}
+}
+
+// The tournament of a league is registered with the provider of its region, so the region is fixed from then on
+func (settings *LoLTournamentSettings) region(leagueId int, leagueOfLegendsDao LeagueOfLegendsDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		isRegistered, err := leagueOfLegendsDao.LeagueHasRegisteredTournament(leagueId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if !isRegistered {
+			return true
+		}
+
+		current, err := leagueOfLegendsDao.GetTournamentSettings(leagueId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if current.Region != settings.Region {
+			*problemDest = TournamentRegionRegistered
+			return false
+		}
+		return true
+	}
+}
+
+func isOneOf(value string, choices []string) bool {
+	for _, choice := range choices {
+		if choice == value {
+			return true
+		}
+	}
+	return false
 }
 
 type LoLPlayer struct {
@@ -67,6 +196,33 @@ type LoLPlayer struct {
 	Position       string `json:"position"`
 	Rank           string `json:"rank"`
 	Tier           string `json:"tier"`
+	Stale          bool   `json:"stale"`
+}
+
+// The summoner name, rank and tier of a summoner as of the unix timestamp Updated, so that they can be shown while
+// the Riot API is unavailable
+type LoLCachedSummoner struct {
+	ExternalId     string
+	GameIdentifier string
+	Rank           string
+	Tier           string
+	Updated        int
+}
+
+// A game of a league that is registered as a tournament
+type LoLTournamentGame struct {
+	GameId       int
+	LeagueId     int
+	TournamentId int
+}
+
+// A tournament callback that could not be ingested, kept as the body it was received with so it can be retried
+type LoLQueuedCallback struct {
+	MatchId     string
+	Callback    string
+	Attempts    int
+	NextAttempt int
+	LastError   string
 }
 
 type LoLPlayerStub struct {
@@ -147,6 +303,7 @@ type LoLMatchPlayerStats struct {
 
 type LoLMatchInformation struct {
 	GameId                 string                `json:"gameId"`
+	MatchId                string                `json:"matchId"`
 	Duration               float64               `json:"duration"`
 	Timestamp              int                   `json:"timestamp"`
 	Team1Id                int                   `json:"team1Id"`
@@ -170,25 +327,38 @@ type LoLPlayerCore struct {
 	ExternalId     string `json:"externalId"`
 }
 
-func (player *LoLPlayerCore) validate(leagueId, teamId, playerId int, leagueOfLegendsDAO LeagueOfLegendsDAO) (bool, string, error) {
-	return validate(
+func (player *LoLPlayerCore) validate(leagueId, playerId int, leagueOfLegendsDAO LeagueOfLegendsDAO,
+	validators ...ValidateFunc) (bool, string, error) {
+	return validate(append([]ValidateFunc{
 		validateGameIdentifier(player.GameIdentifier),
-		player.uniqueness(leagueId, playerId, leagueOfLegendsDAO))
+		player.uniqueness(leagueId, playerId, leagueOfLegendsDAO)},
+		validators...)...)
+}
+
+func (player *LoLPlayerCore) ValidateNew(leagueId, teamId int, administrator bool, leagueDao LeagueDAO, teamDao TeamDAO,
+	leagueOfLegendsDAO LeagueOfLegendsDAO) (bool, string, error) {
+	return player.validate(leagueId, 0, leagueOfLegendsDAO,
+		validateRosterChange(leagueId, teamId, administrator,
+			addToRoster(player.MainRoster, player.Position), leagueDao, teamDao))
 }
 
-func (player *LoLPlayerCore) ValidateNew(leagueId, teamId int, leagueOfLegendsDAO LeagueOfLegendsDAO) (bool, string, error) {
-	return player.validate(leagueId, teamId, 0, leagueOfLegendsDAO)
+func (player *LoLPlayerCore) ValidateEdit(leagueId, teamId, playerId int, administrator bool, leagueDao LeagueDAO,
+	teamDao TeamDAO, leagueOfLegendsDAO LeagueOfLegendsDAO) (bool, string, error) {
+	return player.validate(leagueId, playerId, leagueOfLegendsDAO,
+		validateRosterChange(leagueId, teamId, administrator,
+			replaceInRoster(playerId, player.MainRoster, player.Position), leagueDao, teamDao))
 }
 
-func (player *LoLPlayerCore) ValidateEdit(leagueId, teamId, playerId int, leagueOfLegendsDAO LeagueOfLegendsDAO) (bool, string, error) {
-	return player.validate(leagueId, teamId, playerId, leagueOfLegendsDAO)
+// Players registered together with their team are checked against the rules as a complete roster instead
+func (player *LoLPlayerCore) ValidateWithTeam(leagueId int, leagueOfLegendsDAO LeagueOfLegendsDAO) (bool, string, error) {
+	return player.validate(leagueId, 0, leagueOfLegendsDAO)
 }
 
 func (player *LoLPlayerCore) uniqueness(leagueId, playerId int, leagueOfLegendsDAO LeagueOfLegendsDAO) ValidateFunc {
 	return func(problemDest *string, errorDest *error) bool {
 		teams, err := leagueOfLegendsDAO.GetAllLoLTeamStubInLeague(leagueId)
 		if err != nil {
-			errorDest = &err
+			*errorDest = err
 			return false
 		}
 		for _, team := range teams {
@@ -5,6 +5,7 @@ type Player struct {
 	Name           string `json:"name"`
 	GameIdentifier string `json:"gameIdentifier"`
 	MainRoster     bool   `json:"mainRoster"`
+	UserId         int    `json:"userId"`
 }
 
 type PlayerCore struct {
@@ -13,19 +14,24 @@ type PlayerCore struct {
 	MainRoster     bool   `json:"mainRoster"`
 }
 
-func (player *PlayerCore) validate(leagueId, teamId, playerId int, teamDao TeamDAO) (bool, string, error) {
-	return validate(
+func (player *PlayerCore) validate(leagueId, playerId int, teamDao TeamDAO, validators ...ValidateFunc) (bool, string, error) {
+	return validate(append([]ValidateFunc{
 		validateName(player.Name),
 		validateGameIdentifier(player.GameIdentifier),
-		player.uniquenessWithExisting(leagueId, playerId, teamDao))
+		player.uniquenessWithExisting(leagueId, playerId, teamDao)},
+		validators...)...)
 }
 
-func (player *PlayerCore) ValidateNew(leagueId, teamId int, teamDao TeamDAO) (bool, string, error) {
-	return player.validate(leagueId, teamId, 0, teamDao)
+func (player *PlayerCore) ValidateNew(leagueId, teamId int, administrator bool,
+	leagueDao LeagueDAO, teamDao TeamDAO) (bool, string, error) {
+	return player.validate(leagueId, 0, teamDao,
+		validateRosterChange(leagueId, teamId, administrator, addToRoster(player.MainRoster, ""), leagueDao, teamDao))
 }
 
-func (player *PlayerCore) ValidateEdit(leagueId, teamId, playerId int, teamDao TeamDAO) (bool, string, error) {
-	return player.validate(leagueId, teamId, playerId, teamDao)
+func (player *PlayerCore) ValidateEdit(leagueId, teamId, playerId int, administrator bool,
+	leagueDao LeagueDAO, teamDao TeamDAO) (bool, string, error) {
+	return player.validate(leagueId, playerId, teamDao,
+		validateRosterChange(leagueId, teamId, administrator, moveInRoster(playerId, player.MainRoster), leagueDao, teamDao))
 }
 
 func (player PlayerCore) uniqueness(playerId int, players []*Player) (bool, string) {
@@ -41,12 +47,22 @@ func (player *PlayerCore) uniquenessWithExisting(leagueId, playerId int, teamDao
 	return func(problemDest *string, errorDest *error) bool {
 		teams, err := teamDao.GetAllTeamsInLeague(leagueId)
 		if err != nil {
-			errorDest = &err
+			*errorDest = err
+			return false
+		}
+		pendingTeams, err := teamDao.GetPendingTeams(leagueId)
+		if err != nil {
+			*errorDest = err
+			return false
+		}
+		archivedTeams, err := teamDao.GetArchivedTeams(leagueId)
+		if err != nil {
+			*errorDest = err
 			return false
 		}
 
 		allPlayers := make([]*Player, 0)
-		for _, team := range teams {
+		for _, team := range append(append(teams, pendingTeams...), archivedTeams...) {
 			allPlayers = append(allPlayers, team.Players...)
 		}
 
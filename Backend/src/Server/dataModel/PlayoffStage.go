@@ -0,0 +1,149 @@
+package dataModel
+
+import (
+	"Server/scheduler"
+	"strings"
+)
+
+// The stage that follows the group stage of a season, where the divisions of the league are its groups. Once every
+// game of the group stage is complete, the top AdvancingPerGroup teams of each group advance to a bracket that is
+// seeded across groups and scheduled automatically
+type PlayoffStageCore struct {
+	TournamentType    string `json:"tournamentType"`
+	AdvancingPerGroup int    `json:"advancingPerGroup"`
+	RoundsPerWeek     int    `json:"roundsPerWeek"`
+	ConcurrentGameNum int    `json:"concurrentGameNum"`
+	GameDuration      int    `json:"gameDuration"`
+	BestOf            int    `json:"bestOf"`
+}
+
+type PlayoffStage struct {
+	PlayoffStageCore
+	Generated bool `json:"generated"`
+}
+
+func (stage *PlayoffStageCore) Validate(seasonId int, leagueDao LeagueDAO) (bool, string, error) {
+	return validate(
+		stage.tournamentType(),
+		stage.advancingPerGroup(),
+		stage.schedule(),
+		stage.bestOf(),
+		validatePlayoffStageNotGenerated(seasonId, leagueDao))
+}
+
+func (stage *PlayoffStageCore) tournamentType() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		s := &scheduler.Scheduler{}
+		if !s.IsTournamentTypeSupported(stage.TournamentType) ||
+			!s.IsBracketTournament(s.GetTournamentFromString(strings.ToLower(stage.TournamentType))) {
+			*problemDest = PlayoffTournamentTypeNotSupported
+			return false
+		}
+		return true
+	}
+}
+
+func (stage *PlayoffStageCore) advancingPerGroup() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if stage.AdvancingPerGroup < 1 || stage.AdvancingPerGroup > MaxAdvancingPerGroup {
+			*problemDest = InvalidAdvancingPerGroup
+			return false
+		}
+		return true
+	}
+}
+
+func (stage *PlayoffStageCore) schedule() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if stage.RoundsPerWeek < 1 || stage.ConcurrentGameNum < 1 || stage.GameDuration < 1 {
+			*problemDest = InvalidPlayoffSchedule
+			return false
+		}
+		return true
+	}
+}
+
+func (stage *PlayoffStageCore) bestOf() ValidateFunc {
+	return (&GameCreationInformation{BestOf: stage.BestOf}).bestOf()
+}
+
+// The playoff stage can not be changed once its games have been created
+func validatePlayoffStageNotGenerated(seasonId int, leagueDao LeagueDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		stage, err := leagueDao.GetPlayoffStage(seasonId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if stage != nil && stage.Generated {
+			*problemDest = PlayoffStageAlreadyGenerated
+			return false
+		}
+		return true
+	}
+}
+
+// The playoff stage of the current season can be generated by hand, for example once availabilities have been
+// added after automatic generation failed
+func ValidatePlayoffStageGeneration(leagueId, seasonId int, leagueDao LeagueDAO, gameDao GameDAO,
+	teamDao TeamDAO) (bool, string, error) {
+	return validate(
+		validatePlayoffStageDefined(seasonId, leagueDao),
+		validatePlayoffStageNotGenerated(seasonId, leagueDao),
+		validateCurrentSeason(leagueId, seasonId, leagueDao),
+		validateGroupStageComplete(seasonId, gameDao),
+		validateTeamsInDivisions(leagueId, leagueDao, teamDao))
+}
+
+func validatePlayoffStageDefined(seasonId int, leagueDao LeagueDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		stage, err := leagueDao.GetPlayoffStage(seasonId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if stage == nil {
+			*problemDest = PlayoffStageNotDefined
+			return false
+		}
+		return true
+	}
+}
+
+func validateCurrentSeason(leagueId, seasonId int, leagueDao LeagueDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		currentSeasonId, err := leagueDao.GetCurrentSeasonId(leagueId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if currentSeasonId != seasonId {
+			*problemDest = PlayoffStageNotCurrentSeason
+			return false
+		}
+		return true
+	}
+}
+
+func validateGroupStageComplete(seasonId int, gameDao GameDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		games, err := gameDao.GetAllGamesInSeason(seasonId)
+		if err != nil {
+			*errorDest = err
+			return false
+		}
+		groupGames := 0
+		for _, game := range games {
+			if game.Playoff {
+				continue
+			}
+			groupGames++
+			if !game.Complete {
+				*problemDest = GroupStageNotComplete
+				return false
+			}
+		}
+		if groupGames == 0 {
+			*problemDest = GroupStageNotComplete
+			return false
+		}
+		return true
+	}
+}
@@ -0,0 +1,89 @@
+package dataModel
+
+// A player added to or removed from a team after the roster lock by someone other than a league administrator. The
+// change is queued until a league administrator approves it. If PlayerId is set the player is removed, otherwise a new
+// player is added, with an ExternalId and Position for League of Legends players
+type RosterChangeCore struct {
+	PlayerId       int    `json:"playerId"`
+	Name           string `json:"name"`
+	GameIdentifier string `json:"gameIdentifier"`
+	ExternalId     string `json:"externalId"`
+	MainRoster     bool   `json:"mainRoster"`
+	Position       string `json:"position"`
+}
+
+type RosterChange struct {
+	RosterChangeId int    `json:"rosterChangeId"`
+	LeagueId       int    `json:"leagueId"`
+	TeamId         int    `json:"teamId"`
+	UserId         int    `json:"userId"`
+	PlayerId       int    `json:"playerId"`
+	Name           string `json:"name"`
+	GameIdentifier string `json:"gameIdentifier"`
+	ExternalId     string `json:"externalId"`
+	MainRoster     bool   `json:"mainRoster"`
+	Position       string `json:"position"`
+	CreatedAt      int    `json:"createdAt"`
+}
+
+// The roster may have changed since the change was queued, so it is checked again before it is applied. The change
+// is nil if it does not exist in the league
+func (change *RosterChange) ValidateApproval(leagueDao LeagueDAO, teamDao TeamDAO) (bool, string, error) {
+	return validate(change.exists(), change.playerOnTeam(teamDao), change.uniqueness(teamDao),
+		change.rosterRules(leagueDao, teamDao))
+}
+
+func ValidateRosterChangeExists(change *RosterChange) (bool, string, error) {
+	return validate(change.exists())
+}
+
+func (change *RosterChange) exists() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if change == nil {
+			*problemDest = RosterChangeDoesNotExist
+			return false
+		}
+		return true
+	}
+}
+
+// The player to remove may have been transferred or removed while the change was queued, and must not be removed
+// from the team they are on now
+func (change *RosterChange) playerOnTeam(teamDao TeamDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		if change.PlayerId == 0 {
+			return true
+		}
+		onTeam, err := teamDao.DoesPlayerExist(change.LeagueId, change.TeamId, change.PlayerId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if !onTeam {
+			*problemDest = RosterChangeStale
+			return false
+		}
+		return true
+	}
+}
+
+// Another player with the same game identifier may have joined the league while the change was queued
+func (change *RosterChange) uniqueness(teamDao TeamDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		if change.PlayerId != 0 {
+			return true
+		}
+		player := PlayerCore{GameIdentifier: change.GameIdentifier}
+		return player.uniquenessWithExisting(change.LeagueId, 0, teamDao)(problemDest, errorDest)
+	}
+}
+
+func (change *RosterChange) rosterRules(leagueDao LeagueDAO, teamDao TeamDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		rosterChange := addToRoster(change.MainRoster, change.Position)
+		if change.PlayerId != 0 {
+			rosterChange = removeFromRoster(change.PlayerId)
+		}
+		return validateRosterChange(change.LeagueId, change.TeamId, true, rosterChange,
+			leagueDao, teamDao)(problemDest, errorDest)
+	}
+}
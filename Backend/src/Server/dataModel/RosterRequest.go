@@ -0,0 +1,145 @@
+package dataModel
+
+// A request from a user to join a team as a player, or an invite from a team for a user to join it. If PlayerId is
+// set, the user is linked to that existing player of the team, otherwise a new player is added to the team
+type RosterRequestCore struct {
+	PlayerId       int    `json:"playerId"`
+	Name           string `json:"name"`
+	GameIdentifier string `json:"gameIdentifier"`
+	MainRoster     bool   `json:"mainRoster"`
+}
+
+type RosterInviteCore struct {
+	Email string `json:"email"`
+	RosterRequestCore
+}
+
+type RosterRequest struct {
+	RequestId      int    `json:"requestId"`
+	LeagueId       int    `json:"leagueId"`
+	TeamId         int    `json:"teamId"`
+	UserId         int    `json:"userId"`
+	Email          string `json:"email"`
+	PlayerId       int    `json:"playerId"`
+	Name           string `json:"name"`
+	GameIdentifier string `json:"gameIdentifier"`
+	MainRoster     bool   `json:"mainRoster"`
+	FromTeam       bool   `json:"fromTeam"`
+	CreatedAt      int    `json:"createdAt"`
+}
+
+func (request *RosterRequestCore) Validate(leagueId, teamId, userId int, teamDao TeamDAO) (bool, string, error) {
+	return validate(
+		request.player(leagueId, teamId, teamDao),
+		validateNotOnRoster(leagueId, userId, teamDao),
+		validateNoPendingRosterRequest(teamId, userId, teamDao))
+}
+
+// The invited user is looked up by email beforehand, and userId is 0 if no user has that email
+func (invite *RosterInviteCore) Validate(leagueId, teamId, userId int, teamDao TeamDAO) (bool, string, error) {
+	return validate(
+		validateEmailFormat(invite.Email),
+		invite.recipient(userId),
+		invite.player(leagueId, teamId, teamDao),
+		validateNotOnRoster(leagueId, userId, teamDao),
+		validateNoPendingRosterRequest(teamId, userId, teamDao))
+}
+
+// The roster may have changed since the request was made, so it is checked again before the user is linked. Linking
+// an existing player leaves the roster as it is, but adding a new player has to follow the roster rules of the league
+func (request *RosterRequest) ValidateAcceptance(administrator bool, leagueDao LeagueDAO,
+	teamDao TeamDAO) (bool, string, error) {
+	core := RosterRequestCore{
+		PlayerId:       request.PlayerId,
+		Name:           request.Name,
+		GameIdentifier: request.GameIdentifier,
+		MainRoster:     request.MainRoster,
+	}
+	return validate(
+		core.player(request.LeagueId, request.TeamId, teamDao),
+		validateNotOnRoster(request.LeagueId, request.UserId, teamDao),
+		request.rosterRules(administrator, leagueDao, teamDao))
+}
+
+func (request *RosterRequest) rosterRules(administrator bool, leagueDao LeagueDAO, teamDao TeamDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		if request.PlayerId != 0 {
+			return true
+		}
+		return validateRosterChange(request.LeagueId, request.TeamId, administrator,
+			addToRoster(request.MainRoster, ""), leagueDao, teamDao)(problemDest, errorDest)
+	}
+}
+
+func (invite *RosterInviteCore) recipient(userId int) ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if userId == 0 {
+			*problemDest = NoUserWithEmail
+			return false
+		}
+		return true
+	}
+}
+
+func (request *RosterRequestCore) player(leagueId, teamId int, teamDao TeamDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		if request.PlayerId == 0 {
+			player := PlayerCore{
+				Name:           request.Name,
+				GameIdentifier: request.GameIdentifier,
+				MainRoster:     request.MainRoster,
+			}
+			valid, problem, err := player.validate(leagueId, 0, teamDao)
+			*problemDest = problem
+			*errorDest = err
+			return valid
+		}
+
+		exists, err := teamDao.DoesPlayerExist(leagueId, teamId, request.PlayerId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if !exists {
+			*problemDest = PlayerNotOnTeam
+			return false
+		}
+
+		userId, err := teamDao.GetPlayerUserId(request.PlayerId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if userId != 0 {
+			*problemDest = PlayerAlreadyLinked
+			return false
+		}
+		return true
+	}
+}
+
+func validateNotOnRoster(leagueId, userId int, teamDao TeamDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		onRoster, err := teamDao.IsUserOnRosterInLeague(leagueId, userId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if onRoster {
+			*problemDest = AlreadyOnRoster
+			return false
+		}
+		return true
+	}
+}
+
+func validateNoPendingRosterRequest(teamId, userId int, teamDao TeamDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		pending, err := teamDao.HasPendingRosterRequest(teamId, userId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if pending {
+			*problemDest = RosterRequestPending
+			return false
+		}
+		return true
+	}
+}
@@ -0,0 +1,224 @@
+package dataModel
+
+import "time"
+
+// A MaxMainRoster or MaxSubstitutes of 0 places no limit on that roster, and a RosterLock of 0 never locks rosters.
+// Required positions must each be filled by a player on the main roster
+type RosterRules struct {
+	MinMainRoster     int      `json:"minMainRoster"`
+	MaxMainRoster     int      `json:"maxMainRoster"`
+	MaxSubstitutes    int      `json:"maxSubstitutes"`
+	RequiredPositions []string `json:"requiredPositions"`
+	RosterLock        int      `json:"rosterLock"`
+}
+
+// The part of a player that roster rules are concerned with
+type RosterSlot struct {
+	PlayerId   int
+	MainRoster bool
+	Position   string
+}
+
+// Produces the roster a team would have after adding, removing or changing a player
+type rosterChange func(roster []*RosterSlot) []*RosterSlot
+
+func (rules *RosterRules) Validate() (bool, string, error) {
+	return validate(rules.sizes(), rules.positions(), rules.lock())
+}
+
+func (rules *RosterRules) sizes() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		for _, size := range []int{rules.MinMainRoster, rules.MaxMainRoster, rules.MaxSubstitutes} {
+			if size < 0 || size > MaxRosterSize {
+				*problemDest = InvalidRosterSize
+				return false
+			}
+		}
+		if rules.MaxMainRoster > 0 && rules.MaxMainRoster < rules.MinMainRoster {
+			*problemDest = RosterSizesOutOfOrder
+			return false
+		}
+		return true
+	}
+}
+
+func (rules *RosterRules) positions() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		selected := make(map[string]bool)
+		for _, position := range rules.RequiredPositions {
+			if len(position) == 0 || len(position) > MaxPositionLength {
+				*problemDest = InvalidPosition
+				return false
+			} else if selected[position] {
+				*problemDest = PositionRepeated
+				return false
+			}
+			selected[position] = true
+		}
+		if rules.MaxMainRoster > 0 && len(rules.RequiredPositions) > rules.MaxMainRoster {
+			*problemDest = TooManyRequiredPositions
+			return false
+		}
+		return true
+	}
+}
+
+func (rules *RosterRules) lock() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if rules.RosterLock < 0 {
+			*problemDest = InvalidRosterLock
+			return false
+		}
+		return true
+	}
+}
+
+func (rules *RosterRules) Locked() bool {
+	return rules.RosterLock > 0 && int64(rules.RosterLock) <= time.Now().Unix()
+}
+
+// Players added or removed after the roster lock by anyone but a league administrator are queued until a league
+// administrator approves the change
+func (rules *RosterRules) QueuesChanges(administrator bool) bool {
+	return !administrator && rules.Locked()
+}
+
+// Returns the problem with the roster after a change. A roster that already broke a rule before the change only
+// fails if the change makes it worse, so teams can build up to the minimum roster one player at a time. A complete
+// roster, such as one registered together with its team, must meet every rule
+func (rules *RosterRules) rosterProblem(before, after []*RosterSlot, complete bool) string {
+	mainBefore, substitutesBefore := countRoster(before)
+	mainAfter, substitutesAfter := countRoster(after)
+
+	if rules.MaxMainRoster > 0 && mainAfter > rules.MaxMainRoster && (complete || mainAfter > mainBefore) {
+		return MainRosterTooLarge
+	} else if rules.MaxSubstitutes > 0 && substitutesAfter > rules.MaxSubstitutes &&
+		(complete || substitutesAfter > substitutesBefore) {
+		return TooManySubstitutes
+	} else if mainAfter < rules.MinMainRoster && (complete || mainAfter < mainBefore) {
+		return MainRosterTooSmall
+	}
+
+	for _, position := range rules.RequiredPositions {
+		if !hasPosition(after, position) && (complete || hasPosition(before, position)) {
+			return RequiredPositionMissing
+		}
+	}
+	return ""
+}
+
+func countRoster(roster []*RosterSlot) (int, int) {
+	main, substitutes := 0, 0
+	for _, slot := range roster {
+		if slot.MainRoster {
+			main++
+		} else {
+			substitutes++
+		}
+	}
+	return main, substitutes
+}
+
+func hasPosition(roster []*RosterSlot, position string) bool {
+	for _, slot := range roster {
+		if slot.MainRoster && slot.Position == position {
+			return true
+		}
+	}
+	return false
+}
+
+func addToRoster(mainRoster bool, position string) rosterChange {
+	return func(roster []*RosterSlot) []*RosterSlot {
+		return append(append(make([]*RosterSlot, 0), roster...), &RosterSlot{MainRoster: mainRoster, Position: position})
+	}
+}
+
+func removeFromRoster(playerId int) rosterChange {
+	return func(roster []*RosterSlot) []*RosterSlot {
+		changed := make([]*RosterSlot, 0)
+		for _, slot := range roster {
+			if slot.PlayerId != playerId {
+				changed = append(changed, slot)
+			}
+		}
+		return changed
+	}
+}
+
+// Players edited through the generic endpoints have no position, so only their roster is changed
+func moveInRoster(playerId int, mainRoster bool) rosterChange {
+	return func(roster []*RosterSlot) []*RosterSlot {
+		changed := make([]*RosterSlot, 0)
+		for _, slot := range roster {
+			if slot.PlayerId == playerId {
+				changed = append(changed, &RosterSlot{PlayerId: playerId, MainRoster: mainRoster, Position: slot.Position})
+			} else {
+				changed = append(changed, slot)
+			}
+		}
+		return changed
+	}
+}
+
+func replaceInRoster(playerId int, mainRoster bool, position string) rosterChange {
+	return func(roster []*RosterSlot) []*RosterSlot {
+		changed := make([]*RosterSlot, 0)
+		for _, slot := range roster {
+			if slot.PlayerId == playerId {
+				changed = append(changed, &RosterSlot{PlayerId: playerId, MainRoster: mainRoster, Position: position})
+			} else {
+				changed = append(changed, slot)
+			}
+		}
+		return changed
+	}
+}
+
+// After the roster lock only league administrators can add, remove or change players. Players added or removed by
+// anyone else are queued as roster changes instead, which are validated as if made by an administrator
+func validateRosterChange(leagueId, teamId int, administrator bool, change rosterChange,
+	leagueDao LeagueDAO, teamDao TeamDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		rules, err := leagueDao.GetRosterRules(leagueId)
+		if err != nil {
+			*errorDest = err
+			return false
+		}
+		if rules.Locked() && !administrator {
+			*problemDest = RosterLocked
+			return false
+		}
+
+		before, err := teamDao.GetRoster(teamId)
+		if err != nil {
+			*errorDest = err
+			return false
+		}
+		if problem := rules.rosterProblem(before, change(before), false); problem != "" {
+			*problemDest = problem
+			return false
+		}
+		return true
+	}
+}
+
+func validateCompleteRoster(leagueId int, roster []*RosterSlot, leagueDao LeagueDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		rules, err := leagueDao.GetRosterRules(leagueId)
+		if err != nil {
+			*errorDest = err
+			return false
+		}
+		if problem := rules.rosterProblem(nil, roster, true); problem != "" {
+			*problemDest = problem
+			return false
+		}
+		return true
+	}
+}
+
+func ValidatePlayerRemoval(leagueId, teamId, playerId int, administrator bool,
+	leagueDao LeagueDAO, teamDao TeamDAO) (bool, string, error) {
+	return validate(validateRosterChange(leagueId, teamId, administrator, removeFromRoster(playerId), leagueDao, teamDao))
+}
@@ -3,6 +3,7 @@ package dataModel
 import (
 	"Server/scheduler"
 	"math"
+	"strings"
 )
 
 type AvailabilityCore struct {
@@ -120,15 +121,48 @@ type WeeklyAvailability struct {
 	Duration       int    `json:"duration"`
 }
 
+type TeamBlackoutCore struct {
+	StartTime int `json:"startTime"`
+	EndTime   int `json:"endTime"`
+}
+
+type TeamBlackout struct {
+	BlackoutId int `json:"blackoutId"`
+	TeamId     int `json:"teamId"`
+	StartTime  int `json:"startTime"`
+	EndTime    int `json:"endTime"`
+}
+
+func (blackout *TeamBlackoutCore) Validate() (bool, string, error) {
+	return validate(blackout.timestamps())
+}
+
+func (blackout *TeamBlackoutCore) timestamps() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if blackout.StartTime >= blackout.EndTime {
+			*problemDest = BlackoutOutOfOrder
+			return false
+		} else {
+			return true
+		}
+	}
+}
+
+// In leagues with divisions, round robin tournaments are played within each division and every team also plays up
+// to CrossDivisionGames games against teams of other divisions
 type SchedulingParameters struct {
-	TournamentType    string `json:"tournamentType"`
-	RoundsPerWeek     int    `json:"roundsPerWeek"`
-	ConcurrentGameNum int    `json:"concurrentGameNum"`
-	GameDuration      int    `json:"gameDuration"`
+	TournamentType     string `json:"tournamentType"`
+	RoundsPerWeek      int    `json:"roundsPerWeek"`
+	ConcurrentGameNum  int    `json:"concurrentGameNum"`
+	GameDuration       int    `json:"gameDuration"`
+	BestOf             int    `json:"bestOf"`
+	Commit             bool   `json:"commit"`
+	CrossDivisionGames int    `json:"crossDivisionGames"`
 }
 
-func (params *SchedulingParameters) Validate() (bool, string, error) {
-	return validate(params.tournamentType())
+func (params *SchedulingParameters) Validate(leagueId int, leagueDao LeagueDAO, teamDao TeamDAO) (bool, string, error) {
+	return validate(params.tournamentType(), params.fullSchedule(), params.schedule(), params.crossDivisionGames(),
+		params.divisions(leagueId, leagueDao, teamDao))
 }
 
 func (params *SchedulingParameters) tournamentType() ValidateFunc {
@@ -141,3 +175,122 @@ func (params *SchedulingParameters) tournamentType() ValidateFunc {
 		}
 	}
 }
+
+func (params *SchedulingParameters) fullSchedule() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if byRound := (&scheduler.Scheduler{}).IsRoundByRoundTournament(params.TournamentType); byRound {
+			*problemDest = TournamentGeneratedByRound
+			return false
+		} else {
+			return true
+		}
+	}
+}
+
+// Games are placed in blocks of the game duration, so a duration of 0 would never fill a week
+func (params *SchedulingParameters) schedule() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if params.RoundsPerWeek < 1 || params.ConcurrentGameNum < 1 || params.GameDuration < 1 {
+			*problemDest = InvalidSchedule
+			return false
+		}
+		return true
+	}
+}
+
+// Divisions are only used when scheduling round robins; brackets and swiss rounds are paired across the league
+func (params *SchedulingParameters) crossDivisionGames() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		s := &scheduler.Scheduler{}
+		tournamentType := s.GetTournamentFromString(strings.ToLower(params.TournamentType))
+		if params.CrossDivisionGames < 0 || params.CrossDivisionGames > MaxCrossDivisionGames {
+			*problemDest = InvalidCrossDivisionGames
+			return false
+		} else if params.CrossDivisionGames > 0 &&
+			(s.IsBracketTournament(tournamentType) || tournamentType == scheduler.Swiss) {
+			*problemDest = CrossDivisionGamesNotSupported
+			return false
+		}
+		return true
+	}
+}
+
+type RoundParameters struct {
+	ConcurrentGameNum int `json:"concurrentGameNum"`
+	GameDuration      int `json:"gameDuration"`
+	BestOf            int `json:"bestOf"`
+}
+
+func (params *RoundParameters) Validate(leagueId int, leagueDao LeagueDAO, gameDao GameDAO) (bool, string, error) {
+	return validate(
+		params.schedule(),
+		params.noDivisions(leagueId, leagueDao),
+		params.previousRoundComplete(leagueId, gameDao))
+}
+
+func (params *RoundParameters) schedule() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if params.ConcurrentGameNum < 1 || params.GameDuration < 1 {
+			*problemDest = InvalidRoundSchedule
+			return false
+		}
+		return true
+	}
+}
+
+// Swiss rounds pair teams across the whole league, so they would ignore its divisions
+func (params *RoundParameters) noDivisions(leagueId int, leagueDao LeagueDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		divisions, err := leagueDao.GetDivisions(leagueId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if len(divisions) > 0 {
+			*problemDest = SwissDivisionsNotSupported
+			return false
+		}
+		return true
+	}
+}
+
+func (params *RoundParameters) previousRoundComplete(leagueId int, gameDao GameDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		games, err := gameDao.GetAllGamesInLeague(leagueId)
+		if err != nil {
+			*errorDest = err
+			return false
+		}
+		for _, game := range games {
+			if !game.Complete {
+				*problemDest = PreviousRoundNotComplete
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Refers to the game at GameIndex in the same generated schedule; its winner or loser plays in this game
+type GameSource struct {
+	GameIndex int  `json:"gameIndex"`
+	Winner    bool `json:"winner"`
+}
+
+type ScheduledGame struct {
+	GameId int `json:"gameId,omitempty"`
+	GameCore
+	Round       int         `json:"round"`
+	Team1Source *GameSource `json:"team1Source,omitempty"`
+	Team2Source *GameSource `json:"team2Source,omitempty"`
+}
+
+func (params *SchedulingParameters) divisions(leagueId int, leagueDao LeagueDAO, teamDao TeamDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		s := &scheduler.Scheduler{}
+		tournamentType := s.GetTournamentFromString(strings.ToLower(params.TournamentType))
+		if s.IsBracketTournament(tournamentType) || tournamentType == scheduler.Swiss {
+			return true
+		}
+		return validateTeamsInDivisions(leagueId, leagueDao, teamDao)(problemDest, errorDest)
+	}
+}
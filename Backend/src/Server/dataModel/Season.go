@@ -0,0 +1,149 @@
+package dataModel
+
+// A competition period of a league. Games, availabilities and standings belong to a season, while teams and their
+// players carry over from one season to the next when the league rolls over
+type SeasonCore struct {
+	Name        string `json:"name"`
+	SeasonStart int    `json:"seasonStart"`
+	SeasonEnd   int    `json:"seasonEnd"`
+}
+
+type Season struct {
+	SeasonId    int    `json:"seasonId"`
+	Name        string `json:"name"`
+	SeasonStart int    `json:"seasonStart"`
+	SeasonEnd   int    `json:"seasonEnd"`
+	Current     bool   `json:"current"`
+}
+
+// Makes a season the current season of its league. If TeamIds is empty every team that has not been archived carries
+// over to the new season, otherwise only the listed teams do and the rest are archived
+type SeasonRolloverCore struct {
+	TeamIds []int `json:"teamIds"`
+}
+
+func (season *SeasonCore) ValidateNew(leagueId int, leagueDao LeagueDAO) (bool, string, error) {
+	return validate(
+		season.name(),
+		season.uniqueness(leagueId, leagueDao),
+		validateSeasonDates(leagueId, 0, season.SeasonStart, season.SeasonEnd, leagueDao))
+}
+
+func (season *SeasonCore) name() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		if len(season.Name) > MaxNameLength {
+			*problemDest = NameTooLong
+			return false
+		} else if len(season.Name) < MinInformationLength {
+			*problemDest = NameTooShort
+			return false
+		}
+		return true
+	}
+}
+
+func (season *SeasonCore) uniqueness(leagueId int, leagueDao LeagueDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		inUse, err := leagueDao.IsSeasonNameInUse(leagueId, season.Name)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if inUse {
+			*problemDest = SeasonNameInUse
+			return false
+		}
+		return true
+	}
+}
+
+// Seasons of a league can not overlap, so every game and availability belongs to exactly one competition period.
+// The season with seasonId is left out so that the dates of an existing season can be changed
+func validateSeasonDates(leagueId, seasonId, seasonStart, seasonEnd int, leagueDao LeagueDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		if seasonStart > seasonEnd {
+			*problemDest = TimeOutOfOrder
+			return false
+		}
+
+		seasons, err := leagueDao.GetSeasons(leagueId)
+		if err != nil {
+			*errorDest = err
+			return false
+		}
+		for _, other := range seasons {
+			if other.SeasonId != seasonId && seasonStart < other.SeasonEnd && other.SeasonStart < seasonEnd {
+				*problemDest = SeasonsOverlap
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func (rollover *SeasonRolloverCore) Validate(leagueId, seasonId int, leagueDao LeagueDAO,
+	teamDao TeamDAO) (bool, string, error) {
+	return validate(
+		rollover.season(leagueId, seasonId, leagueDao),
+		rollover.teams(leagueId, teamDao))
+}
+
+// A league can only roll over to a season that comes after the current one
+func (rollover *SeasonRolloverCore) season(leagueId, seasonId int, leagueDao LeagueDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		season, err := leagueDao.GetSeason(seasonId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if season.Current {
+			*problemDest = SeasonAlreadyCurrent
+			return false
+		}
+
+		currentSeasonId, err := leagueDao.GetCurrentSeasonId(leagueId)
+		if err != nil {
+			*errorDest = err
+			return false
+		}
+		currentSeason, err := leagueDao.GetSeason(currentSeasonId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if currentSeason != nil && season.SeasonStart < currentSeason.SeasonEnd {
+			*problemDest = SeasonBeforeCurrent
+			return false
+		}
+		return true
+	}
+}
+
+func (rollover *SeasonRolloverCore) teams(leagueId int, teamDao TeamDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		selected := make(map[int]bool)
+		for _, teamId := range rollover.TeamIds {
+			if selected[teamId] {
+				*problemDest = RolloverTeamRepeated
+				return false
+			}
+			selected[teamId] = true
+
+			exists, err := teamDao.DoesTeamExistInLeague(leagueId, teamId)
+			if err != nil {
+				*errorDest = err
+				return false
+			} else if !exists {
+				*problemDest = RolloverTeamDoesNotExist
+				return false
+			}
+
+			archived, err := teamDao.IsTeamArchived(leagueId, teamId)
+			if err != nil {
+				*errorDest = err
+				return false
+			} else if archived {
+				*problemDest = RolloverTeamArchived
+				return false
+			}
+		}
+		return true
+	}
+}
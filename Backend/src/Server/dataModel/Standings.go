@@ -0,0 +1,61 @@
+package dataModel
+
+import "Server/standings"
+
+type StandingsConfiguration struct {
+	WinPoints   int      `json:"winPoints"`
+	DrawPoints  int      `json:"drawPoints"`
+	LossPoints  int      `json:"lossPoints"`
+	Tiebreakers []string `json:"tiebreakers"`
+}
+
+type Standing struct {
+	Team               TeamDisplay `json:"team"`
+	Rank               int         `json:"rank"`
+	Played             int         `json:"played"`
+	Wins               int         `json:"wins"`
+	Draws              int         `json:"draws"`
+	Losses             int         `json:"losses"`
+	Points             int         `json:"points"`
+	GameDifferential   int         `json:"gameDifferential"`
+	StrengthOfSchedule float64     `json:"strengthOfSchedule"`
+	SonnebornBerger    float64     `json:"sonnebornBerger"`
+	Withdrawn          bool        `json:"withdrawn"`
+}
+
+func (config *StandingsConfiguration) Validate() (bool, string, error) {
+	return validate(config.points(), config.tiebreakers())
+}
+
+func (config *StandingsConfiguration) points() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		for _, points := range []int{config.WinPoints, config.DrawPoints, config.LossPoints} {
+			if points < 0 || points > MaxStandingsPoints {
+				*problemDest = InvalidStandingsPoints
+				return false
+			}
+		}
+		if config.WinPoints < config.DrawPoints || config.DrawPoints < config.LossPoints {
+			*problemDest = StandingsPointsOutOfOrder
+			return false
+		}
+		return true
+	}
+}
+
+func (config *StandingsConfiguration) tiebreakers() ValidateFunc {
+	return func(problemDest *string, _ *error) bool {
+		selected := make(map[string]bool)
+		for _, tiebreaker := range config.Tiebreakers {
+			if !standings.IsTiebreaker(tiebreaker) {
+				*problemDest = TiebreakerNotSupported
+				return false
+			} else if selected[tiebreaker] {
+				*problemDest = TiebreakerRepeated
+				return false
+			}
+			selected[tiebreaker] = true
+		}
+		return true
+	}
+}
@@ -1,5 +1,7 @@
 package dataModel
 
+import "time"
+
 type TeamDAO interface {
 	// Teams
 	CreateTeam(leagueId, userId int, teamInfo TeamCore) (int, error)
@@ -13,21 +15,59 @@ type TeamDAO interface {
 	GetAllTeamsInLeague(leagueId int) ([]*TeamWithPlayers, error)
 	GetAllTeamsInLeagueWithRosters(leagueId int) ([]*TeamWithRosters, error)
 	GetAllTeamDisplaysInLeague(leagueId int) ([]*TeamDisplay, error)
+	GetSeasonTeamDisplays(seasonId int) ([]*TeamDisplay, error)
+	GetWithdrawnTeamIds(seasonId int) ([]int, error)
+	GetPendingTeams(leagueId int) ([]*TeamWithPlayers, error)
+	ApproveTeam(teamId int) error
+	WithdrawTeam(teamId int) error
+	GetArchivedTeams(leagueId int) ([]*TeamWithPlayers, error)
 
 	// Players
 	CreatePlayer(leagueId, teamId int, playerInfo PlayerCore) (int, error)
 	DeletePlayer(playerId int) error
 	UpdatePlayer(playerId int, playerInfo PlayerCore) error
+	GetRoster(teamId int) ([]*RosterSlot, error)
+
+	// Transfers
+	TransferPlayer(playerId, fromTeamId, userId int, transfer PlayerTransferCore) (int, error)
+	GetTeamTransfers(teamId int) ([]*PlayerTransfer, error)
+	GetLeagueTransfers(leagueId int) ([]*PlayerTransfer, error)
 
 	// Get Information For Team and Player Management
 	GetTeamPermissions(teamId, userId int) (*TeamPermissionsCore, error)
 	IsInfoInUse(leagueId, teamId int, name, tag string) (bool, string, error)
 	DoesTeamExistInLeague(leagueId, teamId int) (bool, error)
+	IsTeamPending(leagueId, teamId int) (bool, error)
+	IsTeamArchived(leagueId, teamId int) (bool, error)
 	IsTeamActive(leagueId, teamId int) (bool, error)
 	DoesPlayerExist(leagueId, teamId, playerId int) (bool, error)
+	GetPlayerUserId(playerId int) (int, error)
+	IsUserOnRosterInLeague(leagueId, userId int) (bool, error)
 
 	// Managers
 	ChangeManagerPermissions(teamId, userId int, teamPermissionInformation TeamPermissionsCore) error
+
+	// Roster Requests
+	CreateRosterRequest(teamId, userId int, fromTeam bool, request RosterRequestCore) (int, error)
+	GetRosterRequest(requestId int) (*RosterRequest, error)
+	GetTeamRosterRequests(teamId int) ([]*RosterRequest, error)
+	GetUserRosterInvites(userId int) ([]*RosterRequest, error)
+	AcceptRosterRequest(requestId int) (int, error)
+	DeleteRosterRequest(requestId int) error
+	DoesRosterRequestExist(teamId, requestId int) (bool, error)
+	HasPendingRosterRequest(teamId, userId int) (bool, error)
+
+	// Roster Changes
+	CreateRosterChange(teamId, userId int, change RosterChangeCore) (int, error)
+	GetRosterChange(leagueId, rosterChangeId int) (*RosterChange, error)
+	GetRosterChanges(leagueId int) ([]*RosterChange, error)
+	DeleteRosterChange(rosterChangeId int) error
+
+	// Blackouts
+	AddTeamBlackout(teamId int, blackout TeamBlackoutCore) (int, error)
+	GetTeamBlackouts(teamId int) ([]*TeamBlackout, error)
+	GetAllTeamBlackoutsInLeague(leagueId int) ([]*TeamBlackout, error)
+	DeleteTeamBlackout(teamId, blackoutId int) error
 }
 
 type TeamWithPlayersCore struct {
@@ -36,8 +76,9 @@ type TeamWithPlayersCore struct {
 	Players []PlayerCore `json:"players"`
 }
 
-func (team *TeamWithPlayersCore) Validate(leagueId int, teamDao TeamDAO) (bool, string, error) {
-	valid, problem, err := team.Team.ValidateNew(leagueId, teamDao)
+func (team *TeamWithPlayersCore) Validate(leagueId int, administrator bool, leagueDao LeagueDAO,
+	teamDao TeamDAO) (bool, string, error) {
+	valid, problem, err := team.Team.ValidateNew(leagueId, administrator, leagueDao, teamDao)
 	if !valid || problem != "" || err != nil {
 		return valid, problem, err
 	}
@@ -63,14 +104,16 @@ func (team *TeamWithPlayersCore) Validate(leagueId int, teamDao TeamDAO) (bool,
 	}
 
 	// Validate each player normally
+	roster := make([]*RosterSlot, 0)
 	for _, player := range team.Players {
-		valid, problem, err := player.ValidateNew(leagueId, -1, teamDao)
+		valid, problem, err := player.validate(leagueId, 0, teamDao)
 		if !valid || problem != "" || err != nil {
 			return valid, problem, err
 		}
+		roster = append(roster, &RosterSlot{MainRoster: player.MainRoster})
 	}
 
-	return true, "", nil
+	return validate(validateCompleteRoster(leagueId, roster, leagueDao))
 }
 
 type TeamWithPlayers struct {
@@ -142,14 +185,54 @@ func (team *TeamCore) validate(leagueId, teamId int, teamDao TeamDAO) (bool, str
 		team.description())
 }
 
-func (team *TeamCore) ValidateNew(leagueId int, teamDao TeamDAO) (bool, string, error) {
-	return team.validate(leagueId, 0, teamDao)
+// Only league administrators can register teams outside of the signup period
+func (team *TeamCore) ValidateNew(leagueId int, administrator bool, leagueDao LeagueDAO,
+	teamDao TeamDAO) (bool, string, error) {
+	valid, problem, err := validate(validateRegistrationOpen(leagueId, administrator, leagueDao))
+	if !valid || problem != "" || err != nil {
+		return valid, problem, err
+	} else {
+		return team.validate(leagueId, 0, teamDao)
+	}
 }
 
 func (team *TeamCore) ValidateEdit(leagueId, teamId int, teamDao TeamDAO) (bool, string, error) {
 	return team.validate(leagueId, teamId, teamDao)
 }
 
+func ValidateTeamPending(leagueId, teamId int, teamDao TeamDAO) (bool, string, error) {
+	return validate(func(problemDest *string, errorDest *error) bool {
+		pending, err := teamDao.IsTeamPending(leagueId, teamId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if !pending {
+			*problemDest = TeamNotPending
+			return false
+		}
+		return true
+	})
+}
+
+func validateRegistrationOpen(leagueId int, administrator bool, leagueDao LeagueDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		if administrator {
+			return true
+		}
+		leagueInformation, err := leagueDao.GetLeagueInformation(leagueId)
+		if err != nil {
+			*errorDest = err
+			return false
+		}
+		now := time.Now().Unix()
+		if now < int64(leagueInformation.SignupStart) || now > int64(leagueInformation.SignupEnd) {
+			*problemDest = TeamRegistrationClosed
+			return false
+		}
+		return true
+	}
+}
+
 func (team *TeamCore) name() ValidateFunc {
 	return func(problemDest *string, _ *error) bool {
 		valid := false
@@ -0,0 +1,86 @@
+package dataModel
+
+// Moves a player to another team in the same league, onto either its main or substitute roster
+type PlayerTransferCore struct {
+	TeamId     int  `json:"teamId"`
+	MainRoster bool `json:"mainRoster"`
+}
+
+type PlayerTransfer struct {
+	TransferId     int         `json:"transferId"`
+	PlayerId       int         `json:"playerId"`
+	Name           string      `json:"name"`
+	GameIdentifier string      `json:"gameIdentifier"`
+	FromTeam       TeamDisplay `json:"fromTeam"`
+	ToTeam         TeamDisplay `json:"toTeam"`
+	UserId         int         `json:"userId"`
+	TransferredAt  int         `json:"transferredAt"`
+}
+
+// The player leaves one roster and joins another, so the roster rules of the league are checked for both teams
+func (transfer *PlayerTransferCore) Validate(leagueId, teamId, playerId int, administrator bool,
+	leagueDao LeagueDAO, teamDao TeamDAO) (bool, string, error) {
+	return validate(
+		transfer.destination(leagueId, teamId, teamDao),
+		validateRosterChange(leagueId, teamId, administrator, removeFromRoster(playerId), leagueDao, teamDao),
+		transfer.rosterRules(leagueId, teamId, playerId, administrator, leagueDao, teamDao))
+}
+
+// Players can only join teams that are playing, so not teams waiting for approval or that have withdrawn
+func (transfer *PlayerTransferCore) destination(leagueId, teamId int, teamDao TeamDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		if transfer.TeamId == teamId {
+			*problemDest = TransferToSameTeam
+			return false
+		}
+
+		exists, err := teamDao.DoesTeamExistInLeague(leagueId, transfer.TeamId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if !exists {
+			*problemDest = TransferTeamDoesNotExist
+			return false
+		}
+
+		pending, err := teamDao.IsTeamPending(leagueId, transfer.TeamId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if pending {
+			*problemDest = TransferTeamPending
+			return false
+		}
+
+		archived, err := teamDao.IsTeamArchived(leagueId, transfer.TeamId)
+		if err != nil {
+			*errorDest = err
+			return false
+		} else if archived {
+			*problemDest = TransferTeamArchived
+			return false
+		}
+		return true
+	}
+}
+
+// The player keeps their position when joining the other team
+func (transfer *PlayerTransferCore) rosterRules(leagueId, teamId, playerId int, administrator bool,
+	leagueDao LeagueDAO, teamDao TeamDAO) ValidateFunc {
+	return func(problemDest *string, errorDest *error) bool {
+		roster, err := teamDao.GetRoster(teamId)
+		if err != nil {
+			*errorDest = err
+			return false
+		}
+
+		position := ""
+		for _, slot := range roster {
+			if slot.PlayerId == playerId {
+				position = slot.Position
+			}
+		}
+		return validateRosterChange(leagueId, transfer.TeamId, administrator,
+			addToRoster(transfer.MainRoster, position), leagueDao, teamDao)(problemDest, errorDest)
+	}
+}
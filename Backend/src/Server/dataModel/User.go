@@ -7,6 +7,7 @@ type UserDAO interface {
 	IsEmailInUse(email string) (bool, error)
 	GetAuthenticationInformation(email string) (*UserAuthenticationDTO, error)
 	GetUserProfile(userId int) (*User, error)
+	GetUserIdByEmail(email string) (int, error)
 	GetUserWithPermissions(leagueId, userId int) (*UserWithPermissions, error)
 }
 
@@ -22,8 +23,17 @@ type UserAuthenticationDTO struct {
 }
 
 type User struct {
-	UserId int    `json:"userId"`
-	Email  string `json:"email"`
+	UserId int         `json:"userId"`
+	Email  string      `json:"email"`
+	Teams  []*UserTeam `json:"teams"`
+}
+
+// A team in any league that the user is a player of
+type UserTeam struct {
+	LeagueId   int         `json:"leagueId"`
+	LeagueName string      `json:"leagueName"`
+	PlayerId   int         `json:"playerId"`
+	Team       TeamDisplay `json:"team"`
 }
 
 type UserWithPermissions struct {
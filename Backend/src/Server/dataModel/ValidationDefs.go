@@ -14,13 +14,20 @@ func validate(validators ...ValidateFunc) (bool, string, error) {
 }
 
 const (
-	MaxTagLength         = 5
-	MaxNameLength        = 50
-	MaxDescriptionLength = 500
-	MaxMdLength          = 50000
-	MaxPasswordLength    = 64
-	MinInformationLength = 2
-	MinPasswordLength    = 8
+	MaxTagLength               = 5
+	MaxNameLength              = 50
+	MaxDescriptionLength       = 500
+	MaxMdLength                = 50000
+	MaxPasswordLength          = 64
+	MinInformationLength       = 2
+	MinPasswordLength          = 8
+	MaxStandingsPoints         = 100
+	MaxResultConfirmationHours = 24 * 14
+	MaxInviteUses              = 1000
+	MaxRosterSize              = 50
+	MaxPositionLength          = 20
+	MaxCrossDivisionGames      = 50
+	MaxAdvancingPerGroup       = 16
 )
 
 type DataProblem string
@@ -62,6 +69,103 @@ const (
 	TournamentTypeNotSupported        = "The specified tournament type is not supported"
 	AvailabilitiesNotDuringLeague     = "New league times would result in an availability outside the league competition period"
 	GamesNotDuringLeague              = "New league times would result in a game scheduled outside the league competition period"
+	GameTeamsNotDetermined            = "The teams in this game have not been determined yet"
+	TournamentGeneratedByRound        = "This tournament type must be generated one round at a time"
+	InvalidSchedule                   = "Rounds per week, concurrent games and game duration must be at least 1"
+	InvalidRoundSchedule              = "Concurrent games and game duration must be at least 1"
+	PreviousRoundNotComplete          = "All games must be complete before the next round can be generated"
+	SwissDivisionsNotSupported        = "Swiss rounds cannot be generated in a league with divisions"
+	BlackoutOutOfOrder                = "Blackout start time must be before end time"
+	InvalidBestOf                     = "Games must be a best of 1, 3 or 5"
+	SeriesScoreInvalid                = "The winner of a series must have won the majority of its games"
+	SeriesAlreadyDecided              = "This series has already been decided"
+	InvalidTimeZone                   = "Time zone must be a valid IANA time zone name such as 'America/New_York'"
+	GameAlreadyReported               = "This game already has a result, amend the result to change it"
+	GameNotReported                   = "Only the result of a completed game can be amended"
+	AmendmentReasonMissing            = "A reason must be given for amending a result"
+	AmendmentReasonTooLong            = "Amendment reason too long"
+	NextGameAlreadyReported           = "The winner can not be changed after the bracket games it advanced to have been reported"
+	InvalidOutcome                    = "Outcome must be one of 'win', 'draw', 'forfeit' or 'doubleForfeit'"
+	OutcomeHasNoWinner                = "A draw or double forfeit can not have a winner or loser"
+	DrawScoresNotEqual                = "Both teams must have the same score in a draw"
+	SeriesCanNotBeDrawn               = "A series can not end in a draw"
+	BracketGameNeedsWinner            = "A bracket game must have a winner to advance"
+	ResultAwaitingConfirmation        = "The opposing team has submitted a result for this game, confirm or dispute it instead"
+	ResultDisputed                    = "The result of this game is disputed and must be resolved by the league"
+	NoResultSubmitted                 = "No result has been submitted for this game"
+	DisputeReasonMissing              = "A reason must be given for disputing a result"
+	DisputeReasonTooLong              = "Dispute reason too long"
+	InvalidResultConfirmationHours    = "Result confirmation window must be between 0 and 336 hours inclusive"
+	InvalidStandingsPoints            = "Points for a win, draw or loss must be between 0 and 100 inclusive"
+	StandingsPointsOutOfOrder         = "A win must be worth at least as many points as a draw, and a draw at least as many as a loss"
+	TiebreakerNotSupported            = "The specified tiebreaker is not supported"
+	TiebreakerRepeated                = "Each tiebreaker can only be selected once"
+	InvalidInviteUses                 = "Invite uses must be between 0 and 1000 inclusive, where 0 allows unlimited uses"
+	InviteExpiryInPast                = "An invite must expire in the future"
+	InviteRevoked                     = "This invite has been revoked"
+	InviteExpired                     = "This invite has expired"
+	InviteUsedUp                      = "This invite has no uses remaining"
+	InviteForDifferentEmail           = "This invite was sent to a different email address"
+	AlreadyLeagueMember               = "Already a member of this league"
+	NoUserWithEmail                   = "No user has this email"
+	PlayerNotOnTeam                   = "This player is not on this team"
+	PlayerAlreadyLinked               = "This player is already linked to a user"
+	AlreadyOnRoster                   = "This user is already a player on a team in this league"
+	RosterRequestPending              = "There is already a pending request for this user to join this team"
+	TeamRegistrationClosed            = "Teams can only be registered during the signup period of the league"
+	TeamNotPending                    = "This team is not awaiting approval"
+	TeamInGamePending                 = "A team in this game is awaiting approval by the league"
+	InvalidRosterSize                 = "Roster sizes must be between 0 and 50 inclusive"
+	RosterSizesOutOfOrder             = "The maximum main roster size can not be less than the minimum"
+	InvalidPosition                   = "Positions must be between 1 and 20 characters long"
+	PositionRepeated                  = "Each position can only be required once"
+	TooManyRequiredPositions          = "There can not be more required positions than main roster spots"
+	InvalidRosterLock                 = "Roster lock can not be negative"
+	RosterLocked                      = "Rosters are locked, changes must be made by a league administrator"
+	MainRosterTooLarge                = "The main roster of this team is full"
+	TooManySubstitutes                = "The substitute roster of this team is full"
+	MainRosterTooSmall                = "The main roster of this team would have fewer players than the league requires"
+	RequiredPositionMissing           = "A position required by the league would not be filled on the main roster"
+	RosterChangeDoesNotExist          = "This roster change does not exist in this league"
+	RosterChangeStale                 = "The player of this roster change is no longer on the team"
+	TransferToSameTeam                = "A player can not be transferred to the team they are already on"
+	TransferTeamDoesNotExist          = "The team to transfer the player to does not exist in this league"
+	TransferTeamPending               = "The team to transfer the player to has not been approved yet"
+	TransferTeamArchived              = "The team to transfer the player to has been archived"
+	InvalidWithdrawalPolicy           = "Withdrawal policy must be one of 'forfeit' or 'cancel'"
+	TeamInGameWithdrawn               = "A team in this game has withdrawn from the league"
+	SeasonNameInUse                   = "Season name already in use in this league"
+	SeasonsOverlap                    = "Seasons of a league can not overlap"
+	SeasonAlreadyCurrent              = "This season is already the current season"
+	SeasonBeforeCurrent               = "A league can only roll over to a season that starts after the current season ends"
+	RolloverTeamDoesNotExist          = "A team to carry over does not exist in this league"
+	RolloverTeamArchived              = "A team to carry over has been archived"
+	RolloverTeamRepeated              = "Each team can only be carried over once"
+	DivisionNameInUse                 = "Division name already in use in this league"
+	DivisionTeamDoesNotExist          = "A team to add to the division does not exist in this league"
+	DivisionTeamArchived              = "A team to add to the division has been archived"
+	DivisionTeamRepeated              = "Each team can only be added to the division once"
+	InvalidCrossDivisionGames         = "Cross division games must be between 0 and 50 inclusive"
+	CrossDivisionGamesNotSupported    = "Cross division games can only be scheduled in round robin tournaments"
+	TeamsWithoutDivision              = "Every team must be in a division, teams without a division: "
+	PlayoffTournamentTypeNotSupported = "Playoff stages must be a single or double elimination bracket"
+	InvalidAdvancingPerGroup          = "Teams advancing from each group must be between 1 and 16 inclusive"
+	InvalidPlayoffSchedule            = "Rounds per week, concurrent games and game duration must be at least 1"
+	PlayoffStageAlreadyGenerated      = "The games of this playoff stage have already been created"
+	PlayoffStageNotDefined            = "This season does not have a playoff stage"
+	PlayoffStageNotCurrentSeason      = "Only the playoff stage of the current season can be generated"
+	GroupStageNotComplete             = "Every game of the group stage must be complete before the playoffs"
+	NotEnoughPlayoffTeams             = "At least two teams must advance to the playoffs"
+	InvalidTournamentMetadata         = "The metadata of the tournament code is not of a game of this league"
+	TournamentCodeNotOfGame           = "The tournament code was not created for the game in its metadata"
+	TournamentTeamRosterEmpty         = "Both teams must have non-empty main rosters"
+	TournamentRosterTooSmall          = "Both teams must have at least as many players as the team size of the tournament"
+	InvalidLoLRegion                  = "The specified region is not supported"
+	InvalidLoLMapType                 = "The specified map type is not supported"
+	InvalidLoLPickType                = "The specified pick type is not supported"
+	InvalidLoLSpectatorType           = "The specified spectator type is not supported"
+	InvalidLoLTeamSize                = "Team size must be between 1 and 5 inclusive"
+	TournamentRegionRegistered        = "The region can not be changed once the league is registered as a tournament"
 )
 
 var ValidGameStrings = [...]string{
@@ -79,3 +183,40 @@ var ValidGameStrings = [...]string{
 	"leagueoflegends",
 	"overwatch",
 }
+
+// Platforms that tournaments can be registered on, and the regional host that serves the tournament API for each
+var LoLRegions = map[string]string{
+	"BR":   "americas",
+	"LAN":  "americas",
+	"LAS":  "americas",
+	"NA":   "americas",
+	"OCE":  "americas",
+	"PBE":  "americas",
+	"JP":   "asia",
+	"KR":   "asia",
+	"EUNE": "europe",
+	"EUW":  "europe",
+	"RU":   "europe",
+	"TR":   "europe",
+}
+
+var ValidLoLMapTypes = [...]string{
+	"SUMMONERS_RIFT",
+	"TWISTED_TREELINE",
+	"HOWLING_ABYSS",
+}
+
+var ValidLoLPickTypes = [...]string{
+	"BLIND_PICK",
+	"DRAFT_MODE",
+	"ALL_RANDOM",
+	"TOURNAMENT_DRAFT",
+}
+
+var ValidLoLSpectatorTypes = [...]string{
+	"NONE",
+	"LOBBYONLY",
+	"ALL",
+}
+
+const MaxLoLTeamSize = 5
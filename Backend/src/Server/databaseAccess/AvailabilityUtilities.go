@@ -10,6 +10,7 @@ type AvailabilityArray struct {
 	rows []*dataModel.Availability
 }
 
+// Availabilities of the current season of the league
 func getAvailabilitySelector(leagueId int) squirrel.SelectBuilder {
 	return psql.Select(
 		"availability_id",
@@ -17,7 +18,7 @@ func getAvailabilitySelector(leagueId int) squirrel.SelectBuilder {
 		"end_time",
 	).
 		From("availability").
-		Where("is_recurring_weekly = false AND league_id = ?", leagueId)
+		Where("is_recurring_weekly = false AND league_id = ? AND "+inCurrentSeason, leagueId)
 
 }
 
@@ -48,6 +49,7 @@ type WeeklyAvailabilityArray struct {
 	rows []*dataModel.WeeklyAvailability
 }
 
+// Weekly availabilities of the current season of the league
 func getWeeklyAvailabilitySelector(leagueId int) squirrel.SelectBuilder {
 	return psql.Select(
 		"availability.availability_id",
@@ -61,7 +63,7 @@ func getWeeklyAvailabilitySelector(leagueId int) squirrel.SelectBuilder {
 	).
 		From("availability").
 		Join("weekly_recurrence ON availability.availability_id = weekly_recurrence.availability_id").
-		Where("is_recurring_weekly = true AND league_id = ?", leagueId)
+		Where("is_recurring_weekly = true AND league_id = ? AND "+inCurrentSeason, leagueId)
 }
 
 func GetScannedWeeklyAvailability(rows squirrel.RowScanner) (*dataModel.WeeklyAvailability, error) {
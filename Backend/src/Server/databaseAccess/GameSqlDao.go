@@ -2,6 +2,7 @@ package databaseAccess
 
 import (
 	"Server/dataModel"
+	"database/sql"
 	"fmt"
 	"github.com/snabb/isoweek"
 	"time"
@@ -16,47 +17,120 @@ type GameSqlDao struct{}
 // Modify Games
 
 func (d *GameSqlDao) CreateGame(leagueId int, gameInformation dataModel.GameCreationInformation) (int, error) {
-	gameId := -1
-	err := psql.Insert("game").
-		Columns(
-			"league_id",
-			"team1_id",
-			"team2_id",
-			"game_time",
-			"complete",
-			"winner_id",
-			"loser_id",
-			"score_team1",
-			"score_team2",
-		).
-		Values(
-			leagueId,
-			gameInformation.Team1Id,
-			gameInformation.Team2Id,
-			gameInformation.GameTime,
-			false,
-			-1,
-			-1,
-			0,
-			0,
-		).
-		Suffix("RETURNING \"game_id\"").
-		RunWith(db).QueryRow().Scan(&gameId)
+	seasonId, err := getCurrentSeasonId(db, leagueId)
+	if err != nil {
+		return -1, err
+	}
+
+	return insertGame(db, leagueId, seasonId, gameInformation)
+}
+
+func (d *GameSqlDao) CreateGames(leagueId int, games []dataModel.GameCreationInformation) ([]int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
 
-	return gameId, err
+	seasonId, err := getCurrentSeasonId(tx, leagueId)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	gameIds, err := insertGames(tx, leagueId, seasonId, games)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return gameIds, tx.Commit()
+}
+
+func (d *GameSqlDao) CreateBracket(leagueId int, games []dataModel.BracketGameCreationInformation) ([]int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	seasonId, err := getCurrentSeasonId(tx, leagueId)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	gamesInformation := make([]dataModel.GameCreationInformation, 0)
+	for _, game := range games {
+		gamesInformation = append(gamesInformation, game.GameCreationInformation)
+	}
+	gameIds, err := insertGames(tx, leagueId, seasonId, gamesInformation)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Link each game to the games whose results decide its teams
+	for i, game := range games {
+		for slot, source := range []*dataModel.GameSource{game.Team1Source, game.Team2Source} {
+			if source == nil {
+				continue
+			}
+			outcome := "loser"
+			if source.Winner {
+				outcome = "winner"
+			}
+			_, err := psql.Insert("game_progression").
+				Columns(
+					"game_id",
+					"outcome",
+					"next_game_id",
+					"next_game_slot",
+				).
+				Values(
+					gameIds[source.GameIndex],
+					outcome,
+					gameIds[i],
+					slot+1,
+				).
+				RunWith(tx).Exec()
+			if err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+		}
+	}
+
+	return gameIds, tx.Commit()
 }
 
 func (d *GameSqlDao) ReportGame(gameId int, gameResult dataModel.GameResult) error {
-	_, err := db.Exec("SELECT report_game($1,$2,$3,$4,$5)",
+	scoreTeam1, scoreTeam2 := gameResult.Scores()
+	_, err := db.Exec("SELECT report_game($1,$2,$3,$4,$5,$6)",
 		gameId,
+		gameResult.OutcomeName(),
 		gameResult.WinnerId,
 		gameResult.LoserId,
-		gameResult.ScoreTeam1,
-		gameResult.ScoreTeam2,
+		scoreTeam1,
+		scoreTeam2,
 	)
 	return err
 }
 
+func (d *GameSqlDao) AmendGameResult(gameId, userId int, amendment dataModel.GameResultAmendment) (int, error) {
+	var amendmentId = -1
+	scoreTeam1, scoreTeam2 := amendment.Scores()
+	err := db.QueryRow("SELECT amend_game_result($1,$2,$3,$4,$5,$6,$7,$8)",
+		gameId,
+		userId,
+		amendment.Reason,
+		amendment.OutcomeName(),
+		amendment.WinnerId,
+		amendment.LoserId,
+		scoreTeam1,
+		scoreTeam2,
+	).Scan(&amendmentId)
+	return amendmentId, err
+}
+
 func (d *GameSqlDao) ReportGameByExternalId(externalId string, gameResult dataModel.GameResult) (int, int, error) {
 	var gameId int
 	var leagueId int
@@ -117,33 +191,64 @@ func (d *GameSqlDao) GetGameInformationFromExternalId(externalId string) (*dataM
 	return GetScannedGame(row)
 }
 
+func (d *GameSqlDao) GetSeriesGames(gameId int) ([]*dataModel.SeriesGame, error) {
+	seriesGames := SeriesGameArray{rows: make([]*dataModel.SeriesGame, 0)}
+	if err := ScanRows(getSeriesGameSelector().
+		Where("game_id = ?", gameId).OrderBy("game_number ASC"), &seriesGames); err != nil {
+		return nil, err
+	}
+
+	return seriesGames.rows, nil
+}
+
+func (d *GameSqlDao) GetResultAmendments(gameId int) ([]*dataModel.ResultAmendment, error) {
+	amendments := ResultAmendmentArray{rows: make([]*dataModel.ResultAmendment, 0)}
+	if err := ScanRows(getResultAmendmentSelector().
+		Where("game_id = ?", gameId).OrderBy("amended_at ASC", "amendment_id ASC"), &amendments); err != nil {
+		return nil, err
+	}
+
+	return amendments.rows, nil
+}
+
+// Games of the current season, which scheduling and the league competition period are concerned with
 func (d *GameSqlDao) GetAllGamesInLeague(leagueId int) ([]*dataModel.Game, error) {
 	var games GameArray
 	if err := ScanRows(getGameSelector().
-		Where("game.league_id = ?", leagueId).OrderBy("game.game_time ASC"), &games); err != nil {
+		Where("game.league_id = ? AND game."+inCurrentSeason, leagueId).OrderBy("game.game_time ASC"), &games); err != nil {
 		return nil, err
 	}
 
 	return games.rows, nil
 }
 
-func (d *GameSqlDao) GetSortedGames(leagueId, teamId, limit int) (*dataModel.SortedGames, error) {
+func (d *GameSqlDao) GetAllGamesInSeason(seasonId int) ([]*dataModel.Game, error) {
+	games := GameArray{rows: make([]*dataModel.Game, 0)}
+	if err := ScanRows(getGameSelector().
+		Where("game.season_id = ?", seasonId).OrderBy("game.game_time ASC"), &games); err != nil {
+		return nil, err
+	}
+
+	return games.rows, nil
+}
+
+func (d *GameSqlDao) GetSortedGames(seasonId, teamId, limit int) (*dataModel.SortedGames, error) {
 	var games dataModel.SortedGames
 	gameSelectorCompleted := getGameSelector()
 	gameSelectorUpcoming := getGameSelector()
 
 	if teamId == 0 {
 		gameSelectorCompleted = gameSelectorCompleted.
-			Where("game.league_id = ? AND game.complete = true", leagueId)
+			Where("game.season_id = ? AND game.complete = true", seasonId)
 		gameSelectorUpcoming = gameSelectorUpcoming.
-			Where("game.league_id = ? AND game.complete = false", leagueId)
+			Where("game.season_id = ? AND game.complete = false", seasonId)
 	} else {
 		gameSelectorCompleted = gameSelectorCompleted.
-			Where("game.league_id = ? AND game.complete = true AND "+
-				"(game.team1_id = ? OR game.team2_id = ?)", leagueId, teamId, teamId)
+			Where("game.season_id = ? AND game.complete = true AND "+
+				"(game.team1_id = ? OR game.team2_id = ?)", seasonId, teamId, teamId)
 		gameSelectorUpcoming = gameSelectorUpcoming.
-			Where("game.league_id = ? AND game.complete = false AND "+
-				"(game.team1_id = ? OR game.team2_id = ?)", leagueId, teamId, teamId)
+			Where("game.season_id = ? AND game.complete = false AND "+
+				"(game.team1_id = ? OR game.team2_id = ?)", seasonId, teamId, teamId)
 	}
 	gameSelectorCompleted = gameSelectorCompleted.OrderBy("game.game_time ASC")
 	gameSelectorUpcoming = gameSelectorUpcoming.OrderBy("game.game_time ASC")
@@ -247,35 +352,54 @@ func (d *GameSqlDao) HasReportResultPermissions(leagueId, gameId, userId int) (b
 	return canReport, err
 }
 
-func (d *GameSqlDao) GetGamesByWeek(leagueId, timeZone int) ([]*dataModel.CompetitionWeek, error) {
-	games, err := d.GetAllGamesInLeague(leagueId)
-	if err != nil {
-		return nil, err
+func (d *GameSqlDao) HasCompletedNextGame(gameId int) (bool, error) {
+	var count int
+	if err := psql.Select("count(*)").
+		From("game_progression").
+		Join("game ON game.game_id = game_progression.next_game_id").
+		Where("game_progression.game_id = ? AND game.complete = true", gameId).
+		RunWith(db).QueryRow().Scan(&count); err != nil {
+		return false, err
+	} else {
+		return count > 0, nil
 	}
+}
 
-	competitionWeeks := make([]*dataModel.CompetitionWeek, 0)
-	if len(games) == 0 {
-		return competitionWeeks, nil
+func (d *GameSqlDao) IsBracketGame(gameId int) (bool, error) {
+	var count int
+	if err := psql.Select("count(*)").
+		From("game_progression").
+		Where("game_id = ? OR next_game_id = ?", gameId, gameId).
+		RunWith(db).QueryRow().Scan(&count); err != nil {
+		return false, err
+	} else {
+		return count > 0, nil
 	}
+}
 
-	// Create initial objects for the first week of games
-	fmt.Printf("first game: %+v", games[0])
-	fmt.Printf("offset: %+v", timeZone)
-	year, week := time.Unix(int64(games[0].GameTime), 0).ISOWeek()
-	weekStart := isoweek.StartTime(year, week, time.FixedZone("", timeZone))
-	competitionWeek := &dataModel.CompetitionWeek{
-		WeekStart: int(weekStart.Unix()),
-		Games:     make([]*dataModel.Game, 0),
+// If divisionId is set, only games that a team of the division plays in are included
+func (d *GameSqlDao) GetGamesByWeek(seasonId, divisionId int,
+	location *time.Location) ([]*dataModel.CompetitionWeek, error) {
+	gameSelector := getGameSelector().Where("game.season_id = ?", seasonId)
+	if divisionId != 0 {
+		gameSelector = gameSelector.Where("(team1.division_id = ? OR team2.division_id = ?)", divisionId, divisionId)
 	}
-	competitionWeeks = append(competitionWeeks, competitionWeek)
 
-	// Add all games to a week struct, creating a new week as necessary
-	for _, game := range games {
-		// Make new week if game is after the end of current week
-		if time.Unix(int64(game.GameTime), 0).After(weekStart.AddDate(0, 0, 7)) {
-			weekStart = weekStart.AddDate(0, 0, 7)
+	var games GameArray
+	if err := ScanRows(gameSelector.OrderBy("game.game_time ASC"), &games); err != nil {
+		return nil, err
+	}
+
+	competitionWeeks := make([]*dataModel.CompetitionWeek, 0)
+	var competitionWeek *dataModel.CompetitionWeek
+
+	// Add all games to the ISO week they are played in within the time zone, creating a new week as necessary
+	for _, game := range games.rows {
+		year, week := time.Unix(int64(game.GameTime), 0).In(location).ISOWeek()
+		weekStart := int(isoweek.StartTime(year, week, location).Unix())
+		if competitionWeek == nil || competitionWeek.WeekStart != weekStart {
 			competitionWeek = &dataModel.CompetitionWeek{
-				WeekStart: int(weekStart.Unix()),
+				WeekStart: weekStart,
 				Games:     make([]*dataModel.Game, 0),
 			}
 			competitionWeeks = append(competitionWeeks, competitionWeek)
@@ -285,3 +409,83 @@ func (d *GameSqlDao) GetGamesByWeek(leagueId, timeZone int) ([]*dataModel.Compet
 
 	return competitionWeeks, nil
 }
+
+// Result Confirmation
+func (d *GameSqlDao) SubmitGameResult(gameId, teamId, userId int, gameResult dataModel.GameResult) error {
+	scoreTeam1, scoreTeam2 := gameResult.Scores()
+	_, err := psql.Insert("game_result_submission").
+		Columns(
+			"game_id",
+			"team_id",
+			"user_id",
+			"submitted_at",
+			"outcome",
+			"winner_id",
+			"loser_id",
+			"score_team1",
+			"score_team2",
+		).
+		Values(
+			gameId,
+			teamId,
+			userId,
+			time.Now().Unix(),
+			gameResult.OutcomeName(),
+			gameResult.WinnerId,
+			gameResult.LoserId,
+			scoreTeam1,
+			scoreTeam2,
+		).
+		Suffix("ON CONFLICT (game_id) DO UPDATE SET " +
+			"team_id = EXCLUDED.team_id, user_id = EXCLUDED.user_id, submitted_at = EXCLUDED.submitted_at, " +
+			"outcome = EXCLUDED.outcome, winner_id = EXCLUDED.winner_id, loser_id = EXCLUDED.loser_id, " +
+			"score_team1 = EXCLUDED.score_team1, score_team2 = EXCLUDED.score_team2, " +
+			"disputed = FALSE, dispute_reason = NULL").
+		RunWith(db).Exec()
+
+	return err
+}
+
+func (d *GameSqlDao) GetResultSubmission(gameId int) (*dataModel.ResultSubmission, error) {
+	submission, err := GetScannedResultSubmission(getResultSubmissionSelector().
+		Where("game_result_submission.game_id = ?", gameId).
+		RunWith(db).QueryRow())
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else {
+		return submission, err
+	}
+}
+
+func (d *GameSqlDao) ConfirmResultSubmission(gameId int) error {
+	_, err := db.Exec("SELECT confirm_result_submission($1)", gameId)
+	return err
+}
+
+func (d *GameSqlDao) DisputeResultSubmission(gameId int, reason string) error {
+	_, err := psql.Update("game_result_submission").
+		Set("disputed", true).
+		Set("dispute_reason", reason).
+		Where("game_id = ?", gameId).
+		RunWith(db).Exec()
+
+	return err
+}
+
+func (d *GameSqlDao) GetDisputedResultSubmissions(leagueId int) ([]*dataModel.ResultSubmission, error) {
+	submissions := ResultSubmissionArray{rows: make([]*dataModel.ResultSubmission, 0)}
+	if err := ScanRows(getResultSubmissionSelector().
+		Join("game ON game.game_id = game_result_submission.game_id").
+		Where("game.league_id = ? AND game_result_submission.disputed = true", leagueId).
+		OrderBy("game_result_submission.submitted_at ASC"), &submissions); err != nil {
+		return nil, err
+	}
+
+	return submissions.rows, nil
+}
+
+func (d *GameSqlDao) ConfirmExpiredResultSubmissions() (int, error) {
+	var confirmed int
+	err := db.QueryRow("SELECT confirm_expired_result_submissions()").Scan(&confirmed)
+	return confirmed, err
+}
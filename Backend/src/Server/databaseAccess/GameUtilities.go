@@ -11,26 +11,109 @@ func getGameSelector() squirrel.SelectBuilder {
 	return psql.Select(
 		"game_id",
 		"complete",
+		"outcome",
 		"game_time",
 		"winner_id",
 		"loser_id",
 		"score_team1",
 		"score_team2",
-		"team1.team_id",
-		"team1.name",
-		"team1.tag",
-		"team1.icon_small",
-		"team1.wins",
-		"team1.losses",
-		"team2.team_id",
-		"team2.name",
-		"team2.tag",
-		"team2.icon_small",
-		"team2.wins",
-		"team2.losses").
+		"best_of",
+		"duration",
+		"playoff",
+		"COALESCE(team1.team_id, 0)",
+		"COALESCE(team1.name, '')",
+		"COALESCE(team1.tag, '')",
+		"COALESCE(team1.icon_small, '')",
+		"COALESCE(team1.wins, 0)",
+		"COALESCE(team1.losses, 0)",
+		"COALESCE(team2.team_id, 0)",
+		"COALESCE(team2.name, '')",
+		"COALESCE(team2.tag, '')",
+		"COALESCE(team2.icon_small, '')",
+		"COALESCE(team2.wins, 0)",
+		"COALESCE(team2.losses, 0)").
 		From("game").
-		Join("team AS team1 ON game.team1_id = team1.team_id").
-		Join("team AS team2 ON game.team2_id = team2.team_id")
+		LeftJoin("team AS team1 ON game.team1_id = team1.team_id").
+		LeftJoin("team AS team2 ON game.team2_id = team2.team_id")
+}
+
+// Bracket games whose teams are decided by earlier results store NULL until the result is reported
+func nullableTeamId(teamId int) interface{} {
+	if teamId == 0 {
+		return nil
+	}
+	return teamId
+}
+
+// Records a played game of the series with the given external id, completing the series once it is decided.
+// Returns the league id, game id and number of the game within the series
+func reportSeriesGame(tx *sql.Tx, externalId, seriesGameExternalId string,
+	gameResult dataModel.GameResult) (int, int, int, error) {
+	var leagueId, gameId, gameNumber int
+	var complete bool
+	if err := tx.QueryRow("SELECT league_id, game_id, game_number, complete FROM report_series_game($1,$2,$3,$4)",
+		externalId,
+		seriesGameExternalId,
+		gameResult.WinnerId,
+		gameResult.LoserId,
+	).Scan(&leagueId, &gameId, &gameNumber, &complete); err != nil {
+		return 0, 0, 0, err
+	} else {
+		return leagueId, gameId, gameNumber, nil
+	}
+}
+
+func insertGame(runner squirrel.BaseRunner, leagueId, seasonId int,
+	gameInformation dataModel.GameCreationInformation) (int, error) {
+	gameId := -1
+	err := psql.Insert("game").
+		Columns(
+			"league_id",
+			"season_id",
+			"team1_id",
+			"team2_id",
+			"game_time",
+			"complete",
+			"winner_id",
+			"loser_id",
+			"score_team1",
+			"score_team2",
+			"best_of",
+			"duration",
+			"playoff",
+		).
+		Values(
+			leagueId,
+			seasonId,
+			nullableTeamId(gameInformation.Team1Id),
+			nullableTeamId(gameInformation.Team2Id),
+			gameInformation.GameTime,
+			false,
+			-1,
+			-1,
+			0,
+			0,
+			gameInformation.SeriesLength(),
+			gameInformation.Duration,
+			gameInformation.Playoff,
+		).
+		Suffix("RETURNING \"game_id\"").
+		RunWith(runner).QueryRow().Scan(&gameId)
+
+	return gameId, err
+}
+
+func insertGames(runner squirrel.BaseRunner, leagueId, seasonId int,
+	games []dataModel.GameCreationInformation) ([]int, error) {
+	gameIds := make([]int, 0)
+	for _, game := range games {
+		gameId, err := insertGame(runner, leagueId, seasonId, game)
+		if err != nil {
+			return nil, err
+		}
+		gameIds = append(gameIds, gameId)
+	}
+	return gameIds, nil
 }
 
 type GameArray struct {
@@ -46,11 +129,15 @@ func GetScannedGame(rows squirrel.RowScanner) (*dataModel.Game, error) {
 	if err := rows.Scan(
 		&game.GameId,
 		&game.Complete,
+		&game.Outcome,
 		&game.GameTime,
 		&game.WinnerId,
 		&game.LoserId,
 		&game.ScoreTeam1,
 		&game.ScoreTeam2,
+		&game.BestOf,
+		&game.Duration,
+		&game.Playoff,
 		&team1.TeamId,
 		&team1.Name,
 		&team1.Tag,
@@ -81,3 +168,150 @@ func (r *GameArray) Scan(rows *sql.Rows) error {
 		return nil
 	}
 }
+
+type SeriesGameArray struct {
+	rows []*dataModel.SeriesGame
+}
+
+func getSeriesGameSelector() squirrel.SelectBuilder {
+	return psql.Select(
+		"game_number",
+		"COALESCE(external_id, '')",
+		"winner_id",
+		"loser_id",
+	).From("series_game")
+}
+
+func GetScannedSeriesGame(rows squirrel.RowScanner) (*dataModel.SeriesGame, error) {
+	var seriesGame dataModel.SeriesGame
+	if err := rows.Scan(
+		&seriesGame.GameNumber,
+		&seriesGame.ExternalId,
+		&seriesGame.WinnerId,
+		&seriesGame.LoserId,
+	); err != nil {
+		return nil, err
+	} else {
+		return &seriesGame, nil
+	}
+}
+
+func (r *SeriesGameArray) Scan(rows *sql.Rows) error {
+	row, err := GetScannedSeriesGame(rows)
+	if err != nil {
+		return err
+	} else {
+		r.rows = append(r.rows, row)
+		return nil
+	}
+}
+
+type ResultAmendmentArray struct {
+	rows []*dataModel.ResultAmendment
+}
+
+func getResultAmendmentSelector() squirrel.SelectBuilder {
+	return psql.Select(
+		"amendment_id",
+		"game_id",
+		"user_id",
+		"reason",
+		"amended_at",
+		"old_outcome",
+		"old_winner_id",
+		"old_loser_id",
+		"old_score_team1",
+		"old_score_team2",
+		"outcome",
+		"winner_id",
+		"loser_id",
+		"score_team1",
+		"score_team2",
+	).From("game_result_amendment")
+}
+
+func GetScannedResultAmendment(rows squirrel.RowScanner) (*dataModel.ResultAmendment, error) {
+	var amendment dataModel.ResultAmendment
+	if err := rows.Scan(
+		&amendment.AmendmentId,
+		&amendment.GameId,
+		&amendment.UserId,
+		&amendment.Reason,
+		&amendment.AmendedAt,
+		&amendment.OldResult.Outcome,
+		&amendment.OldResult.WinnerId,
+		&amendment.OldResult.LoserId,
+		&amendment.OldResult.ScoreTeam1,
+		&amendment.OldResult.ScoreTeam2,
+		&amendment.NewResult.Outcome,
+		&amendment.NewResult.WinnerId,
+		&amendment.NewResult.LoserId,
+		&amendment.NewResult.ScoreTeam1,
+		&amendment.NewResult.ScoreTeam2,
+	); err != nil {
+		return nil, err
+	} else {
+		return &amendment, nil
+	}
+}
+
+func (r *ResultAmendmentArray) Scan(rows *sql.Rows) error {
+	row, err := GetScannedResultAmendment(rows)
+	if err != nil {
+		return err
+	} else {
+		r.rows = append(r.rows, row)
+		return nil
+	}
+}
+
+type ResultSubmissionArray struct {
+	rows []*dataModel.ResultSubmission
+}
+
+func getResultSubmissionSelector() squirrel.SelectBuilder {
+	return psql.Select(
+		"game_result_submission.game_id",
+		"game_result_submission.team_id",
+		"game_result_submission.user_id",
+		"game_result_submission.submitted_at",
+		"game_result_submission.outcome",
+		"game_result_submission.winner_id",
+		"game_result_submission.loser_id",
+		"game_result_submission.score_team1",
+		"game_result_submission.score_team2",
+		"game_result_submission.disputed",
+		"COALESCE(game_result_submission.dispute_reason, '')",
+	).From("game_result_submission")
+}
+
+func GetScannedResultSubmission(rows squirrel.RowScanner) (*dataModel.ResultSubmission, error) {
+	var submission dataModel.ResultSubmission
+	if err := rows.Scan(
+		&submission.GameId,
+		&submission.TeamId,
+		&submission.UserId,
+		&submission.SubmittedAt,
+		&submission.Result.Outcome,
+		&submission.Result.WinnerId,
+		&submission.Result.LoserId,
+		&submission.Result.ScoreTeam1,
+		&submission.Result.ScoreTeam2,
+		&submission.Disputed,
+		&submission.DisputeReason,
+	); err != nil {
+		return nil, err
+	} else {
+		return &submission, nil
+	}
+}
+
+func (r *ResultSubmissionArray) Scan(rows *sql.Rows) error {
+	row, err := GetScannedResultSubmission(rows)
+	if err != nil {
+		return err
+	} else {
+		r.rows = append(r.rows, row)
+		return nil
+	}
+}
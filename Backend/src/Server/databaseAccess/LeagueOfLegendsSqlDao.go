@@ -8,19 +8,23 @@ import (
 
 type LeagueOfLegendsSqlDao struct{}
 
-func (d *LeagueOfLegendsSqlDao) createChampionStatsIfNotExist(leagueId int, champion string) error {
+// Condition on a code in lol_game of a game still having the teams and time the code was created for
+const currentTournamentCode = "lol_game.team1_id = game.team1_id AND lol_game.team2_id = game.team2_id AND " +
+	"lol_game.game_time = game.game_time"
+
+func createChampionStatsIfNotExist(runner squirrel.BaseRunner, leagueId, seasonId int, champion string) error {
 	// check if exists
 	var id int
 	err := psql.Select("league_id").
 		From("lol_champion_stats").
-		Where("league_id = ? AND name = ?", leagueId, champion).
-		RunWith(db).QueryRow().
+		Where("season_id = ? AND name = ?", seasonId, champion).
+		RunWith(runner).QueryRow().
 		Scan(&id)
 	if err == sql.ErrNoRows {
 		// does not exist, so create
 		_, err := psql.Insert("lol_champion_stats").
-			Columns("league_id", "name", "picks", "wins", "bans").
-			Values(leagueId, champion, 0, 0, 0).RunWith(db).Exec()
+			Columns("league_id", "season_id", "name", "picks", "wins", "bans").
+			Values(leagueId, seasonId, champion, 0, 0, 0).RunWith(runner).Exec()
 		if err != nil {
 			return err
 		}
@@ -33,24 +37,25 @@ func (d *LeagueOfLegendsSqlDao) createChampionStatsIfNotExist(leagueId int, cham
 	return nil
 }
 
-func (d *LeagueOfLegendsSqlDao) updateChampionStats(leagueId int, match *dataModel.LoLMatchInformation) error {
+// Champion statistics are kept for each season of the league
+func updateChampionStats(runner squirrel.BaseRunner, leagueId, seasonId int, match *dataModel.LoLMatchInformation) error {
 	for _, champion := range match.BannedChampions {
-		err := d.createChampionStatsIfNotExist(leagueId, champion)
+		err := createChampionStatsIfNotExist(runner, leagueId, seasonId, champion)
 		if err != nil {
 			return err
 		}
 
 		_, err = psql.Update("lol_champion_stats").
 			Set("bans", squirrel.Expr("bans + 1")).
-			Where("league_id = ? AND name = ?", leagueId, champion).
-			RunWith(db).Exec()
+			Where("season_id = ? AND name = ?", seasonId, champion).
+			RunWith(runner).Exec()
 		if err != nil {
 			return err
 		}
 	}
 
 	for _, champion := range match.WinningChampions {
-		err := d.createChampionStatsIfNotExist(leagueId, champion)
+		err := createChampionStatsIfNotExist(runner, leagueId, seasonId, champion)
 		if err != nil {
 			return err
 		}
@@ -58,24 +63,23 @@ func (d *LeagueOfLegendsSqlDao) updateChampionStats(leagueId int, match *dataMod
 		_, err = psql.Update("lol_champion_stats").
 			Set("picks", squirrel.Expr("picks + 1")).
 			Set("wins", squirrel.Expr("wins + 1")).
-			Where("league_id = ? AND name = ?", leagueId, champion).
-			RunWith(db).Exec()
+			Where("season_id = ? AND name = ?", seasonId, champion).
+			RunWith(runner).Exec()
 		if err != nil {
 			return err
 		}
 	}
 
 	for _, champion := range match.LosingChampions {
-		err := d.createChampionStatsIfNotExist(leagueId, champion)
+		err := createChampionStatsIfNotExist(runner, leagueId, seasonId, champion)
 		if err != nil {
 			return err
 		}
 
 		_, err = psql.Update("lol_champion_stats").
 			Set("picks", squirrel.Expr("picks + 1")).
-			Set("wins", squirrel.Expr("wins + 1")).
-			Where("league_id = ? AND name = ?", leagueId, champion).
-			RunWith(db).Exec()
+			Where("season_id = ? AND name = ?", seasonId, champion).
+			RunWith(runner).Exec()
 		if err != nil {
 			return err
 		}
@@ -84,16 +88,35 @@ func (d *LeagueOfLegendsSqlDao) updateChampionStats(leagueId int, match *dataMod
 	return nil
 }
 
-func (d *LeagueOfLegendsSqlDao) ReportEndGameStats(leagueId, gameId int, match *dataModel.LoLMatchInformation) error {
-	if err := d.updateChampionStats(leagueId, match); err != nil {
+func insertEndGameStats(runner squirrel.BaseRunner, leagueId, gameId, gameNumber int,
+	match *dataModel.LoLMatchInformation) error {
+	var seasonId int
+	if err := psql.Select("season_id").
+		From("game").
+		Where("game_id = ?", gameId).
+		RunWith(runner).QueryRow().Scan(&seasonId); err != nil {
 		return err
 	}
 
+	if err := updateChampionStats(runner, leagueId, seasonId, match); err != nil {
+		return err
+	}
+
+	for _, champion := range match.BannedChampions {
+		if _, err := psql.Insert("lol_ban").
+			Columns("game_id", "game_number", "champion").
+			Values(gameId, gameNumber, champion).
+			RunWith(runner).Exec(); err != nil {
+			return err
+		}
+	}
+
 	// Create Winning Team Stats Entry for this game
 	_, err := psql.Insert("lol_team_stats").
 		Columns(
 			"team_id",
 			"game_id",
+			"game_number",
 			"league_id",
 			"duration",
 			"side",
@@ -104,13 +127,14 @@ func (d *LeagueOfLegendsSqlDao) ReportEndGameStats(leagueId, gameId int, match *
 		Values(
 			match.WinningTeamId,
 			gameId,
+			gameNumber,
 			leagueId,
 			match.Duration,
 			match.WinningTeamStats.Side,
 			match.WinningTeamStats.FirstBlood,
 			match.WinningTeamStats.FirstTower,
 			true,
-		).RunWith(db).Exec()
+		).RunWith(runner).Exec()
 	if err != nil {
 		return err
 	}
@@ -120,6 +144,7 @@ func (d *LeagueOfLegendsSqlDao) ReportEndGameStats(leagueId, gameId int, match *
 		Columns(
 			"team_id",
 			"game_id",
+			"game_number",
 			"league_id",
 			"duration",
 			"side",
@@ -130,13 +155,14 @@ func (d *LeagueOfLegendsSqlDao) ReportEndGameStats(leagueId, gameId int, match *
 		Values(
 			match.LosingTeamId,
 			gameId,
+			gameNumber,
 			leagueId,
 			match.Duration,
 			match.LosingTeamStats.Side,
 			match.LosingTeamStats.FirstBlood,
 			match.LosingTeamStats.FirstTower,
 			false,
-		).RunWith(db).Exec()
+		).RunWith(runner).Exec()
 	if err != nil {
 		return err
 	}
@@ -154,6 +180,7 @@ func (d *LeagueOfLegendsSqlDao) ReportEndGameStats(leagueId, gameId int, match *
 				"id",
 				"name",
 				"game_id",
+				"game_number",
 				"team_id",
 				"league_id",
 				"duration",
@@ -171,6 +198,7 @@ func (d *LeagueOfLegendsSqlDao) ReportEndGameStats(leagueId, gameId int, match *
 				player.Id,
 				player.Name,
 				gameId,
+				gameNumber,
 				teamId,
 				leagueId,
 				match.Duration,
@@ -183,7 +211,7 @@ func (d *LeagueOfLegendsSqlDao) ReportEndGameStats(leagueId, gameId int, match *
 				player.Assists,
 				player.Wards,
 				player.Win,
-			).RunWith(db).Exec()
+			).RunWith(runner).Exec()
 		if err != nil {
 			return err
 		}
@@ -192,7 +220,7 @@ func (d *LeagueOfLegendsSqlDao) ReportEndGameStats(leagueId, gameId int, match *
 	return nil
 }
 
-func (d *LeagueOfLegendsSqlDao) GetPlayerStats(leagueId int) ([]*dataModel.LoLPlayerStats, error) {
+func (d *LeagueOfLegendsSqlDao) GetPlayerStats(seasonId int) ([]*dataModel.LoLPlayerStats, error) {
 	rows, err := db.Query(`
 	SELECT id, (array_agg(name ORDER BY name))[1] as name, (array_agg(team_id ORDER BY team_id))[1] as team_id,
 	SUM(damage) / (SUM(duration) / 60) AS DPM,
@@ -204,8 +232,8 @@ func (d *LeagueOfLegendsSqlDao) GetPlayerStats(leagueId int) ([]*dataModel.LoLPl
 	AVG(assists) AS average_assists,
 	AVG(wards) AS average_wards,
 	(AVG(kills) + AVG(assists)) / GREATEST(1, AVG(deaths)) AS average_kda
-	FROM lol_player_stats WHERE league_id = $1
-	GROUP BY id`, leagueId)
+	FROM lol_player_stats WHERE game_id IN (SELECT game_id FROM game WHERE season_id = $1)
+	GROUP BY id`, seasonId)
 	if err != nil {
 		return nil, err
 	}
@@ -242,7 +270,7 @@ func (d *LeagueOfLegendsSqlDao) GetPlayerStats(leagueId int) ([]*dataModel.LoLPl
 	return allPlayerStats, nil
 }
 
-func (d *LeagueOfLegendsSqlDao) GetTeamStats(leagueId int) ([]*dataModel.LoLTeamStats, error) {
+func (d *LeagueOfLegendsSqlDao) GetTeamStats(seasonId int) ([]*dataModel.LoLTeamStats, error) {
 	rows, err := db.Query(`
 	SELECT t1.team_id, average_duration, number_first_bloods, number_first_turrets,
 	average_kda, average_action_score, average_wards, average_gold_per_minute, average_cs_per_minute
@@ -252,15 +280,15 @@ func (d *LeagueOfLegendsSqlDao) GetTeamStats(leagueId int) ([]*dataModel.LoLTeam
 	SUM(wards) / (COUNT(*)/5) AS average_wards,
 	(SUM(gold) / (SUM(duration)/60)) / (COUNT(*)/5) AS average_gold_per_minute,
 	(SUM(cs) / (SUM(duration)/60)) / (COUNT(*)/5) AS average_cs_per_minute
-	FROM lol_player_stats WHERE league_id=$1
+	FROM lol_player_stats WHERE game_id IN (SELECT game_id FROM game WHERE season_id = $1)
 	GROUP BY team_id) AS t1
 	INNER JOIN
 	(SELECT team_id, AVG(duration) AS average_duration,
 	COUNT(*) FILTER (WHERE first_blood) AS number_first_bloods,
 	COUNT(*) FILTER (WHERE first_turret) AS number_first_turrets
-	FROM lol_team_stats WHERE league_id = $1
+	FROM lol_team_stats WHERE game_id IN (SELECT game_id FROM game WHERE season_id = $1)
 	GROUP BY team_id) AS t2
-	ON t1.team_id = t2.team_id`, leagueId)
+	ON t1.team_id = t2.team_id`, seasonId)
 	if err != nil {
 		return nil, err
 	}
@@ -294,14 +322,14 @@ func (d *LeagueOfLegendsSqlDao) GetTeamStats(leagueId int) ([]*dataModel.LoLTeam
 	return allTeamStats, nil
 }
 
-func (d *LeagueOfLegendsSqlDao) GetChampionStats(leagueId int) ([]*dataModel.LoLChampionStats, error) {
+func (d *LeagueOfLegendsSqlDao) GetChampionStats(seasonId int) ([]*dataModel.LoLChampionStats, error) {
 	rows, err := db.Query(`
 	SELECT name, bans, picks, wins, picks-wins AS losses,
 	CASE picks
 	 WHEN 0 THEN 0
 	 ELSE wins::FLOAT / picks::FLOAT
 	END AS winrate
-	FROM lol_champion_stats WHERE league_id = $1`, leagueId)
+	FROM lol_champion_stats WHERE season_id = $1`, seasonId)
 	if err != nil {
 		return nil, err
 	}
@@ -387,7 +415,7 @@ func (d *LeagueOfLegendsSqlDao) GetLoLTeamStub(teamId int) (*dataModel.LoLTeamSt
 
 func (d *LeagueOfLegendsSqlDao) GetAllLoLTeamStubInLeague(leagueId int) ([]*dataModel.LoLTeamStub, error) {
 	rows, err := getLoLTeamStubSelector().
-		Where("team.league_id = ?", leagueId).
+		Where("team.league_id = ? AND team.pending = false AND team.archived = false", leagueId).
 		RunWith(db).Query()
 	if err != nil {
 		return nil, err
@@ -499,39 +527,396 @@ func (d *LeagueOfLegendsSqlDao) GetTournamentId(leagueId int) (int, error) {
 	return tournamentId, nil
 }
 
-func (d *LeagueOfLegendsSqlDao) HasTournamentCode(gameId int) (bool, error) {
+func (d *LeagueOfLegendsSqlDao) GetTournamentSettings(leagueId int) (*dataModel.LoLTournamentSettings, error) {
+	var settings dataModel.LoLTournamentSettings
+	if err := psql.Select(
+		"region",
+		"map_type",
+		"pick_type",
+		"spectator_type",
+		"team_size",
+		"restrict_to_rosters",
+	).
+		From("lol_tournament_settings").
+		Where("league_id = ?", leagueId).
+		RunWith(db).QueryRow().Scan(
+		&settings.Region,
+		&settings.MapType,
+		&settings.PickType,
+		&settings.SpectatorType,
+		&settings.TeamSize,
+		&settings.RestrictToRosters,
+	); err == sql.ErrNoRows {
+		return dataModel.NewLoLTournamentSettings(), nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// Codes that have not been played with yet are removed along with the settings they were created with, so that
+// the upcoming games of the league are given new codes
+func (d *LeagueOfLegendsSqlDao) SetTournamentSettings(leagueId int, settings dataModel.LoLTournamentSettings) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err = psql.Insert("lol_tournament_settings").
+		Columns(
+			"league_id",
+			"region",
+			"map_type",
+			"pick_type",
+			"spectator_type",
+			"team_size",
+			"restrict_to_rosters",
+		).
+		Values(
+			leagueId,
+			settings.Region,
+			settings.MapType,
+			settings.PickType,
+			settings.SpectatorType,
+			settings.TeamSize,
+			settings.RestrictToRosters,
+		).
+		Suffix("ON CONFLICT (league_id) DO UPDATE SET " +
+			"region = EXCLUDED.region, " +
+			"map_type = EXCLUDED.map_type, " +
+			"pick_type = EXCLUDED.pick_type, " +
+			"spectator_type = EXCLUDED.spectator_type, " +
+			"team_size = EXCLUDED.team_size, " +
+			"restrict_to_rosters = EXCLUDED.restrict_to_rosters").
+		RunWith(tx).Exec(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err = psql.Delete("lol_game").
+		Where("match_id IS NULL AND game_id IN (SELECT game_id FROM game WHERE league_id = ? AND complete = false)",
+			leagueId).
+		RunWith(tx).Exec(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// The code a game is played with, unless the game has been rescheduled or its teams have changed since
+func (d *LeagueOfLegendsSqlDao) GetTournamentCode(gameId int) (string, error) {
+	var tournamentCode string
+	if err := psql.Select("lol_game.tournament_code").
+		From("lol_game").
+		Join("game ON lol_game.game_id = game.game_id").
+		Where("lol_game.game_id = ? AND lol_game.match_id IS NULL", gameId).
+		Where(currentTournamentCode).
+		RunWith(db).QueryRow().Scan(&tournamentCode); err == sql.ErrNoRows {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return tournamentCode, nil
+}
+
+// Replaces the code of the game, recording the teams and time of the game it is created for. The external id in the
+// metadata of the code is stored together with it, so that callbacks of the previous code stay valid until then
+func (d *LeagueOfLegendsSqlDao) CreateTournamentCode(gameId int, externalId, tournamentCode string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err = psql.Update("game").
+		Set("external_id", externalId).
+		Where("game_id = ?", gameId).
+		RunWith(tx).Exec(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var team1Id, team2Id, gameTime int
+	if err = psql.Select("team1_id", "team2_id", "game_time").
+		From("game").
+		Where("game_id = ?", gameId).
+		RunWith(tx).QueryRow().Scan(&team1Id, &team2Id, &gameTime); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err = psql.Delete("lol_game").
+		Where("game_id = ? AND match_id IS NULL", gameId).
+		RunWith(tx).Exec(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err = psql.Insert("lol_game").
+		Columns(
+			"game_id",
+			"tournament_code",
+			"team1_id",
+			"team2_id",
+			"game_time",
+		).
+		Values(
+			gameId,
+			tournamentCode,
+			team1Id,
+			team2Id,
+			gameTime,
+		).
+		RunWith(tx).Exec(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Upcoming games of leagues registered as tournaments that are between from and to and do not have a current code
+func (d *LeagueOfLegendsSqlDao) GetGamesAwaitingTournamentCodes(from, to int) ([]*dataModel.LoLTournamentGame, error) {
+	rows, err := psql.Select(
+		"game.game_id",
+		"game.league_id",
+		"lol_tournament.tournament_id",
+	).
+		From("game").
+		Join("lol_tournament ON game.league_id = lol_tournament.league_id").
+		Where("game.complete = false AND game.team1_id IS NOT NULL AND game.team2_id IS NOT NULL").
+		Where("game.game_time BETWEEN ? AND ?", from, to).
+		Where("NOT EXISTS (SELECT 1 FROM lol_game WHERE lol_game.game_id = game.game_id AND " +
+			"lol_game.match_id IS NULL AND " + currentTournamentCode + ")").
+		OrderBy("game.game_time ASC").
+		RunWith(db).Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	games := make([]*dataModel.LoLTournamentGame, 0)
+	for rows.Next() {
+		var game dataModel.LoLTournamentGame
+		if err := rows.Scan(&game.GameId, &game.LeagueId, &game.TournamentId); err != nil {
+			return nil, err
+		}
+		games = append(games, &game)
+	}
+	return games, rows.Err()
+}
+
+func (d *LeagueOfLegendsSqlDao) IsTournamentCodeOfGame(tournamentCode string, gameId int, externalGameId string) (bool, error) {
 	var count int
 	if err := psql.Select("count(*)").
 		From("lol_game").
-		Where("game_id = ?", gameId).
+		Join("game ON lol_game.game_id = game.game_id").
+		Where("lol_game.tournament_code = ? AND game.game_id = ? AND game.external_id = ?",
+			tournamentCode, gameId, externalGameId).
 		RunWith(db).QueryRow().Scan(&count); err != nil {
 		return false, err
-	} else {
-		return count > 0, nil
 	}
+	return count > 0, nil
 }
 
-func (d *LeagueOfLegendsSqlDao) GetTournamentCode(gameId int) (string, error) {
-	var tournamentCode string
-	if err := psql.Select("tournament_code").
+func (d *LeagueOfLegendsSqlDao) IsMatchProcessed(matchId string) (bool, error) {
+	var count int
+	if err := psql.Select("count(*)").
 		From("lol_game").
-		Where("game_id = ?", gameId).
-		RunWith(db).QueryRow().Scan(&tournamentCode); err != nil {
-		return "", err
+		Where("match_id = ?", matchId).
+		RunWith(db).QueryRow().Scan(&count); err != nil {
+		return false, err
 	}
-	return tournamentCode, nil
+	return count > 0, nil
 }
 
-func (d *LeagueOfLegendsSqlDao) CreateTournamentCode(gameId int, tournamentCode string) error {
-	_, err := psql.Insert("lol_game").
+// Records the match as a game of the series of the tournament code along with its stats. The match id is recorded
+// in the same transaction, so a match that has already been reported is not reported again and false is returned
+func (d *LeagueOfLegendsSqlDao) ReportTournamentMatch(tournamentCode string, match *dataModel.LoLMatchInformation,
+	gameResult dataModel.GameResult) (int, bool, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, false, err
+	}
+
+	var gameId int
+	if err = psql.Select("game_id").
+		From("lol_game").
+		Where("tournament_code = ?", tournamentCode).
+		Limit(1).
+		RunWith(tx).QueryRow().Scan(&gameId); err != nil {
+		tx.Rollback()
+		return 0, false, err
+	}
+
+	res, err := psql.Insert("lol_game").
 		Columns(
 			"game_id",
 			"tournament_code",
+			"match_id",
 		).
 		Values(
 			gameId,
 			tournamentCode,
+			match.MatchId,
+		).
+		Suffix("ON CONFLICT (match_id) DO NOTHING").
+		RunWith(tx).Exec()
+	if err != nil {
+		tx.Rollback()
+		return 0, false, err
+	}
+	if rowsAffected, err := res.RowsAffected(); err != nil {
+		tx.Rollback()
+		return 0, false, err
+	} else if rowsAffected == 0 {
+		tx.Rollback()
+		return 0, false, nil
+	}
+
+	leagueId, _, gameNumber, err := reportSeriesGame(tx, match.GameId, match.MatchId, gameResult)
+	if err != nil {
+		tx.Rollback()
+		return 0, false, err
+	}
+	if err = insertEndGameStats(tx, leagueId, gameId, gameNumber, match); err != nil {
+		tx.Rollback()
+		return 0, false, err
+	}
+
+	return leagueId, true, tx.Commit()
+}
+
+// A callback redelivered for a match that is already queued replaces the queued one and starts its attempts over,
+// so that a match that ran out of attempts can be ingested again
+func (d *LeagueOfLegendsSqlDao) QueueTournamentCallback(callback *dataModel.LoLQueuedCallback) error {
+	_, err := psql.Insert("lol_tournament_callback").
+		Columns(
+			"match_id",
+			"callback",
+			"attempts",
+			"next_attempt",
+			"last_error",
+		).
+		Values(
+			callback.MatchId,
+			callback.Callback,
+			callback.Attempts,
+			callback.NextAttempt,
+			callback.LastError,
+		).
+		Suffix("ON CONFLICT (match_id) DO UPDATE SET " +
+			"callback = EXCLUDED.callback, " +
+			"attempts = EXCLUDED.attempts, " +
+			"next_attempt = EXCLUDED.next_attempt, " +
+			"last_error = EXCLUDED.last_error").
+		RunWith(db).Exec()
+	return err
+}
+
+func (d *LeagueOfLegendsSqlDao) GetDueTournamentCallbacks(now, maxAttempts int) ([]*dataModel.LoLQueuedCallback, error) {
+	rows, err := psql.Select(
+		"match_id",
+		"callback",
+		"attempts",
+		"next_attempt",
+		"last_error",
+	).
+		From("lol_tournament_callback").
+		Where("next_attempt <= ? AND attempts < ?", now, maxAttempts).
+		OrderBy("next_attempt ASC").
+		RunWith(db).Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	callbacks := make([]*dataModel.LoLQueuedCallback, 0)
+	for rows.Next() {
+		var callback dataModel.LoLQueuedCallback
+		if err := rows.Scan(
+			&callback.MatchId,
+			&callback.Callback,
+			&callback.Attempts,
+			&callback.NextAttempt,
+			&callback.LastError,
+		); err != nil {
+			return nil, err
+		}
+		callbacks = append(callbacks, &callback)
+	}
+	return callbacks, rows.Err()
+}
+
+func (d *LeagueOfLegendsSqlDao) UpdateTournamentCallback(callback *dataModel.LoLQueuedCallback) error {
+	_, err := psql.Update("lol_tournament_callback").
+		Set("attempts", callback.Attempts).
+		Set("next_attempt", callback.NextAttempt).
+		Set("last_error", callback.LastError).
+		Where("match_id = ?", callback.MatchId).
+		RunWith(db).Exec()
+	return err
+}
+
+func (d *LeagueOfLegendsSqlDao) DeleteTournamentCallback(matchId string) error {
+	_, err := psql.Delete("lol_tournament_callback").
+		Where("match_id = ?", matchId).
+		RunWith(db).Exec()
+	return err
+}
+
+func (d *LeagueOfLegendsSqlDao) GetCachedSummoners(externalIds []string) ([]*dataModel.LoLCachedSummoner, error) {
+	rows, err := psql.Select(
+		"external_id",
+		"game_identifier",
+		"rank",
+		"tier",
+		"updated",
+	).
+		From("lol_summoner").
+		Where(squirrel.Eq{"external_id": externalIds}).
+		RunWith(db).Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summoners := make([]*dataModel.LoLCachedSummoner, 0)
+	for rows.Next() {
+		var summoner dataModel.LoLCachedSummoner
+		if err := rows.Scan(
+			&summoner.ExternalId,
+			&summoner.GameIdentifier,
+			&summoner.Rank,
+			&summoner.Tier,
+			&summoner.Updated,
+		); err != nil {
+			return nil, err
+		}
+		summoners = append(summoners, &summoner)
+	}
+	return summoners, rows.Err()
+}
+
+func (d *LeagueOfLegendsSqlDao) CacheSummoner(summoner *dataModel.LoLCachedSummoner) error {
+	_, err := psql.Insert("lol_summoner").
+		Columns(
+			"external_id",
+			"game_identifier",
+			"rank",
+			"tier",
+			"updated",
+		).
+		Values(
+			summoner.ExternalId,
+			summoner.GameIdentifier,
+			summoner.Rank,
+			summoner.Tier,
+			summoner.Updated,
 		).
+		Suffix("ON CONFLICT (external_id) DO UPDATE SET " +
+			"game_identifier = EXCLUDED.game_identifier, rank = EXCLUDED.rank, " +
+			"tier = EXCLUDED.tier, updated = EXCLUDED.updated").
 		RunWith(db).Exec()
 	return err
 }
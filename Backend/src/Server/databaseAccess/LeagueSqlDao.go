@@ -3,6 +3,7 @@ package databaseAccess
 import (
 	"Server/dataModel"
 	"database/sql"
+	"github.com/Masterminds/squirrel"
 	"strings"
 )
 
@@ -11,7 +12,7 @@ type LeagueSqlDao struct{}
 // Modify League
 func (d *LeagueSqlDao) CreateLeague(userId int, leagueInfo dataModel.LeagueCore) (int, error) {
 	var leagueId = -1
-	err := db.QueryRow("SELECT create_league($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)",
+	err := db.QueryRow("SELECT create_league($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)",
 		leagueInfo.Name,
 		leagueInfo.Description,
 		leagueInfo.PublicView,
@@ -21,14 +22,24 @@ func (d *LeagueSqlDao) CreateLeague(userId int, leagueInfo dataModel.LeagueCore)
 		leagueInfo.LeagueStart,
 		leagueInfo.LeagueEnd,
 		leagueInfo.Game,
+		leagueInfo.TimeZoneName(),
+		leagueInfo.ResultConfirmationHours,
+		leagueInfo.RequireTeamApproval,
+		leagueInfo.WithdrawalPolicyName(),
 		userId,
 	).Scan(&leagueId)
 
 	return leagueId, err
 }
 
+// The league competition period is the period of its current season, so both are updated together
 func (d *LeagueSqlDao) UpdateLeague(leagueId int, leagueInfo dataModel.LeagueCore) error {
-	_, err := psql.Update("league").
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err = psql.Update("league").
 		Set("name", leagueInfo.Name).
 		Set("description", leagueInfo.Description).
 		Set("game", leagueInfo.Game).
@@ -38,33 +49,50 @@ func (d *LeagueSqlDao) UpdateLeague(leagueId int, leagueInfo dataModel.LeagueCor
 		Set("signup_end", leagueInfo.SignupEnd).
 		Set("league_start", leagueInfo.LeagueStart).
 		Set("league_end", leagueInfo.LeagueEnd).
+		Set("time_zone", leagueInfo.TimeZoneName()).
+		Set("result_confirmation_hours", leagueInfo.ResultConfirmationHours).
+		Set("require_team_approval", leagueInfo.RequireTeamApproval).
+		Set("withdrawal_policy", leagueInfo.WithdrawalPolicyName()).
 		Where("league_id = ?", leagueId).
-		RunWith(db).Exec()
+		RunWith(tx).Exec(); err != nil {
+		tx.Rollback()
+		return err
+	}
 
-	return err
+	if _, err = psql.Update("season").
+		Set("season_start", leagueInfo.LeagueStart).
+		Set("season_end", leagueInfo.LeagueEnd).
+		Where("league_id = ? AND current = true", leagueId).
+		RunWith(tx).Exec(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
 }
 
+// Joining a league uses up an outstanding invite sent to the email of the user, if there is one
 func (d *LeagueSqlDao) JoinLeague(leagueId, userId int) error {
-	_, err := psql.Insert("league_permissions").
-		Columns(
-			"user_id",
-			"league_id",
-			"administrator",
-			"create_teams",
-			"edit_teams",
-			"edit_games",
-		).
-		Values(
-			userId,
-			leagueId,
-			false,
-			false,
-			false,
-			false,
-		).
-		RunWith(db).Exec()
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
 
-	return err
+	if _, err = psql.Update("league_invite").
+		Set("uses", squirrel.Expr("uses + 1")).
+		Where("invite_id = (SELECT invite_id FROM league_invite WHERE league_id = ? AND "+
+			inviteForUser+" AND "+outstandingInvite+" ORDER BY invite_id ASC LIMIT 1)", leagueId, userId).
+		RunWith(tx).Exec(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = insertLeagueMember(tx, leagueId, userId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // Permissions
@@ -124,29 +152,52 @@ func (d *LeagueSqlDao) IsLeagueViewable(leagueId, userId int) (bool, error) {
 
 	//if not publicly viewable, see if user has permission to view it. This is checked by seeing if there is a
 	//leaguePermissions row with that userId and leagueId, if there is they have at least the base (viewing) privileges
-	var uid int
-	err = psql.Select("user_id").
-		From("league_permissions").
-		Where("league_id = ? AND user_id = ?", leagueId, userId).
-		RunWith(db).QueryRow().Scan(&uid)
-	if err == sql.ErrNoRows {
-		return false, nil
-	} else if err != nil {
-		return false, err
+	member, err := d.IsLeagueMember(leagueId, userId)
+	if err != nil || member {
+		return member, err
 	}
 
-	return true, nil
+	//users invited to the league can view it before deciding to join
+	return hasOutstandingInvite(leagueId, userId)
 }
 
-//TODO: make invite system for private leagues, check if user invited in this function
 func (d *LeagueSqlDao) CanJoinLeague(leagueId, userId int) (bool, error) {
 	var canJoin = false
 	err := psql.Select("public_join").
 		From("league").
 		Where("league_id = ?", leagueId).
 		RunWith(db).QueryRow().Scan(&canJoin)
+	if err != nil || canJoin {
+		return canJoin, err
+	}
 
-	return canJoin, err
+	return hasOutstandingInvite(leagueId, userId)
+}
+
+func (d *LeagueSqlDao) IsLeagueMember(leagueId, userId int) (bool, error) {
+	var count int
+	if err := psql.Select("count(*)").
+		From("league_permissions").
+		Where("league_id = ? AND user_id = ?", leagueId, userId).
+		RunWith(db).QueryRow().Scan(&count); err != nil {
+		return false, err
+	} else {
+		return count > 0, nil
+	}
+}
+
+func hasOutstandingInvite(leagueId, userId int) (bool, error) {
+	var count int
+	if err := psql.Select("count(*)").
+		From("league_invite").
+		Where("league_id = ?", leagueId).
+		Where(inviteForUser, userId).
+		Where(outstandingInvite).
+		RunWith(db).QueryRow().Scan(&count); err != nil {
+		return false, err
+	} else {
+		return count > 0, nil
+	}
 }
 
 // Get Information About Leagues
@@ -214,18 +265,607 @@ func (d *LeagueSqlDao) SetMarkdownFile(leagueId int, fileName string) error {
 	return err
 }
 
+// Invites
+func (d *LeagueSqlDao) CreateInvite(leagueId, userId int, token string, invite dataModel.LeagueInviteCore) (int, error) {
+	var email interface{}
+	if invite.Email != "" {
+		email = invite.Email
+	}
+
+	var inviteId = -1
+	err := psql.Insert("league_invite").
+		Columns(
+			"league_id",
+			"token",
+			"email",
+			"max_uses",
+			"expires_at",
+			"created_by",
+		).
+		Values(
+			leagueId,
+			token,
+			email,
+			invite.MaxUses,
+			invite.ExpiresAt,
+			userId,
+		).
+		Suffix("RETURNING \"invite_id\"").
+		RunWith(db).QueryRow().Scan(&inviteId)
+
+	return inviteId, err
+}
+
+func (d *LeagueSqlDao) GetInvite(token string) (*dataModel.LeagueInvite, error) {
+	invite, err := GetScannedLeagueInvite(getLeagueInviteSelector().
+		Where("token = ?", token).
+		RunWith(db).QueryRow())
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else {
+		return invite, err
+	}
+}
+
+func (d *LeagueSqlDao) GetInvites(leagueId int) ([]*dataModel.LeagueInvite, error) {
+	invites := LeagueInviteArray{rows: make([]*dataModel.LeagueInvite, 0)}
+	if err := ScanRows(getLeagueInviteSelector().
+		Where("league_id = ?", leagueId).
+		OrderBy("invite_id DESC"), &invites); err != nil {
+		return nil, err
+	}
+
+	return invites.rows, nil
+}
+
+func (d *LeagueSqlDao) UseInvite(inviteId, userId int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	var leagueId int
+	if err = psql.Update("league_invite").
+		Set("uses", squirrel.Expr("uses + 1")).
+		Where("invite_id = ?", inviteId).
+		Where(outstandingInvite).
+		Suffix("RETURNING \"league_id\"").
+		RunWith(tx).QueryRow().Scan(&leagueId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = insertLeagueMember(tx, leagueId, userId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (d *LeagueSqlDao) RevokeInvite(inviteId int) error {
+	_, err := psql.Update("league_invite").
+		Set("revoked", true).
+		Where("invite_id = ?", inviteId).
+		RunWith(db).Exec()
+	return err
+}
+
+func (d *LeagueSqlDao) DoesInviteExistInLeague(leagueId, inviteId int) (bool, error) {
+	var count int
+	if err := psql.Select("count(*)").
+		From("league_invite").
+		Where("league_id = ? AND invite_id = ?", leagueId, inviteId).
+		RunWith(db).QueryRow().Scan(&count); err != nil {
+		return false, err
+	} else {
+		return count > 0, nil
+	}
+}
+
+// Standings
+func (d *LeagueSqlDao) GetStandingsConfiguration(leagueId int) (*dataModel.StandingsConfiguration, error) {
+	var standingsConfiguration dataModel.StandingsConfiguration
+	if err := psql.Select("win_points", "draw_points", "loss_points").
+		From("league").
+		Where("league_id = ?", leagueId).
+		RunWith(db).QueryRow().Scan(
+		&standingsConfiguration.WinPoints,
+		&standingsConfiguration.DrawPoints,
+		&standingsConfiguration.LossPoints,
+	); err != nil {
+		return nil, err
+	}
+
+	var tiebreakers TiebreakerArray
+	if err := ScanRows(psql.Select("tiebreaker").
+		From("league_tiebreaker").
+		Where("league_id = ?", leagueId).
+		OrderBy("priority ASC"), &tiebreakers); err != nil {
+		return nil, err
+	}
+
+	standingsConfiguration.Tiebreakers = append(make([]string, 0), tiebreakers.rows...)
+	return &standingsConfiguration, nil
+}
+
+func (d *LeagueSqlDao) SetStandingsConfiguration(leagueId int, standingsConfiguration dataModel.StandingsConfiguration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err = psql.Update("league").
+		Set("win_points", standingsConfiguration.WinPoints).
+		Set("draw_points", standingsConfiguration.DrawPoints).
+		Set("loss_points", standingsConfiguration.LossPoints).
+		Where("league_id = ?", leagueId).
+		RunWith(tx).Exec(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err = psql.Delete("league_tiebreaker").
+		Where("league_id = ?", leagueId).
+		RunWith(tx).Exec(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for priority, tiebreaker := range standingsConfiguration.Tiebreakers {
+		if _, err = psql.Insert("league_tiebreaker").
+			Columns("league_id", "priority", "tiebreaker").
+			Values(leagueId, priority, tiebreaker).
+			RunWith(tx).Exec(); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Roster Rules
+func (d *LeagueSqlDao) GetRosterRules(leagueId int) (*dataModel.RosterRules, error) {
+	var rules dataModel.RosterRules
+	if err := psql.Select("min_main_roster", "max_main_roster", "max_substitutes", "roster_lock").
+		From("league").
+		Where("league_id = ?", leagueId).
+		RunWith(db).QueryRow().Scan(
+		&rules.MinMainRoster,
+		&rules.MaxMainRoster,
+		&rules.MaxSubstitutes,
+		&rules.RosterLock,
+	); err != nil {
+		return nil, err
+	}
+
+	var positions RequiredPositionArray
+	if err := ScanRows(psql.Select("position").
+		From("league_required_position").
+		Where("league_id = ?", leagueId).
+		OrderBy("position ASC"), &positions); err != nil {
+		return nil, err
+	}
+
+	rules.RequiredPositions = append(make([]string, 0), positions.rows...)
+	return &rules, nil
+}
+
+func (d *LeagueSqlDao) SetRosterRules(leagueId int, rules dataModel.RosterRules) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err = psql.Update("league").
+		Set("min_main_roster", rules.MinMainRoster).
+		Set("max_main_roster", rules.MaxMainRoster).
+		Set("max_substitutes", rules.MaxSubstitutes).
+		Set("roster_lock", rules.RosterLock).
+		Where("league_id = ?", leagueId).
+		RunWith(tx).Exec(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err = psql.Delete("league_required_position").
+		Where("league_id = ?", leagueId).
+		RunWith(tx).Exec(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, position := range rules.RequiredPositions {
+		if _, err = psql.Insert("league_required_position").
+			Columns("league_id", "position").
+			Values(leagueId, position).
+			RunWith(tx).Exec(); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Seasons
+func (d *LeagueSqlDao) CreateSeason(leagueId int, season dataModel.SeasonCore) (int, error) {
+	var seasonId = -1
+	err := psql.Insert("season").
+		Columns(
+			"league_id",
+			"name",
+			"season_start",
+			"season_end",
+		).
+		Values(
+			leagueId,
+			season.Name,
+			season.SeasonStart,
+			season.SeasonEnd,
+		).
+		Suffix("RETURNING \"season_id\"").
+		RunWith(db).QueryRow().Scan(&seasonId)
+
+	return seasonId, err
+}
+
+func (d *LeagueSqlDao) GetSeasons(leagueId int) ([]*dataModel.Season, error) {
+	seasons := SeasonArray{rows: make([]*dataModel.Season, 0)}
+	if err := ScanRows(getSeasonSelector().
+		Where("league_id = ?", leagueId).
+		OrderBy("season_start ASC"), &seasons); err != nil {
+		return nil, err
+	}
+
+	return seasons.rows, nil
+}
+
+func (d *LeagueSqlDao) GetSeason(seasonId int) (*dataModel.Season, error) {
+	season, err := GetScannedSeason(getSeasonSelector().
+		Where("season_id = ?", seasonId).
+		RunWith(db).QueryRow())
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else {
+		return season, err
+	}
+}
+
+// Returns 0 if the league has no current season
+func (d *LeagueSqlDao) GetCurrentSeasonId(leagueId int) (int, error) {
+	seasonId, err := getCurrentSeasonId(db, leagueId)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	} else {
+		return seasonId, err
+	}
+}
+
+// The new season becomes the competition period of the league. Teams carried over keep their players and start the
+// season without wins or losses, while teams left behind are archived with their results in the previous season
+func (d *LeagueSqlDao) RolloverSeason(leagueId, seasonId int, rollover dataModel.SeasonRolloverCore) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err = psql.Update("season").
+		Set("current", false).
+		Where("league_id = ? AND current = true", leagueId).
+		RunWith(tx).Exec(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err = psql.Update("season").
+		Set("current", true).
+		Where("season_id = ?", seasonId).
+		RunWith(tx).Exec(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err = tx.Exec(`
+		UPDATE league SET league_start = season.season_start, league_end = season.season_end
+		FROM season WHERE season.season_id = $1 AND league.league_id = season.league_id`,
+		seasonId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if len(rollover.TeamIds) == 0 {
+		if _, err = tx.Exec(`
+			INSERT INTO season_team(season_id, team_id)
+			SELECT $1, team_id FROM team WHERE league_id = $2 AND archived = false`,
+			seasonId, leagueId); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	for _, teamId := range rollover.TeamIds {
+		if _, err = psql.Insert("season_team").
+			Columns("season_id", "team_id").
+			Values(seasonId, teamId).
+			RunWith(tx).Exec(); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if _, err = tx.Exec(`
+		UPDATE team SET archived = true
+		WHERE league_id = $1 AND archived = false
+		  AND team_id NOT IN (SELECT team_id FROM season_team WHERE season_id = $2)`,
+		leagueId, seasonId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err = psql.Update("team").
+		Set("wins", 0).
+		Set("losses", 0).
+		Where("league_id = ? AND archived = false", leagueId).
+		RunWith(tx).Exec(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (d *LeagueSqlDao) DoesSeasonExistInLeague(leagueId, seasonId int) (bool, error) {
+	var count int
+	if err := psql.Select("count(*)").
+		From("season").
+		Where("league_id = ? AND season_id = ?", leagueId, seasonId).
+		RunWith(db).QueryRow().Scan(&count); err != nil {
+		return false, err
+	} else {
+		return count > 0, nil
+	}
+}
+
+func (d *LeagueSqlDao) IsSeasonNameInUse(leagueId int, name string) (bool, error) {
+	var count int
+	if err := psql.Select("count(*)").
+		From("season").
+		Where("league_id = ? AND name = ?", leagueId, name).
+		RunWith(db).QueryRow().Scan(&count); err != nil {
+		return false, err
+	} else {
+		return count > 0, nil
+	}
+}
+
+// Divisions
+func (d *LeagueSqlDao) CreateDivision(leagueId int, division dataModel.DivisionCore) (int, error) {
+	var divisionId = -1
+	err := psql.Insert("division").
+		Columns("league_id", "name").
+		Values(leagueId, division.Name).
+		Suffix("RETURNING \"division_id\"").
+		RunWith(db).QueryRow().Scan(&divisionId)
+
+	return divisionId, err
+}
+
+func (d *LeagueSqlDao) UpdateDivision(divisionId int, division dataModel.DivisionCore) error {
+	_, err := psql.Update("division").
+		Set("name", division.Name).
+		Where("division_id = ?", divisionId).
+		RunWith(db).Exec()
+	return err
+}
+
+// Teams of a deleted division are left without a division
+func (d *LeagueSqlDao) DeleteDivision(divisionId int) error {
+	_, err := psql.Delete("division").
+		Where("division_id = ?", divisionId).
+		RunWith(db).Exec()
+	return err
+}
+
+func (d *LeagueSqlDao) SetDivisionTeams(leagueId, divisionId int, teams dataModel.DivisionTeams) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err = psql.Update("team").
+		Set("division_id", nil).
+		Where("division_id = ?", divisionId).
+		RunWith(tx).Exec(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, teamId := range teams.TeamIds {
+		if _, err = psql.Update("team").
+			Set("division_id", divisionId).
+			Where("league_id = ? AND team_id = ?", leagueId, teamId).
+			RunWith(tx).Exec(); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (d *LeagueSqlDao) GetDivisions(leagueId int) ([]*dataModel.Division, error) {
+	divisions := DivisionArray{rows: make([]*dataModel.Division, 0)}
+	if err := ScanRows(getDivisionSelector().
+		Where("league_id = ?", leagueId).
+		OrderBy("name ASC"), &divisions); err != nil {
+		return nil, err
+	}
+
+	for _, division := range divisions.rows {
+		teams, err := getDivisionTeams(division.DivisionId)
+		if err != nil {
+			return nil, err
+		}
+		division.Teams = teams
+	}
+	return divisions.rows, nil
+}
+
+func (d *LeagueSqlDao) GetDivision(divisionId int) (*dataModel.Division, error) {
+	division, err := GetScannedDivision(getDivisionSelector().
+		Where("division_id = ?", divisionId).
+		RunWith(db).QueryRow())
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	division.Teams, err = getDivisionTeams(divisionId)
+	return division, err
+}
+
+func (d *LeagueSqlDao) DoesDivisionExistInLeague(leagueId, divisionId int) (bool, error) {
+	var count int
+	if err := psql.Select("count(*)").
+		From("division").
+		Where("league_id = ? AND division_id = ?", leagueId, divisionId).
+		RunWith(db).QueryRow().Scan(&count); err != nil {
+		return false, err
+	} else {
+		return count > 0, nil
+	}
+}
+
+func (d *LeagueSqlDao) IsDivisionNameInUse(leagueId, divisionId int, name string) (bool, error) {
+	var count int
+	if err := psql.Select("count(*)").
+		From("division").
+		Where("league_id = ? AND division_id != ? AND name = ?", leagueId, divisionId, name).
+		RunWith(db).QueryRow().Scan(&count); err != nil {
+		return false, err
+	} else {
+		return count > 0, nil
+	}
+}
+
+// Playoff Stages
+func (d *LeagueSqlDao) SetPlayoffStage(seasonId int, stage dataModel.PlayoffStageCore) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err = psql.Delete("playoff_stage").
+		Where("season_id = ?", seasonId).
+		RunWith(tx).Exec(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err = psql.Insert("playoff_stage").
+		Columns(
+			"season_id",
+			"tournament_type",
+			"advancing_per_group",
+			"rounds_per_week",
+			"concurrent_game_num",
+			"game_duration",
+			"best_of",
+		).
+		Values(
+			seasonId,
+			strings.ToLower(stage.TournamentType),
+			stage.AdvancingPerGroup,
+			stage.RoundsPerWeek,
+			stage.ConcurrentGameNum,
+			stage.GameDuration,
+			(&dataModel.GameCreationInformation{BestOf: stage.BestOf}).SeriesLength(),
+		).
+		RunWith(tx).Exec(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (d *LeagueSqlDao) GetPlayoffStage(seasonId int) (*dataModel.PlayoffStage, error) {
+	stage, err := GetScannedPlayoffStage(getPlayoffStageSelector().
+		Where("season_id = ?", seasonId).
+		RunWith(db).QueryRow())
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return stage, nil
+	}
+}
+
+func (d *LeagueSqlDao) DeletePlayoffStage(seasonId int) error {
+	_, err := psql.Delete("playoff_stage").
+		Where("season_id = ?", seasonId).
+		RunWith(db).Exec()
+	return err
+}
+
+// Marks the playoff stage as generated, returning false if it already was. Only the caller that claims the stage
+// creates its games, even if the last games of the group stage are reported at the same time
+func (d *LeagueSqlDao) ClaimPlayoffStage(seasonId int) (bool, error) {
+	res, err := psql.Update("playoff_stage").
+		Set("generated", true).
+		Where("season_id = ? AND generated = false", seasonId).
+		RunWith(db).Exec()
+	if err != nil {
+		return false, err
+	}
+	claimed, err := res.RowsAffected()
+	return claimed > 0, err
+}
+
+// Allows the playoff stage to be generated again after its games could not be created
+func (d *LeagueSqlDao) ReleasePlayoffStage(seasonId int) error {
+	_, err := psql.Update("playoff_stage").
+		Set("generated", false).
+		Where("season_id = ?", seasonId).
+		RunWith(db).Exec()
+	return err
+}
+
+// Leagues with a playoff stage in their current season whose games have not been created yet
+func (d *LeagueSqlDao) GetLeaguesAwaitingPlayoffs() ([]int, error) {
+	leagueIds := LeagueIdArray{rows: make([]int, 0)}
+	if err := ScanRows(psql.Select("season.league_id").
+		From("playoff_stage").
+		Join("season ON season.season_id = playoff_stage.season_id").
+		Where("season.current = true AND playoff_stage.generated = false"), &leagueIds); err != nil {
+		return nil, err
+	}
+
+	return leagueIds.rows, nil
+}
+
 // Availabilities
 func (d *LeagueSqlDao) AddAvailability(leagueId int, availability dataModel.AvailabilityCore) (int, error) {
 	var availabilityId = -1
-	err := psql.Insert("availability").
+	seasonId, err := getCurrentSeasonId(db, leagueId)
+	if err != nil {
+		return -1, err
+	}
+
+	err = psql.Insert("availability").
 		Columns(
 			"league_id",
+			"season_id",
 			"start_time",
 			"end_time",
 			"is_recurring_weekly",
 		).
 		Values(
 			leagueId,
+			seasonId,
 			availability.StartTime,
 			availability.EndTime,
 			false,
@@ -253,15 +893,22 @@ func (d *LeagueSqlDao) DeleteAvailability(availabilityId int) error {
 
 func (d *LeagueSqlDao) AddWeeklyAvailability(leagueId int, availability dataModel.WeeklyAvailabilityCore) (int, error) {
 	var availabilityId int
-	err := psql.Insert("availability").
+	seasonId, err := getCurrentSeasonId(db, leagueId)
+	if err != nil {
+		return -1, err
+	}
+
+	err = psql.Insert("availability").
 		Columns(
 			"league_id",
+			"season_id",
 			"start_time",
 			"end_time",
 			"is_recurring_weekly",
 		).
 		Values(
 			leagueId,
+			seasonId,
 			availability.StartTime,
 			availability.EndTime,
 			true,
@@ -23,6 +23,10 @@ func getLeagueSelector() squirrel.SelectBuilder {
 		"signup_end",
 		"league_start",
 		"league_end",
+		"time_zone",
+		"result_confirmation_hours",
+		"require_team_approval",
+		"withdrawal_policy",
 	).From("league")
 }
 
@@ -39,6 +43,10 @@ func GetScannedLeague(rows squirrel.RowScanner) (*dataModel.League, error) {
 		&league.SignupEnd,
 		&league.LeagueStart,
 		&league.LeagueEnd,
+		&league.TimeZone,
+		&league.ResultConfirmationHours,
+		&league.RequireTeamApproval,
+		&league.WithdrawalPolicy,
 	); err != nil {
 		return nil, err
 	} else {
@@ -79,3 +87,251 @@ func GetScannedLeaguePermissionsCore(rows squirrel.RowScanner) (*dataModel.Leagu
 		return &leaguePermissions, nil
 	}
 }
+
+// Tiebreakers
+type TiebreakerArray struct {
+	rows []string
+}
+
+func (r *TiebreakerArray) Scan(rows *sql.Rows) error {
+	var tiebreaker string
+	if err := rows.Scan(&tiebreaker); err != nil {
+		return err
+	} else {
+		r.rows = append(r.rows, tiebreaker)
+		return nil
+	}
+}
+
+// Required Positions
+type RequiredPositionArray struct {
+	rows []string
+}
+
+func (r *RequiredPositionArray) Scan(rows *sql.Rows) error {
+	var position string
+	if err := rows.Scan(&position); err != nil {
+		return err
+	} else {
+		r.rows = append(r.rows, position)
+		return nil
+	}
+}
+
+// Invites
+type LeagueInviteArray struct {
+	rows []*dataModel.LeagueInvite
+}
+
+// Invites that have not been revoked, have not expired and have uses remaining
+const outstandingInvite = "revoked = false AND expires_at > EXTRACT(EPOCH FROM NOW()) AND (max_uses = 0 OR uses < max_uses)"
+
+// Invites bound to the email of a user
+const inviteForUser = "LOWER(email) = (SELECT LOWER(email) FROM user_ WHERE user_id = ?)"
+
+func getLeagueInviteSelector() squirrel.SelectBuilder {
+	return psql.Select(
+		"invite_id",
+		"league_id",
+		"token",
+		"COALESCE(email, '')",
+		"max_uses",
+		"uses",
+		"expires_at",
+		"revoked",
+		"created_by",
+	).From("league_invite")
+}
+
+func GetScannedLeagueInvite(rows squirrel.RowScanner) (*dataModel.LeagueInvite, error) {
+	var invite dataModel.LeagueInvite
+	if err := rows.Scan(
+		&invite.InviteId,
+		&invite.LeagueId,
+		&invite.Token,
+		&invite.Email,
+		&invite.MaxUses,
+		&invite.Uses,
+		&invite.ExpiresAt,
+		&invite.Revoked,
+		&invite.CreatedBy,
+	); err != nil {
+		return nil, err
+	} else {
+		return &invite, nil
+	}
+}
+
+func (r *LeagueInviteArray) Scan(rows *sql.Rows) error {
+	row, err := GetScannedLeagueInvite(rows)
+	if err != nil {
+		return err
+	} else {
+		r.rows = append(r.rows, row)
+		return nil
+	}
+}
+
+func insertLeagueMember(runner squirrel.BaseRunner, leagueId, userId int) error {
+	_, err := psql.Insert("league_permissions").
+		Columns(
+			"user_id",
+			"league_id",
+			"administrator",
+			"create_teams",
+			"edit_teams",
+			"edit_games",
+		).
+		Values(
+			userId,
+			leagueId,
+			false,
+			false,
+			false,
+			false,
+		).
+		RunWith(runner).Exec()
+
+	return err
+}
+
+// Games and availabilities that belong to the current season of their league
+const inCurrentSeason = "season_id IN (SELECT season_id FROM season WHERE current = true)"
+
+type SeasonArray struct {
+	rows []*dataModel.Season
+}
+
+func getSeasonSelector() squirrel.SelectBuilder {
+	return psql.Select(
+		"season_id",
+		"name",
+		"season_start",
+		"season_end",
+		"current",
+	).From("season")
+}
+
+func GetScannedSeason(rows squirrel.RowScanner) (*dataModel.Season, error) {
+	var season dataModel.Season
+	if err := rows.Scan(
+		&season.SeasonId,
+		&season.Name,
+		&season.SeasonStart,
+		&season.SeasonEnd,
+		&season.Current,
+	); err != nil {
+		return nil, err
+	} else {
+		return &season, nil
+	}
+}
+
+func (r *SeasonArray) Scan(rows *sql.Rows) error {
+	row, err := GetScannedSeason(rows)
+	if err != nil {
+		return err
+	} else {
+		r.rows = append(r.rows, row)
+		return nil
+	}
+}
+
+// Games and availabilities are created in the current season of the league
+func getCurrentSeasonId(runner squirrel.BaseRunner, leagueId int) (int, error) {
+	var seasonId int
+	err := psql.Select("season_id").
+		From("season").
+		Where("league_id = ? AND current = true", leagueId).
+		RunWith(runner).QueryRow().Scan(&seasonId)
+	return seasonId, err
+}
+
+type DivisionArray struct {
+	rows []*dataModel.Division
+}
+
+func getDivisionSelector() squirrel.SelectBuilder {
+	return psql.Select(
+		"division_id",
+		"name",
+	).From("division")
+}
+
+func GetScannedDivision(rows squirrel.RowScanner) (*dataModel.Division, error) {
+	var division dataModel.Division
+	if err := rows.Scan(
+		&division.DivisionId,
+		&division.Name,
+	); err != nil {
+		return nil, err
+	} else {
+		return &division, nil
+	}
+}
+
+func (r *DivisionArray) Scan(rows *sql.Rows) error {
+	row, err := GetScannedDivision(rows)
+	if err != nil {
+		return err
+	} else {
+		r.rows = append(r.rows, row)
+		return nil
+	}
+}
+
+// Teams of a division that have not been archived
+func getDivisionTeams(divisionId int) ([]*dataModel.TeamDisplay, error) {
+	teams := TeamDisplayArray{rows: make([]*dataModel.TeamDisplay, 0)}
+	if err := ScanRows(getTeamDisplaySelector().
+		Where("division_id = ? AND archived = false", divisionId).
+		OrderBy("name ASC"), &teams); err != nil {
+		return nil, err
+	}
+
+	return teams.rows, nil
+}
+
+// Playoff Stages
+func getPlayoffStageSelector() squirrel.SelectBuilder {
+	return psql.Select(
+		"tournament_type",
+		"advancing_per_group",
+		"rounds_per_week",
+		"concurrent_game_num",
+		"game_duration",
+		"best_of",
+		"generated",
+	).From("playoff_stage")
+}
+
+func GetScannedPlayoffStage(rows squirrel.RowScanner) (*dataModel.PlayoffStage, error) {
+	var stage dataModel.PlayoffStage
+	if err := rows.Scan(
+		&stage.TournamentType,
+		&stage.AdvancingPerGroup,
+		&stage.RoundsPerWeek,
+		&stage.ConcurrentGameNum,
+		&stage.GameDuration,
+		&stage.BestOf,
+		&stage.Generated,
+	); err != nil {
+		return nil, err
+	} else {
+		return &stage, nil
+	}
+}
+
+type LeagueIdArray struct {
+	rows []int
+}
+
+func (r *LeagueIdArray) Scan(rows *sql.Rows) error {
+	var leagueId int
+	if err := rows.Scan(&leagueId); err != nil {
+		return err
+	} else {
+		r.rows = append(r.rows, leagueId)
+		return nil
+	}
+}
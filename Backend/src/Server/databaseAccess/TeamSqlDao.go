@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/Pallinder/go-randomdata"
+	"time"
 )
 
 type TeamSqlDao struct{}
@@ -211,7 +212,7 @@ func (d *TeamSqlDao) GetTeamWithRosters(teamId int) (*dataModel.TeamWithRosters,
 
 func (d *TeamSqlDao) GetAllTeamsInLeague(leagueId int) ([]*dataModel.TeamWithPlayers, error) {
 	rows, err := getTeamWithPlayersSelector().
-		Where("team.league_id = ?", leagueId).
+		Where("team.league_id = ? AND team.pending = false AND team.archived = false", leagueId).
 		OrderBy("team.wins DESC, team.losses ASC").
 		RunWith(db).Query()
 	if err != nil {
@@ -222,7 +223,7 @@ func (d *TeamSqlDao) GetAllTeamsInLeague(leagueId int) ([]*dataModel.TeamWithPla
 
 func (d *TeamSqlDao) GetAllTeamsInLeagueWithRosters(leagueId int) ([]*dataModel.TeamWithRosters, error) {
 	rows, err := getTeamWithPlayersSelector().
-		Where("team.league_id = ?", leagueId).
+		Where("team.league_id = ? AND team.pending = false AND team.archived = false", leagueId).
 		OrderBy("team.wins DESC, team.losses ASC").
 		RunWith(db).Query()
 	if err != nil {
@@ -234,13 +235,83 @@ func (d *TeamSqlDao) GetAllTeamsInLeagueWithRosters(leagueId int) ([]*dataModel.
 func (d *TeamSqlDao) GetAllTeamDisplaysInLeague(leagueId int) ([]*dataModel.TeamDisplay, error) {
 	teams := TeamDisplayArray{rows: make([]*dataModel.TeamDisplay, 0)}
 	if err := ScanRows(getTeamDisplaySelector().
-		Where("league_id = ?", leagueId), &teams); err != nil {
+		Where("league_id = ? AND pending = false AND archived = false", leagueId).
+		OrderBy("wins DESC, losses ASC"), &teams); err != nil {
+		return nil, err
+	}
+
+	return teams.rows, nil
+}
+
+// Teams that took part in a season, including teams that withdrew during it or were archived since
+func (d *TeamSqlDao) GetSeasonTeamDisplays(seasonId int) ([]*dataModel.TeamDisplay, error) {
+	teams := TeamDisplayArray{rows: make([]*dataModel.TeamDisplay, 0)}
+	if err := ScanRows(getTeamDisplaySelector().
+		Where("pending = false AND team_id IN (SELECT team_id FROM season_team WHERE season_id = ?)", seasonId).
+		OrderBy("wins DESC, losses ASC"), &teams); err != nil {
 		return nil, err
 	}
 
 	return teams.rows, nil
 }
 
+func (d *TeamSqlDao) GetWithdrawnTeamIds(seasonId int) ([]int, error) {
+	rows, err := psql.Select("team_id").
+		From("season_team").
+		Where("season_id = ? AND withdrawn = true", seasonId).
+		RunWith(db).Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	teamIds := make([]int, 0)
+	for rows.Next() {
+		var teamId int
+		if err := rows.Scan(&teamId); err != nil {
+			return nil, err
+		}
+		teamIds = append(teamIds, teamId)
+	}
+	return teamIds, rows.Err()
+}
+
+func (d *TeamSqlDao) GetPendingTeams(leagueId int) ([]*dataModel.TeamWithPlayers, error) {
+	rows, err := getTeamWithPlayersSelector().
+		Where("team.league_id = ? AND team.pending = true", leagueId).
+		OrderBy("team.team_id ASC").
+		RunWith(db).Query()
+	if err != nil {
+		return nil, err
+	}
+	return GetScannedAllTeamWithPlayers(rows)
+}
+
+func (d *TeamSqlDao) ApproveTeam(teamId int) error {
+	_, err := psql.Update("team").
+		Set("pending", false).
+		Where("team_id = ?", teamId).
+		RunWith(db).Exec()
+
+	return err
+}
+
+func (d *TeamSqlDao) WithdrawTeam(teamId int) error {
+	_, err := db.Exec("SELECT withdraw_team($1)", teamId)
+	return err
+}
+
+func (d *TeamSqlDao) GetArchivedTeams(leagueId int) ([]*dataModel.TeamWithPlayers, error) {
+	rows, err := getTeamWithPlayersSelector().
+		Where("team.league_id = ? AND team.archived = true", leagueId).
+		OrderBy("team.team_id ASC").
+		RunWith(db).Query()
+	if err != nil {
+		return nil, err
+	}
+	return GetScannedAllTeamWithPlayers(rows)
+}
+
 // Players
 func (d *TeamSqlDao) CreatePlayer(leagueId, teamId int, playerInfo dataModel.PlayerCore) (int, error) {
 	var playerId int
@@ -285,6 +356,81 @@ func (d *TeamSqlDao) UpdatePlayer(playerId int, playerInfo dataModel.PlayerCore)
 	return err
 }
 
+func (d *TeamSqlDao) GetRoster(teamId int) ([]*dataModel.RosterSlot, error) {
+	var roster RosterSlotArray
+	if err := ScanRows(psql.Select("player_id", "main_roster", "COALESCE(position, '')").
+		From("player").
+		Where("team_id = ?", teamId), &roster); err != nil {
+		return nil, err
+	}
+	return roster.rows, nil
+}
+
+// Transfers
+func (d *TeamSqlDao) TransferPlayer(playerId, fromTeamId, userId int, transfer dataModel.PlayerTransferCore) (int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return -1, err
+	}
+
+	var leagueId int
+	if err = psql.Update("player").
+		Set("team_id", transfer.TeamId).
+		Set("main_roster", transfer.MainRoster).
+		Where("player_id = ? AND team_id = ?", playerId, fromTeamId).
+		Suffix("RETURNING \"league_id\"").
+		RunWith(tx).QueryRow().Scan(&leagueId); err != nil {
+		tx.Rollback()
+		return -1, err
+	}
+
+	transferId := -1
+	if err = psql.Insert("player_transfer").
+		Columns(
+			"league_id",
+			"player_id",
+			"from_team_id",
+			"to_team_id",
+			"user_id",
+			"transferred_at",
+		).
+		Values(
+			leagueId,
+			playerId,
+			fromTeamId,
+			transfer.TeamId,
+			userId,
+			time.Now().Unix(),
+		).
+		Suffix("RETURNING \"transfer_id\"").
+		RunWith(tx).QueryRow().Scan(&transferId); err != nil {
+		tx.Rollback()
+		return -1, err
+	}
+
+	return transferId, tx.Commit()
+}
+
+func (d *TeamSqlDao) GetTeamTransfers(teamId int) ([]*dataModel.PlayerTransfer, error) {
+	transfers := PlayerTransferArray{rows: make([]*dataModel.PlayerTransfer, 0)}
+	if err := ScanRows(getPlayerTransferSelector().
+		Where("player_transfer.from_team_id = ? OR player_transfer.to_team_id = ?", teamId, teamId),
+		&transfers); err != nil {
+		return nil, err
+	}
+	return transfers.rows, nil
+}
+
+func (d *TeamSqlDao) GetLeagueTransfers(leagueId int) ([]*dataModel.PlayerTransfer, error) {
+	transfers := PlayerTransferArray{rows: make([]*dataModel.PlayerTransfer, 0)}
+	if err := ScanRows(getPlayerTransferSelector().
+		Where("player_transfer.league_id = ?", leagueId),
+		&transfers); err != nil {
+		return nil, err
+	}
+	return transfers.rows, nil
+}
+
 // Get Information For Team and Player Management
 
 func (d *TeamSqlDao) GetTeamPermissions(teamId, userId int) (*dataModel.TeamPermissionsCore, error) {
@@ -336,9 +482,34 @@ func (d *TeamSqlDao) DoesTeamExistInLeague(leagueId, teamId int) (bool, error) {
 	}
 }
 
+func (d *TeamSqlDao) IsTeamPending(leagueId, teamId int) (bool, error) {
+	var count int
+	if err := psql.Select("count(*)").
+		From("team").
+		Where("league_id = ? AND team_id = ? AND pending = true", leagueId, teamId).
+		RunWith(db).QueryRow().Scan(&count); err != nil {
+		return false, err
+	} else {
+		return count > 0, nil
+	}
+}
+
+func (d *TeamSqlDao) IsTeamArchived(leagueId, teamId int) (bool, error) {
+	var count int
+	if err := psql.Select("count(*)").
+		From("team").
+		Where("league_id = ? AND team_id = ? AND archived = true", leagueId, teamId).
+		RunWith(db).QueryRow().Scan(&count); err != nil {
+		return false, err
+	} else {
+		return count > 0, nil
+	}
+}
+
+// A team is active once it has games, after which it is withdrawn instead of deleted to keep their results
 func (d *TeamSqlDao) IsTeamActive(leagueId, teamId int) (bool, error) {
 	var count int
-	if err := psql.Select("count(id)").
+	if err := psql.Select("count(*)").
 		From("game").
 		Where("league_id = ? AND ( team1_id = ? OR team2_id = ?)", leagueId, teamId, teamId).
 		RunWith(db).QueryRow().Scan(&count); err != nil {
@@ -360,6 +531,28 @@ func (d *TeamSqlDao) DoesPlayerExist(leagueId, teamId, playerId int) (bool, erro
 	}
 }
 
+func (d *TeamSqlDao) GetPlayerUserId(playerId int) (int, error) {
+	var userId sql.NullInt64
+	err := psql.Select("user_id").
+		From("player").
+		Where("player_id = ?", playerId).
+		RunWith(db).QueryRow().Scan(&userId)
+
+	return int(userId.Int64), err
+}
+
+func (d *TeamSqlDao) IsUserOnRosterInLeague(leagueId, userId int) (bool, error) {
+	var count int
+	if err := psql.Select("count(*)").
+		From("player").
+		Where("league_id = ? AND user_id = ?", leagueId, userId).
+		RunWith(db).QueryRow().Scan(&count); err != nil {
+		return false, err
+	} else {
+		return count > 0, nil
+	}
+}
+
 // Managers
 
 func (d *TeamSqlDao) ChangeManagerPermissions(teamId, userId int, teamPermissionInformation dataModel.TeamPermissionsCore) error {
@@ -372,3 +565,303 @@ func (d *TeamSqlDao) ChangeManagerPermissions(teamId, userId int, teamPermission
 
 	return err
 }
+
+// Roster Requests
+
+func (d *TeamSqlDao) CreateRosterRequest(teamId, userId int, fromTeam bool,
+	request dataModel.RosterRequestCore) (int, error) {
+	var playerId, name, gameIdentifier interface{}
+	if request.PlayerId != 0 {
+		playerId = request.PlayerId
+	} else {
+		name = request.Name
+		gameIdentifier = request.GameIdentifier
+	}
+
+	var requestId = -1
+	err := psql.Insert("roster_request").
+		Columns(
+			"team_id",
+			"user_id",
+			"player_id",
+			"name",
+			"game_identifier",
+			"main_roster",
+			"from_team",
+			"created_at",
+		).
+		Values(
+			teamId,
+			userId,
+			playerId,
+			name,
+			gameIdentifier,
+			request.MainRoster,
+			fromTeam,
+			time.Now().Unix(),
+		).
+		Suffix("RETURNING \"request_id\"").
+		RunWith(db).QueryRow().Scan(&requestId)
+
+	return requestId, err
+}
+
+func (d *TeamSqlDao) GetRosterRequest(requestId int) (*dataModel.RosterRequest, error) {
+	request, err := GetScannedRosterRequest(getRosterRequestSelector().
+		Where("roster_request.request_id = ?", requestId).
+		RunWith(db).QueryRow())
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else {
+		return request, err
+	}
+}
+
+func (d *TeamSqlDao) GetTeamRosterRequests(teamId int) ([]*dataModel.RosterRequest, error) {
+	requests := RosterRequestArray{rows: make([]*dataModel.RosterRequest, 0)}
+	if err := ScanRows(getRosterRequestSelector().
+		Where("roster_request.team_id = ?", teamId).
+		OrderBy("roster_request.created_at ASC"), &requests); err != nil {
+		return nil, err
+	}
+
+	return requests.rows, nil
+}
+
+func (d *TeamSqlDao) GetUserRosterInvites(userId int) ([]*dataModel.RosterRequest, error) {
+	requests := RosterRequestArray{rows: make([]*dataModel.RosterRequest, 0)}
+	if err := ScanRows(getRosterRequestSelector().
+		Where("roster_request.user_id = ? AND roster_request.from_team = true", userId).
+		OrderBy("roster_request.created_at ASC"), &requests); err != nil {
+		return nil, err
+	}
+
+	return requests.rows, nil
+}
+
+// Links the user to the player, joining the league if they are not a member yet. A user can only be a player on one
+// team per league, so their other pending requests in the league are removed
+func (d *TeamSqlDao) AcceptRosterRequest(requestId int) (int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return -1, err
+	}
+
+	request, err := GetScannedRosterRequest(getRosterRequestSelector().
+		Where("roster_request.request_id = ?", requestId).
+		RunWith(tx).QueryRow())
+	if err != nil {
+		tx.Rollback()
+		return -1, err
+	}
+
+	playerId := request.PlayerId
+	if playerId != 0 {
+		_, err = psql.Update("player").
+			Set("user_id", request.UserId).
+			Where("player_id = ?", playerId).
+			RunWith(tx).Exec()
+	} else {
+		err = psql.Insert("player").
+			Columns(
+				"team_id",
+				"league_id",
+				"user_id",
+				"game_identifier",
+				"name",
+				"main_roster",
+			).
+			Values(
+				request.TeamId,
+				request.LeagueId,
+				request.UserId,
+				request.GameIdentifier,
+				request.Name,
+				request.MainRoster,
+			).
+			Suffix("RETURNING \"player_id\"").
+			RunWith(tx).QueryRow().Scan(&playerId)
+	}
+	if err != nil {
+		tx.Rollback()
+		return -1, err
+	}
+
+	if _, err = psql.Delete("roster_request").
+		Where("user_id = ? AND team_id IN (SELECT team_id FROM team WHERE league_id = ?)",
+			request.UserId, request.LeagueId).
+		RunWith(tx).Exec(); err != nil {
+		tx.Rollback()
+		return -1, err
+	}
+
+	var memberships int
+	if err = psql.Select("count(*)").
+		From("league_permissions").
+		Where("league_id = ? AND user_id = ?", request.LeagueId, request.UserId).
+		RunWith(tx).QueryRow().Scan(&memberships); err != nil {
+		tx.Rollback()
+		return -1, err
+	}
+	if memberships == 0 {
+		if err = insertLeagueMember(tx, request.LeagueId, request.UserId); err != nil {
+			tx.Rollback()
+			return -1, err
+		}
+	}
+
+	return playerId, tx.Commit()
+}
+
+func (d *TeamSqlDao) DeleteRosterRequest(requestId int) error {
+	_, err := psql.Delete("roster_request").
+		Where("request_id = ?", requestId).
+		RunWith(db).Exec()
+	return err
+}
+
+func (d *TeamSqlDao) DoesRosterRequestExist(teamId, requestId int) (bool, error) {
+	var count int
+	if err := psql.Select("count(*)").
+		From("roster_request").
+		Where("team_id = ? AND request_id = ?", teamId, requestId).
+		RunWith(db).QueryRow().Scan(&count); err != nil {
+		return false, err
+	} else {
+		return count > 0, nil
+	}
+}
+
+func (d *TeamSqlDao) HasPendingRosterRequest(teamId, userId int) (bool, error) {
+	var count int
+	if err := psql.Select("count(*)").
+		From("roster_request").
+		Where("team_id = ? AND user_id = ?", teamId, userId).
+		RunWith(db).QueryRow().Scan(&count); err != nil {
+		return false, err
+	} else {
+		return count > 0, nil
+	}
+}
+
+// Roster Changes
+
+func (d *TeamSqlDao) CreateRosterChange(teamId, userId int, change dataModel.RosterChangeCore) (int, error) {
+	var playerId, name, gameIdentifier, externalId, position interface{}
+	if change.PlayerId != 0 {
+		playerId = change.PlayerId
+	} else {
+		name = change.Name
+		gameIdentifier = change.GameIdentifier
+		if change.ExternalId != "" {
+			externalId = change.ExternalId
+			position = change.Position
+		}
+	}
+
+	var rosterChangeId = -1
+	err := psql.Insert("roster_change").
+		Columns(
+			"team_id",
+			"user_id",
+			"player_id",
+			"name",
+			"game_identifier",
+			"external_id",
+			"main_roster",
+			"position",
+			"created_at",
+		).
+		Values(
+			teamId,
+			userId,
+			playerId,
+			name,
+			gameIdentifier,
+			externalId,
+			change.MainRoster,
+			position,
+			time.Now().Unix(),
+		).
+		Suffix("RETURNING \"roster_change_id\"").
+		RunWith(db).QueryRow().Scan(&rosterChangeId)
+
+	return rosterChangeId, err
+}
+
+func (d *TeamSqlDao) GetRosterChange(leagueId, rosterChangeId int) (*dataModel.RosterChange, error) {
+	change, err := GetScannedRosterChange(getRosterChangeSelector().
+		Where("team.league_id = ? AND roster_change.roster_change_id = ?", leagueId, rosterChangeId).
+		RunWith(db).QueryRow())
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else {
+		return change, err
+	}
+}
+
+func (d *TeamSqlDao) GetRosterChanges(leagueId int) ([]*dataModel.RosterChange, error) {
+	changes := RosterChangeArray{rows: make([]*dataModel.RosterChange, 0)}
+	if err := ScanRows(getRosterChangeSelector().
+		Where("team.league_id = ?", leagueId).
+		OrderBy("roster_change.created_at ASC"), &changes); err != nil {
+		return nil, err
+	}
+
+	return changes.rows, nil
+}
+
+func (d *TeamSqlDao) DeleteRosterChange(rosterChangeId int) error {
+	_, err := psql.Delete("roster_change").
+		Where("roster_change_id = ?", rosterChangeId).
+		RunWith(db).Exec()
+	return err
+}
+
+// Blackouts
+
+func (d *TeamSqlDao) AddTeamBlackout(teamId int, blackout dataModel.TeamBlackoutCore) (int, error) {
+	var blackoutId = -1
+	err := psql.Insert("team_blackout").
+		Columns(
+			"team_id",
+			"start_time",
+			"end_time",
+		).
+		Values(
+			teamId,
+			blackout.StartTime,
+			blackout.EndTime,
+		).
+		Suffix("RETURNING \"blackout_id\"").
+		RunWith(db).QueryRow().Scan(&blackoutId)
+
+	return blackoutId, err
+}
+
+func (d *TeamSqlDao) GetTeamBlackouts(teamId int) ([]*dataModel.TeamBlackout, error) {
+	blackouts := TeamBlackoutArray{rows: make([]*dataModel.TeamBlackout, 0)}
+	if err := ScanRows(getTeamBlackoutSelector().
+		Where("team_id = ?", teamId), &blackouts); err != nil {
+		return nil, err
+	}
+
+	return blackouts.rows, nil
+}
+
+func (d *TeamSqlDao) GetAllTeamBlackoutsInLeague(leagueId int) ([]*dataModel.TeamBlackout, error) {
+	blackouts := TeamBlackoutArray{rows: make([]*dataModel.TeamBlackout, 0)}
+	if err := ScanRows(getTeamBlackoutSelector().
+		Where("team_id IN (SELECT team_id FROM team WHERE league_id = ?)", leagueId), &blackouts); err != nil {
+		return nil, err
+	}
+
+	return blackouts.rows, nil
+}
+
+func (d *TeamSqlDao) DeleteTeamBlackout(teamId, blackoutId int) error {
+	_, err := psql.Delete("team_blackout").
+		Where("blackout_id = ? AND team_id = ?", blackoutId, teamId).
+		RunWith(db).Exec()
+	return err
+}
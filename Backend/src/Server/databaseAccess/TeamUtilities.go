@@ -53,6 +53,7 @@ func getTeamWithPlayersSelector() squirrel.SelectBuilder {
 		"player.name",
 		"player.game_identifier",
 		"player.main_roster",
+		"player.user_id",
 	).
 		From("team").
 		LeftJoin("player ON team.team_id = player.team_id")
@@ -80,6 +81,7 @@ func GetScannedAllTeamWithRosters(rows *sql.Rows) ([]*dataModel.TeamWithRosters,
 			playerName           sql.NullString
 			playerGameIdentifier sql.NullString
 			playerMainRoster     sql.NullBool
+			playerUserId         sql.NullInt64
 		)
 		if err := rows.Scan(
 			&team.TeamId,
@@ -94,6 +96,7 @@ func GetScannedAllTeamWithRosters(rows *sql.Rows) ([]*dataModel.TeamWithRosters,
 			&playerName,
 			&playerGameIdentifier,
 			&playerMainRoster,
+			&playerUserId,
 		); err != nil {
 			return nil, err
 		}
@@ -106,6 +109,7 @@ func GetScannedAllTeamWithRosters(rows *sql.Rows) ([]*dataModel.TeamWithRosters,
 					Name:           playerName.String,
 					GameIdentifier: playerGameIdentifier.String,
 					MainRoster:     playerMainRoster.Bool,
+					UserId:         int(playerUserId.Int64),
 				})
 			} else {
 				uniqueTeam.SubstituteRoster = append(uniqueTeam.SubstituteRoster, &dataModel.Player{
@@ -113,6 +117,7 @@ func GetScannedAllTeamWithRosters(rows *sql.Rows) ([]*dataModel.TeamWithRosters,
 					Name:           playerName.String,
 					GameIdentifier: playerGameIdentifier.String,
 					MainRoster:     playerMainRoster.Bool,
+					UserId:         int(playerUserId.Int64),
 				})
 			}
 		}
@@ -155,6 +160,7 @@ func GetScannedAllTeamWithPlayers(rows *sql.Rows) ([]*dataModel.TeamWithPlayers,
 			playerName           sql.NullString
 			playerGameIdentifier sql.NullString
 			playerMainRoster     sql.NullBool
+			playerUserId         sql.NullInt64
 		)
 		if err := rows.Scan(
 			&team.TeamId,
@@ -169,6 +175,7 @@ func GetScannedAllTeamWithPlayers(rows *sql.Rows) ([]*dataModel.TeamWithPlayers,
 			&playerName,
 			&playerGameIdentifier,
 			&playerMainRoster,
+			&playerUserId,
 		); err != nil {
 			return nil, err
 		}
@@ -180,6 +187,7 @@ func GetScannedAllTeamWithPlayers(rows *sql.Rows) ([]*dataModel.TeamWithPlayers,
 				Name:           playerName.String,
 				GameIdentifier: playerGameIdentifier.String,
 				MainRoster:     playerMainRoster.Bool,
+				UserId:         int(playerUserId.Int64),
 			})
 		}
 	}
@@ -375,6 +383,8 @@ func getTeamDisplaySelector() squirrel.SelectBuilder {
 		"name",
 		"tag",
 		"icon_small",
+		"wins",
+		"losses",
 	).From("team")
 }
 
@@ -385,6 +395,8 @@ func GetScannedTeamDisplay(rows squirrel.RowScanner) (*dataModel.TeamDisplay, er
 		&team.Name,
 		&team.Tag,
 		&team.IconSmall,
+		&team.Wins,
+		&team.Losses,
 	); err != nil {
 		return nil, err
 	} else {
@@ -447,3 +459,239 @@ func (r *TeamPermissionsArray) Scan(rows *sql.Rows) error {
 		return nil
 	}
 }
+
+type TeamBlackoutArray struct {
+	rows []*dataModel.TeamBlackout
+}
+
+func getTeamBlackoutSelector() squirrel.SelectBuilder {
+	return psql.Select(
+		"blackout_id",
+		"team_id",
+		"start_time",
+		"end_time",
+	).From("team_blackout")
+}
+
+func GetScannedTeamBlackout(rows squirrel.RowScanner) (*dataModel.TeamBlackout, error) {
+	var blackout dataModel.TeamBlackout
+	if err := rows.Scan(
+		&blackout.BlackoutId,
+		&blackout.TeamId,
+		&blackout.StartTime,
+		&blackout.EndTime,
+	); err != nil {
+		return nil, err
+	} else {
+		return &blackout, nil
+	}
+}
+
+func (r *TeamBlackoutArray) Scan(rows *sql.Rows) error {
+	row, err := GetScannedTeamBlackout(rows)
+	if err != nil {
+		return err
+	} else {
+		r.rows = append(r.rows, row)
+		return nil
+	}
+}
+
+// Roster Slots
+type RosterSlotArray struct {
+	rows []*dataModel.RosterSlot
+}
+
+func (r *RosterSlotArray) Scan(rows *sql.Rows) error {
+	var slot dataModel.RosterSlot
+	if err := rows.Scan(&slot.PlayerId, &slot.MainRoster, &slot.Position); err != nil {
+		return err
+	} else {
+		r.rows = append(r.rows, &slot)
+		return nil
+	}
+}
+
+// Transfers
+type PlayerTransferArray struct {
+	rows []*dataModel.PlayerTransfer
+}
+
+func getPlayerTransferSelector() squirrel.SelectBuilder {
+	return psql.Select(
+		"player_transfer.transfer_id",
+		"player_transfer.player_id",
+		"player.name",
+		"player.game_identifier",
+		"from_team.team_id",
+		"from_team.name",
+		"from_team.tag",
+		"from_team.icon_small",
+		"from_team.wins",
+		"from_team.losses",
+		"to_team.team_id",
+		"to_team.name",
+		"to_team.tag",
+		"to_team.icon_small",
+		"to_team.wins",
+		"to_team.losses",
+		"COALESCE(player_transfer.user_id, 0)",
+		"player_transfer.transferred_at",
+	).
+		From("player_transfer").
+		Join("player ON player_transfer.player_id = player.player_id").
+		Join("team AS from_team ON player_transfer.from_team_id = from_team.team_id").
+		Join("team AS to_team ON player_transfer.to_team_id = to_team.team_id").
+		OrderBy("player_transfer.transferred_at DESC", "player_transfer.transfer_id DESC")
+}
+
+func GetScannedPlayerTransfer(rows squirrel.RowScanner) (*dataModel.PlayerTransfer, error) {
+	var transfer dataModel.PlayerTransfer
+	if err := rows.Scan(
+		&transfer.TransferId,
+		&transfer.PlayerId,
+		&transfer.Name,
+		&transfer.GameIdentifier,
+		&transfer.FromTeam.TeamId,
+		&transfer.FromTeam.Name,
+		&transfer.FromTeam.Tag,
+		&transfer.FromTeam.IconSmall,
+		&transfer.FromTeam.Wins,
+		&transfer.FromTeam.Losses,
+		&transfer.ToTeam.TeamId,
+		&transfer.ToTeam.Name,
+		&transfer.ToTeam.Tag,
+		&transfer.ToTeam.IconSmall,
+		&transfer.ToTeam.Wins,
+		&transfer.ToTeam.Losses,
+		&transfer.UserId,
+		&transfer.TransferredAt,
+	); err != nil {
+		return nil, err
+	} else {
+		return &transfer, nil
+	}
+}
+
+func (r *PlayerTransferArray) Scan(rows *sql.Rows) error {
+	row, err := GetScannedPlayerTransfer(rows)
+	if err != nil {
+		return err
+	} else {
+		r.rows = append(r.rows, row)
+		return nil
+	}
+}
+
+// Roster Requests
+type RosterRequestArray struct {
+	rows []*dataModel.RosterRequest
+}
+
+// Requests linking an existing player show the current name and game identifier of that player
+func getRosterRequestSelector() squirrel.SelectBuilder {
+	return psql.Select(
+		"roster_request.request_id",
+		"team.league_id",
+		"roster_request.team_id",
+		"roster_request.user_id",
+		"user_.email",
+		"COALESCE(roster_request.player_id, 0)",
+		"COALESCE(player.name, roster_request.name, '')",
+		"COALESCE(player.game_identifier, roster_request.game_identifier, '')",
+		"COALESCE(player.main_roster, roster_request.main_roster)",
+		"roster_request.from_team",
+		"roster_request.created_at",
+	).
+		From("roster_request").
+		Join("team ON team.team_id = roster_request.team_id").
+		Join("user_ ON user_.user_id = roster_request.user_id").
+		LeftJoin("player ON player.player_id = roster_request.player_id")
+}
+
+func GetScannedRosterRequest(rows squirrel.RowScanner) (*dataModel.RosterRequest, error) {
+	var request dataModel.RosterRequest
+	if err := rows.Scan(
+		&request.RequestId,
+		&request.LeagueId,
+		&request.TeamId,
+		&request.UserId,
+		&request.Email,
+		&request.PlayerId,
+		&request.Name,
+		&request.GameIdentifier,
+		&request.MainRoster,
+		&request.FromTeam,
+		&request.CreatedAt,
+	); err != nil {
+		return nil, err
+	} else {
+		return &request, nil
+	}
+}
+
+func (r *RosterRequestArray) Scan(rows *sql.Rows) error {
+	row, err := GetScannedRosterRequest(rows)
+	if err != nil {
+		return err
+	} else {
+		r.rows = append(r.rows, row)
+		return nil
+	}
+}
+
+// Roster Changes
+type RosterChangeArray struct {
+	rows []*dataModel.RosterChange
+}
+
+// Removals show the current information of the player to remove
+func getRosterChangeSelector() squirrel.SelectBuilder {
+	return psql.Select(
+		"roster_change.roster_change_id",
+		"team.league_id",
+		"roster_change.team_id",
+		"roster_change.user_id",
+		"COALESCE(roster_change.player_id, 0)",
+		"COALESCE(player.name, roster_change.name, '')",
+		"COALESCE(player.game_identifier, roster_change.game_identifier, '')",
+		"COALESCE(player.external_id, roster_change.external_id, '')",
+		"COALESCE(player.main_roster, roster_change.main_roster)",
+		"COALESCE(player.position, roster_change.position, '')",
+		"roster_change.created_at",
+	).
+		From("roster_change").
+		Join("team ON team.team_id = roster_change.team_id").
+		LeftJoin("player ON player.player_id = roster_change.player_id")
+}
+
+func GetScannedRosterChange(rows squirrel.RowScanner) (*dataModel.RosterChange, error) {
+	var change dataModel.RosterChange
+	if err := rows.Scan(
+		&change.RosterChangeId,
+		&change.LeagueId,
+		&change.TeamId,
+		&change.UserId,
+		&change.PlayerId,
+		&change.Name,
+		&change.GameIdentifier,
+		&change.ExternalId,
+		&change.MainRoster,
+		&change.Position,
+		&change.CreatedAt,
+	); err != nil {
+		return nil, err
+	} else {
+		return &change, nil
+	}
+}
+
+func (r *RosterChangeArray) Scan(rows *sql.Rows) error {
+	row, err := GetScannedRosterChange(rows)
+	if err != nil {
+		return err
+	} else {
+		r.rows = append(r.rows, row)
+		return nil
+	}
+}
@@ -91,7 +91,34 @@ func getLeagueAndTeamPermissions(leagueId, teamId, userId int) (*dataModel.Leagu
 //}
 
 func (d *UserSqlDao) GetUserProfile(userId int) (*dataModel.User, error) {
-	return GetScannedUser(getUserSelector().Where("user_id = ?", userId).RunWith(db).QueryRow())
+	user, err := GetScannedUser(getUserSelector().Where("user_id = ?", userId).RunWith(db).QueryRow())
+	if err != nil {
+		return nil, err
+	}
+
+	teams := UserTeamArray{rows: make([]*dataModel.UserTeam, 0)}
+	if err := ScanRows(getUserTeamSelector().
+		Where("player.user_id = ?", userId).
+		OrderBy("league.league_id DESC"), &teams); err != nil {
+		return nil, err
+	}
+	user.Teams = teams.rows
+
+	return user, nil
+}
+
+// Returns 0 if no user has this email
+func (d *UserSqlDao) GetUserIdByEmail(email string) (int, error) {
+	var userId int
+	err := psql.Select("user_id").
+		From("user_").
+		Where("email = ?", email).
+		RunWith(db).QueryRow().Scan(&userId)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	} else {
+		return userId, err
+	}
 }
 
 func (d *UserSqlDao) GetUserWithPermissions(leagueId, userId int) (*dataModel.UserWithPermissions, error) {
@@ -2,6 +2,7 @@ package databaseAccess
 
 import (
 	"Server/dataModel"
+	"database/sql"
 	"github.com/Masterminds/squirrel"
 )
 
@@ -38,3 +39,54 @@ func GetScannedUser(rows squirrel.RowScanner) (*dataModel.User, error) {
 		return &user, nil
 	}
 }
+
+// UserTeam
+type UserTeamArray struct {
+	rows []*dataModel.UserTeam
+}
+
+func getUserTeamSelector() squirrel.SelectBuilder {
+	return psql.Select(
+		"league.league_id",
+		"league.name",
+		"player.player_id",
+		"team.team_id",
+		"team.name",
+		"team.tag",
+		"team.icon_small",
+		"team.wins",
+		"team.losses",
+	).
+		From("player").
+		Join("team ON team.team_id = player.team_id").
+		Join("league ON league.league_id = player.league_id")
+}
+
+func GetScannedUserTeam(rows squirrel.RowScanner) (*dataModel.UserTeam, error) {
+	var userTeam dataModel.UserTeam
+	if err := rows.Scan(
+		&userTeam.LeagueId,
+		&userTeam.LeagueName,
+		&userTeam.PlayerId,
+		&userTeam.Team.TeamId,
+		&userTeam.Team.Name,
+		&userTeam.Team.Tag,
+		&userTeam.Team.IconSmall,
+		&userTeam.Team.Wins,
+		&userTeam.Team.Losses,
+	); err != nil {
+		return nil, err
+	} else {
+		return &userTeam, nil
+	}
+}
+
+func (r *UserTeamArray) Scan(rows *sql.Rows) error {
+	row, err := GetScannedUserTeam(rows)
+	if err != nil {
+		return err
+	} else {
+		r.rows = append(r.rows, row)
+		return nil
+	}
+}
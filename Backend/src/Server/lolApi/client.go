@@ -0,0 +1,84 @@
+package lolApi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Requests that are rate limited or find the Riot API unavailable are attempted this many times in total
+const maxAttempts = 3
+
+// Wait before retrying a request the Riot API was unavailable for, unless it sent a Retry-After header
+const unavailableRetryAfter = 500 * time.Millisecond
+
+// Returned for responses of the Riot API other than 200 OK
+type StatusError struct {
+	StatusCode int
+	Url        string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%v %v from %v", e.StatusCode, http.StatusText(e.StatusCode), e.Url)
+}
+
+// Whether the request failed because the Riot API could not answer it, rather than because of the request
+func isUnavailable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// Decodes the JSON response of the Riot API to a GET request to method into out. Requests wait for the rate limits
+// of the application and the method, and are retried when rate limited or when the Riot API is unavailable
+func (l *lolApi) get(method, url string, out interface{}) error {
+	for attempt := 1; ; attempt++ {
+		l.limiter.wait(method)
+		res, err := l.send(url, true)
+		if err != nil {
+			return err
+		}
+		l.limiter.update(method, res.Header)
+
+		if res.StatusCode == http.StatusOK {
+			defer res.Body.Close()
+			return json.NewDecoder(res.Body).Decode(out)
+		}
+		res.Body.Close()
+
+		if !isUnavailable(res.StatusCode) || attempt == maxAttempts {
+			return &StatusError{StatusCode: res.StatusCode, Url: url}
+		}
+		if res.StatusCode == http.StatusTooManyRequests {
+			l.limiter.backOff(method, res.Header)
+		} else if res.Header.Get("Retry-After") != "" {
+			time.Sleep(retryAfter(res.Header))
+		} else {
+			time.Sleep(unavailableRetryAfter)
+		}
+	}
+}
+
+// Decodes the JSON response of Data Dragon, which is not rate limited and must not be sent the api key
+func (l *lolApi) getStatic(url string, out interface{}) error {
+	res, err := l.send(url, false)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return &StatusError{StatusCode: res.StatusCode, Url: url}
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+func (l *lolApi) send(url string, authenticate bool) (*http.Response, error) {
+	httpReq, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if authenticate {
+		httpReq.Header.Set("X-Riot-Token", l.apiKey)
+	}
+	return l.client.Do(httpReq)
+}
@@ -0,0 +1,139 @@
+package lolApi
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// Responses of the Riot API, limited to the fields that are used
+
+// summoner-v4
+type summonerDto struct {
+	Id            string `json:"id"`
+	AccountId     string `json:"accountId"`
+	Puuid         string `json:"puuid"`
+	Name          string `json:"name"`
+	ProfileIconId int    `json:"profileIconId"`
+	SummonerLevel int    `json:"summonerLevel"`
+}
+
+// league-v4
+type leagueEntryDto struct {
+	QueueType    string `json:"queueType"`
+	Tier         string `json:"tier"`
+	Rank         string `json:"rank"`
+	LeaguePoints int    `json:"leaguePoints"`
+	Wins         int    `json:"wins"`
+	Losses       int    `json:"losses"`
+}
+
+// match-v4
+type matchDto struct {
+	GameId                int64                    `json:"gameId"`
+	GameCreation          int64                    `json:"gameCreation"`
+	GameDuration          int64                    `json:"gameDuration"`
+	Teams                 []teamStatsDto           `json:"teams"`
+	Participants          []participantDto         `json:"participants"`
+	ParticipantIdentities []participantIdentityDto `json:"participantIdentities"`
+}
+
+type teamStatsDto struct {
+	TeamId     int           `json:"teamId"`
+	Win        string        `json:"win"`
+	FirstBlood bool          `json:"firstBlood"`
+	FirstTower bool          `json:"firstTower"`
+	Bans       []teamBansDto `json:"bans"`
+}
+
+type teamBansDto struct {
+	ChampionId int `json:"championId"`
+	PickTurn   int `json:"pickTurn"`
+}
+
+type participantDto struct {
+	ParticipantId int                 `json:"participantId"`
+	TeamId        int                 `json:"teamId"`
+	ChampionId    int                 `json:"championId"`
+	Stats         participantStatsDto `json:"stats"`
+}
+
+type participantStatsDto struct {
+	Win                         bool `json:"win"`
+	Kills                       int  `json:"kills"`
+	Deaths                      int  `json:"deaths"`
+	Assists                     int  `json:"assists"`
+	GoldEarned                  int  `json:"goldEarned"`
+	TotalMinionsKilled          int  `json:"totalMinionsKilled"`
+	TotalDamageDealtToChampions int  `json:"totalDamageDealtToChampions"`
+	WardsPlaced                 int  `json:"wardsPlaced"`
+}
+
+type participantIdentityDto struct {
+	ParticipantId int       `json:"participantId"`
+	Player        playerDto `json:"player"`
+}
+
+type playerDto struct {
+	SummonerId   string `json:"summonerId"`
+	SummonerName string `json:"summonerName"`
+	AccountId    string `json:"accountId"`
+}
+
+// Data Dragon champion.json
+type championListDto struct {
+	Data map[string]championDto `json:"data"`
+}
+
+type championDto struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// Query parameter of the provider callback url holding the secret that callbacks are verified with
+const CallbackSecretParam = "secret"
+
+// Body of the request the Riot tournament API makes to the provider callback url when a tournament game ends
+type TournamentCallback struct {
+	StartTime int64  `json:"startTime"`
+	ShortCode string `json:"shortCode"`
+	MetaData  string `json:"metaData"`
+	GameId    int64  `json:"gameId"`
+	GameName  string `json:"gameName"`
+	GameType  string `json:"gameType"`
+	GameMap   int    `json:"gameMap"`
+	GameMode  string `json:"gameMode"`
+	Region    string `json:"region"`
+}
+
+// Metadata attached to the tournament code of a game, returned in the callback once the game is played
+type TournamentGameMetadata struct {
+	GameId           string `json:"gameId"`
+	ElmGameId        int    `json:"elmGameId"`
+	Team1Id          int    `json:"team1Id"`
+	Team2Id          int    `json:"team2Id"`
+	Team1RefPlayerId string `json:"team1RefPlayerId"`
+}
+
+// Body of the request creating a tournament code. When AllowedSummonerIds is empty anyone can join the lobby
+type TournamentCodeParameters struct {
+	AllowedSummonerIds []string `json:"allowedSummonerIds,omitempty"`
+	MapType            string   `json:"mapType"`
+	Metadata           string   `json:"metadata"`
+	PickType           string   `json:"pickType"`
+	SpectatorType      string   `json:"spectatorType"`
+	TeamSize           int      `json:"teamSize"`
+}
+
+// The metadata is a string holding JSON, since it is passed through the Riot API unchanged
+func (callback *TournamentCallback) GameMetadata() (*TournamentGameMetadata, error) {
+	var metadata TournamentGameMetadata
+	if err := json.Unmarshal([]byte(callback.MetaData), &metadata); err != nil {
+		return nil, err
+	}
+	return &metadata, nil
+}
+
+// Id of the played game in the match API
+func (callback *TournamentCallback) MatchId() string {
+	return strconv.FormatInt(callback.GameId, 10)
+}
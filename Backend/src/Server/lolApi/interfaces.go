@@ -3,14 +3,20 @@ package lolApi
 import (
 	"Server/config"
 	"Server/dataModel"
+	"fmt"
 	"github.com/imroc/req"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
 )
 
 type SummonerInformation struct {
 	GameIdentifier string `json:"gameIdentifier"`
 	Rank           string `json:"rank"`
 	Tier           string `json:"tier"`
+	Stale          bool   `json:"stale"`
 }
 
 type LoLApi interface {
@@ -21,32 +27,79 @@ type LoLApi interface {
 }
 
 type lolApi struct {
-	client *http.Client
+	client        *http.Client
+	apiUrl        string
+	dataDragonUrl string
+	apiKey        string
+	limiter       *rateLimiter
+	summoners     *SummonerCache
+
+	championsLock sync.Mutex
+	champions     map[int]string
+}
+
+// Summoners are only cached in the store if the configuration enables it
+func GetLoLApi(config config.Config, store SummonerStore) LoLApi {
+	if !config.GetPersistSummonerCache() {
+		store = nil
+	}
+	return NewLoLApi(
+		config.GetLeagueOfLegendsApiUrl(),
+		config.GetDataDragonUrl(),
+		config.GetLeagueOfLegendsApiKey(),
+		&http.Client{Timeout: 10 * time.Second},
+		NewSummonerCache(config.GetSummonerCacheTtl(), store))
 }
 
-func GetLolApiWrapper() LoLApi {
-	//startWrapperServer()
+// Creates a client of the Riot API platform at apiUrl. Requests are made with the given http client, so that its
+// transport can be replaced, for example by a fake server in tests. Without a summoner cache, summoners are fetched
+// every time but the last information of each is still shown while the Riot API is unavailable
+func NewLoLApi(apiUrl, dataDragonUrl, apiKey string, client *http.Client, summoners *SummonerCache) LoLApi {
+	if summoners == nil {
+		summoners = NewSummonerCache(0, nil)
+	}
 	return &lolApi{
-		client: &http.Client{},
+		client:        client,
+		apiUrl:        strings.TrimSuffix(apiUrl, "/"),
+		dataDragonUrl: strings.TrimSuffix(dataDragonUrl, "/"),
+		apiKey:        apiKey,
+		limiter:       newRateLimiter(),
+		summoners:     summoners,
 	}
 }
 
 type LoLTournamentApi interface {
 	RegisterTournament(leagueId int, region, tournamentName string) (providerId int, tournamentId int, err error)
-	CreateTournamentKey(tournamentId int, metadata string) (string, error)
-	ForwardCompleteTournamentGame(body []byte) error
+	CreateTournamentKey(region string, tournamentId int, parameters *TournamentCodeParameters) (string, error)
 }
 
 type NativeLoLTournamentApi struct {
-	r      *req.Req
-	apiKey string
+	r           *req.Req
+	apiKey      string
+	callbackUrl string
 }
 
-func GetLoLTournamentApi(config config.Config) LoLTournamentApi {
-	//startWrapperServer()
-	req.Debug = true
+func GetLoLTournamentApi(config config.Config) (LoLTournamentApi, error) {
+	callbackUrl, err := getCallbackUrl(config.GetTournamentCallbackUrl(), config.GetTournamentCallbackSecret())
+	if err != nil {
+		return nil, err
+	}
 	return &NativeLoLTournamentApi{
-		r:      req.New(),
-		apiKey: config.GetLeagueOfLegendsApiKey(),
+		r:           req.New(),
+		apiKey:      config.GetLeagueOfLegendsApiKey(),
+		callbackUrl: callbackUrl,
+	}, nil
+}
+
+// The Riot tournament API does not sign callbacks, so the secret is part of the provider url that it calls. The url
+// is not logged since it holds the secret
+func getCallbackUrl(callbackUrl, secret string) (string, error) {
+	providerUrl, err := url.Parse(callbackUrl)
+	if err != nil {
+		return "", fmt.Errorf("invalid tournament callback url: %v", err)
 	}
+	query := providerUrl.Query()
+	query.Set(CallbackSecretParam, secret)
+	providerUrl.RawQuery = query.Encode()
+	return providerUrl.String(), nil
 }
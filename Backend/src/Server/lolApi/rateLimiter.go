@@ -0,0 +1,154 @@
+package lolApi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limits of a development api key, used until the Riot API reports the limits of the application
+const defaultAppRateLimit = "20:1,100:120"
+
+// Wait when rate limited without a Retry-After header
+const defaultRetryAfter = time.Second
+
+type rateLimit struct {
+	count  int
+	window time.Duration
+}
+
+// Parses limits in the format of the X-App-Rate-Limit and X-Method-Rate-Limit headers, for example "20:1,100:120"
+// for 20 requests every second and 100 requests every 2 minutes
+func parseRateLimits(header string) []rateLimit {
+	limits := make([]rateLimit, 0)
+	for _, limit := range strings.Split(header, ",") {
+		parts := strings.Split(strings.TrimSpace(limit), ":")
+		if len(parts) != 2 {
+			continue
+		}
+		count, countErr := strconv.Atoi(parts[0])
+		seconds, secondsErr := strconv.Atoi(parts[1])
+		if countErr != nil || secondsErr != nil || count < 1 || seconds < 1 {
+			continue
+		}
+		limits = append(limits, rateLimit{count: count, window: time.Duration(seconds) * time.Second})
+	}
+	return limits
+}
+
+// The requests made within the longest window of a set of limits
+type rateLimitBucket struct {
+	limits       []rateLimit
+	requests     []time.Time
+	blockedUntil time.Time
+}
+
+// Returns how long to wait until another request is within every limit of the bucket
+func (b *rateLimitBucket) delay(now time.Time) time.Duration {
+	var delay time.Duration
+	if b.blockedUntil.After(now) {
+		delay = b.blockedUntil.Sub(now)
+	}
+
+	var longestWindow time.Duration
+	for _, limit := range b.limits {
+		if limit.window > longestWindow {
+			longestWindow = limit.window
+		}
+		// The request that has to leave the window before another request can be made
+		if i := len(b.requests) - limit.count; i >= 0 {
+			if wait := b.requests[i].Add(limit.window).Sub(now); wait > delay {
+				delay = wait
+			}
+		}
+	}
+
+	expired := 0
+	for expired < len(b.requests) && !b.requests[expired].Add(longestWindow).After(now) {
+		expired++
+	}
+	b.requests = b.requests[expired:]
+	return delay
+}
+
+// Keeps requests within the rate limits of the application, which apply to every request, and the rate limits of
+// each method of the Riot API. Limits are updated from the headers of responses, and requests are held back for the
+// Retry-After period once a limit has been exceeded
+type rateLimiter struct {
+	lock    sync.Mutex
+	app     *rateLimitBucket
+	methods map[string]*rateLimitBucket
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		app:     &rateLimitBucket{limits: parseRateLimits(defaultAppRateLimit)},
+		methods: make(map[string]*rateLimitBucket),
+	}
+}
+
+func (r *rateLimiter) method(method string) *rateLimitBucket {
+	bucket, ok := r.methods[method]
+	if !ok {
+		bucket = &rateLimitBucket{}
+		r.methods[method] = bucket
+	}
+	return bucket
+}
+
+// Blocks until a request to method is within the rate limits, and counts the request
+func (r *rateLimiter) wait(method string) {
+	for {
+		r.lock.Lock()
+		now := time.Now()
+		bucket := r.method(method)
+		delay := r.app.delay(now)
+		if methodDelay := bucket.delay(now); methodDelay > delay {
+			delay = methodDelay
+		}
+		if delay <= 0 {
+			r.app.requests = append(r.app.requests, now)
+			bucket.requests = append(bucket.requests, now)
+			r.lock.Unlock()
+			return
+		}
+		r.lock.Unlock()
+		time.Sleep(delay)
+	}
+}
+
+// Updates the limits of the application and the method to the ones reported in the response
+func (r *rateLimiter) update(method string, header http.Header) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if limits := parseRateLimits(header.Get("X-App-Rate-Limit")); len(limits) > 0 {
+		r.app.limits = limits
+	}
+	if limits := parseRateLimits(header.Get("X-Method-Rate-Limit")); len(limits) > 0 {
+		r.method(method).limits = limits
+	}
+}
+
+// Holds back requests after a 429 response for its Retry-After period. Only the application limit holds back
+// requests to every method; method and service limits only hold back requests to the same method
+func (r *rateLimiter) backOff(method string, header http.Header) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	bucket := r.method(method)
+	if header.Get("X-Rate-Limit-Type") == "application" {
+		bucket = r.app
+	}
+	if until := time.Now().Add(retryAfter(header)); until.After(bucket.blockedUntil) {
+		bucket.blockedUntil = until
+	}
+}
+
+func retryAfter(header http.Header) time.Duration {
+	seconds, err := strconv.Atoi(header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return defaultRetryAfter
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,334 @@
+package lolApi
+
+import (
+	"Server/dataModel"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const (
+	soloQueue = "RANKED_SOLO_5x5"
+	flexQueue = "RANKED_FLEX_SR"
+)
+
+// Summoners looked up at the same time, each of which makes two requests to the Riot API
+const maxSummonerLookups = 5
+
+var tiers = map[string]int{
+	"IRON":        1,
+	"BRONZE":      2,
+	"SILVER":      3,
+	"GOLD":        4,
+	"PLATINUM":    5,
+	"DIAMOND":     6,
+	"MASTER":      7,
+	"GRANDMASTER": 8,
+	"CHALLENGER":  9,
+}
+
+var divisions = map[string]int{
+	"IV":  1,
+	"III": 2,
+	"II":  3,
+	"I":   4,
+}
+
+func isHigherRank(entry, other *leagueEntryDto) bool {
+	if tiers[entry.Tier] != tiers[other.Tier] {
+		return tiers[entry.Tier] > tiers[other.Tier]
+	}
+	return divisions[entry.Rank] > divisions[other.Rank]
+}
+
+func (l *lolApi) getSummoner(id string) (*summonerDto, error) {
+	var summoner summonerDto
+	if err := l.get("summoner-v4.getBySummonerId", l.apiUrl+"/lol/summoner/v4/summoners/"+url.PathEscape(id), &summoner); err != nil {
+		return nil, err
+	}
+	return &summoner, nil
+}
+
+// The rank shown for a summoner is the higher of their solo and flex queue ranks, if they have either
+func (l *lolApi) getSummonerInformation(id string) (*SummonerInformation, error) {
+	summoner, err := l.getSummoner(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []leagueEntryDto
+	if err := l.get("league-v4.getLeagueEntriesForSummoner",
+		l.apiUrl+"/lol/league/v4/entries/by-summoner/"+url.PathEscape(id), &entries); err != nil {
+		return nil, err
+	}
+
+	var best *leagueEntryDto
+	for i, entry := range entries {
+		if entry.QueueType != soloQueue && entry.QueueType != flexQueue {
+			continue
+		}
+		if best == nil || isHigherRank(&entries[i], best) {
+			best = &entries[i]
+		}
+	}
+
+	information := &SummonerInformation{GameIdentifier: summoner.Name}
+	if best != nil {
+		information.Rank = best.Rank
+		information.Tier = best.Tier
+	}
+	return information, nil
+}
+
+// Summoners that are not cached or are older than the ttl of the cache are looked up concurrently by up to
+// maxSummonerLookups workers. Summoners that can not be looked up are shown from the cache and marked stale, or
+// have empty information if they were never cached
+func (l *lolApi) GetSummonerInformation(ids []string) map[string]*SummonerInformation {
+	cached := l.summoners.get(ids)
+	summonerInformation := make(map[string]*SummonerInformation)
+	toLookUp := make(chan string, len(ids))
+	lookups := 0
+	for _, id := range ids {
+		if _, ok := summonerInformation[id]; ok {
+			continue
+		}
+		if summoner, ok := cached[id]; ok && l.summoners.isFresh(summoner) {
+			summonerInformation[id] = cachedSummonerInformation(summoner, false)
+		} else {
+			summonerInformation[id] = nil
+			toLookUp <- id
+			lookups++
+		}
+	}
+	close(toLookUp)
+
+	type result struct {
+		id          string
+		information *SummonerInformation
+	}
+	results := make(chan result, lookups)
+	workers := lookups
+	if workers > maxSummonerLookups {
+		workers = maxSummonerLookups
+	}
+	for i := 0; i < workers; i++ {
+		go func() {
+			for id := range toLookUp {
+				information, err := l.getSummonerInformation(id)
+				if err == nil {
+					l.summoners.set(id, information)
+				} else if summoner, ok := cached[id]; ok {
+					fmt.Printf("showing cached summoner information of %v: %v\n", id, err)
+					information = cachedSummonerInformation(summoner, true)
+				} else {
+					fmt.Printf("failed to get summoner information of %v: %v\n", id, err)
+					information = &SummonerInformation{}
+				}
+				results <- result{id, information}
+			}
+		}()
+	}
+
+	for i := 0; i < lookups; i++ {
+		res := <-results
+		summonerInformation[res.id] = res.information
+	}
+	return summonerInformation
+}
+
+func cachedSummonerInformation(summoner *dataModel.LoLCachedSummoner, stale bool) *SummonerInformation {
+	return &SummonerInformation{
+		GameIdentifier: summoner.GameIdentifier,
+		Rank:           summoner.Rank,
+		Tier:           summoner.Tier,
+		Stale:          stale,
+	}
+}
+
+func (l *lolApi) CompletePlayerStubs(stub *dataModel.LoLTeamStub) (*dataModel.LoLTeamWithRosters, error) {
+	team := dataModel.LoLTeamWithRosters{
+		TeamId:           stub.TeamId,
+		Name:             stub.Name,
+		Description:      stub.Description,
+		Tag:              stub.Tag,
+		IconSmall:        stub.IconSmall,
+		IconLarge:        stub.IconLarge,
+		Wins:             stub.Wins,
+		Losses:           stub.Losses,
+		MainRoster:       make([]*dataModel.LoLPlayer, 0),
+		SubstituteRoster: make([]*dataModel.LoLPlayer, 0),
+	}
+
+	allPlayerIds := make([]string, 0)
+	for _, player := range append(stub.MainRoster, stub.SubstituteRoster...) {
+		allPlayerIds = append(allPlayerIds, player.ExternalId)
+	}
+
+	summonerMap := l.GetSummonerInformation(allPlayerIds)
+	for _, player := range stub.MainRoster {
+		info := summonerMap[player.ExternalId]
+		team.MainRoster = append(team.MainRoster, &dataModel.LoLPlayer{
+			PlayerId:       player.PlayerId,
+			GameIdentifier: info.GameIdentifier,
+			MainRoster:     player.MainRoster,
+			Position:       player.Position,
+			Rank:           info.Rank,
+			Tier:           info.Tier,
+			Stale:          info.Stale,
+		})
+	}
+	for _, player := range stub.SubstituteRoster {
+		info := summonerMap[player.ExternalId]
+		team.SubstituteRoster = append(team.SubstituteRoster, &dataModel.LoLPlayer{
+			PlayerId:       player.PlayerId,
+			GameIdentifier: info.GameIdentifier,
+			MainRoster:     player.MainRoster,
+			Position:       player.Position,
+			Rank:           info.Rank,
+			Tier:           info.Tier,
+			Stale:          info.Stale,
+		})
+	}
+	return &team, nil
+}
+
+func (l *lolApi) GetSummonerId(name string) (string, error) {
+	var summoner summonerDto
+	if err := l.get("summoner-v4.getBySummonerName", l.apiUrl+"/lol/summoner/v4/summoners/by-name/"+url.PathEscape(name), &summoner); err != nil {
+		return "", err
+	}
+	return summoner.Id, nil
+}
+
+// Champion names by champion id from the latest version of Data Dragon, loaded once
+func (l *lolApi) getChampionNames() (map[int]string, error) {
+	l.championsLock.Lock()
+	defer l.championsLock.Unlock()
+	if l.champions != nil {
+		return l.champions, nil
+	}
+
+	var versions []string
+	if err := l.getStatic(l.dataDragonUrl+"/api/versions.json", &versions); err != nil {
+		return nil, err
+	} else if len(versions) == 0 {
+		return nil, fmt.Errorf("no versions in data dragon")
+	}
+
+	var championList championListDto
+	if err := l.getStatic(fmt.Sprintf("%v/cdn/%v/data/en_US/champion.json", l.dataDragonUrl, versions[0]),
+		&championList); err != nil {
+		return nil, err
+	}
+
+	champions := make(map[int]string)
+	for _, champion := range championList.Data {
+		championId, err := strconv.Atoi(champion.Key)
+		if err != nil {
+			return nil, err
+		}
+		champions[championId] = champion.Name
+	}
+	l.champions = champions
+	return champions, nil
+}
+
+func (l *lolApi) GetMatchStats(id string) (*dataModel.LoLMatchInformation, error) {
+	var match matchDto
+	if err := l.get("match-v4.getMatch", l.apiUrl+"/lol/match/v4/matches/"+url.PathEscape(id), &match); err != nil {
+		return nil, err
+	}
+	champions, err := l.getChampionNames()
+	if err != nil {
+		return nil, err
+	}
+
+	matchInfo := dataModel.LoLMatchInformation{
+		MatchId:                id,
+		Duration:               float64(match.GameDuration),
+		Timestamp:              int(match.GameCreation / 1000),
+		BannedChampions:        make([]string, 0),
+		WinningChampions:       make([]string, 0),
+		LosingChampions:        make([]string, 0),
+		WinningTeamSummonerIds: make([]string, 0),
+		LosingTeamSummonerIds:  make([]string, 0),
+		PlayerStats:            make([]dataModel.LoLMatchPlayerStats, 0),
+	}
+
+	for _, team := range match.Teams {
+		teamStats := dataModel.LoLMatchTeamStats{
+			FirstBlood: team.FirstBlood,
+			FirstTower: team.FirstTower,
+			Side:       team.TeamId,
+		}
+		if team.Win == "Win" {
+			matchInfo.WinningTeamStats = teamStats
+		} else {
+			matchInfo.LosingTeamStats = teamStats
+		}
+
+		// Bans that were skipped have a champion id of -1
+		for _, ban := range team.Bans {
+			if name, ok := champions[ban.ChampionId]; ok {
+				matchInfo.BannedChampions = append(matchInfo.BannedChampions, name)
+			}
+		}
+	}
+
+	players := make(map[int]playerDto)
+	for _, identity := range match.ParticipantIdentities {
+		players[identity.ParticipantId] = identity.Player
+	}
+	for _, participant := range match.Participants {
+		player := players[participant.ParticipantId]
+		champion := champions[participant.ChampionId]
+		if participant.Stats.Win {
+			matchInfo.WinningChampions = append(matchInfo.WinningChampions, champion)
+			matchInfo.WinningTeamSummonerIds = append(matchInfo.WinningTeamSummonerIds, player.SummonerId)
+		} else {
+			matchInfo.LosingChampions = append(matchInfo.LosingChampions, champion)
+			matchInfo.LosingTeamSummonerIds = append(matchInfo.LosingTeamSummonerIds, player.SummonerId)
+		}
+
+		matchInfo.PlayerStats = append(matchInfo.PlayerStats, dataModel.LoLMatchPlayerStats{
+			Id:             player.SummonerId,
+			Name:           player.SummonerName,
+			ChampionPicked: champion,
+			Gold:           float64(participant.Stats.GoldEarned),
+			Cs:             float64(participant.Stats.TotalMinionsKilled),
+			Damage:         float64(participant.Stats.TotalDamageDealtToChampions),
+			Kills:          float64(participant.Stats.Kills),
+			Deaths:         float64(participant.Stats.Deaths),
+			Assists:        float64(participant.Stats.Assists),
+			Wards:          float64(participant.Stats.WardsPlaced),
+			Win:            participant.Stats.Win,
+		})
+	}
+
+	return &matchInfo, nil
+}
+
+// Combines the statistics of a finished tournament game with the teams in the metadata of its tournament code.
+// The winner is the team whose reference player was on the winning side
+func GetTournamentMatchInformation(api LoLApi, callback *TournamentCallback) (*dataModel.LoLMatchInformation, error) {
+	metadata, err := callback.GameMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	matchInfo, err := api.GetMatchStats(callback.MatchId())
+	if err != nil {
+		return nil, err
+	}
+
+	matchInfo.GameId = metadata.GameId
+	matchInfo.Team1Id = metadata.Team1Id
+	matchInfo.Team2Id = metadata.Team2Id
+	matchInfo.WinningTeamId, matchInfo.LosingTeamId = metadata.Team2Id, metadata.Team1Id
+	for _, summonerId := range matchInfo.WinningTeamSummonerIds {
+		if summonerId == metadata.Team1RefPlayerId {
+			matchInfo.WinningTeamId, matchInfo.LosingTeamId = metadata.Team1Id, metadata.Team2Id
+		}
+	}
+	return matchInfo, nil
+}
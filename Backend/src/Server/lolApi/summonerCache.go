@@ -0,0 +1,93 @@
+package lolApi
+
+import (
+	"Server/dataModel"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Keeps summoners beyond the lifetime of the process, implemented by the League of Legends DAO
+type SummonerStore interface {
+	GetCachedSummoners(externalIds []string) ([]*dataModel.LoLCachedSummoner, error)
+	CacheSummoner(summoner *dataModel.LoLCachedSummoner) error
+}
+
+// Caches the information of summoners in memory, and in the store if there is one. Summoners older than the ttl are
+// fetched from the Riot API again, but are still kept to be shown while it is unavailable
+type SummonerCache struct {
+	ttl   time.Duration
+	store SummonerStore
+
+	lock      sync.RWMutex
+	summoners map[string]*dataModel.LoLCachedSummoner
+}
+
+func NewSummonerCache(ttl time.Duration, store SummonerStore) *SummonerCache {
+	return &SummonerCache{
+		ttl:       ttl,
+		store:     store,
+		summoners: make(map[string]*dataModel.LoLCachedSummoner),
+	}
+}
+
+func (c *SummonerCache) isFresh(summoner *dataModel.LoLCachedSummoner) bool {
+	return time.Since(time.Unix(int64(summoner.Updated), 0)) < c.ttl
+}
+
+// Returns the cached summoners of ids that are cached, fresh or not. Summoners that are not fresh in memory are
+// looked up in the store, since another instance of the server may have fetched them since
+func (c *SummonerCache) get(ids []string) map[string]*dataModel.LoLCachedSummoner {
+	summoners := make(map[string]*dataModel.LoLCachedSummoner)
+	missing := make([]string, 0)
+
+	c.lock.RLock()
+	for _, id := range ids {
+		if summoner, ok := c.summoners[id]; ok {
+			summoners[id] = summoner
+		}
+		if summoners[id] == nil || !c.isFresh(summoners[id]) {
+			missing = append(missing, id)
+		}
+	}
+	c.lock.RUnlock()
+
+	if c.store == nil || len(missing) == 0 {
+		return summoners
+	}
+	stored, err := c.store.GetCachedSummoners(missing)
+	if err != nil {
+		fmt.Printf("failed to get cached summoners: %v\n", err)
+		return summoners
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, summoner := range stored {
+		if cached, ok := c.summoners[summoner.ExternalId]; !ok || summoner.Updated > cached.Updated {
+			c.summoners[summoner.ExternalId] = summoner
+		}
+		summoners[summoner.ExternalId] = c.summoners[summoner.ExternalId]
+	}
+	return summoners
+}
+
+func (c *SummonerCache) set(id string, information *SummonerInformation) {
+	summoner := &dataModel.LoLCachedSummoner{
+		ExternalId:     id,
+		GameIdentifier: information.GameIdentifier,
+		Rank:           information.Rank,
+		Tier:           information.Tier,
+		Updated:        int(time.Now().Unix()),
+	}
+
+	c.lock.Lock()
+	c.summoners[id] = summoner
+	c.lock.Unlock()
+
+	if c.store != nil {
+		if err := c.store.CacheSummoner(summoner); err != nil {
+			fmt.Printf("failed to cache summoner %v: %v\n", id, err)
+		}
+	}
+}
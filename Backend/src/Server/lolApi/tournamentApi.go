@@ -1,52 +1,26 @@
 package lolApi
 
 import (
-	"bytes"
+	"Server/dataModel"
+	"fmt"
 	"github.com/imroc/req"
 	"github.com/pkg/errors"
 	"net/http"
 	"strconv"
 )
 
-func (api *NativeLoLTournamentApi) callTournamentServer(url string, body *bytes.Buffer) ([]byte, error) {
-
-	//httpReq, err := http.NewRequest(
-	//	"GET",
-	//	fmt.Sprintf("https://americas.api.riotgames.com/lol/tournament-stub/v4/"+url),
-	//	nil)
-	//httpReq.Header.Set("X-Riot-Token", api.apiKey)
-	//if body != nil {
-	//	httpReq.Header.Set("Content-Type", "application/json")
-	//}
-
-	//
-	//res, err := api.client.Do(httpReq)
-	//if err != nil {
-	//	fmt.Println("Failed to execute HTTP Request. Error:", err.Error())
-	//	return nil, err
-	//}
-	//
-	//if res.StatusCode != http.StatusOK {
-	//	fmt.Println(fmt.Sprintf("Non 200 Status Code: %v %v", res.StatusCode, res.Status))
-	//	return nil, err
-	//}
-	//
-	//bodyBytes, err := ioutil.ReadAll(res.Body)
-	//if err != nil {
-	//	fmt.Println("Failed to read body. Error:", err.Error())
-	//	return nil, err
-	//}
-
-	return nil, nil
+// The tournament API is served by the regional host of the platform the tournament is registered on
+func getTournamentApiUrl(region, path string) string {
+	return fmt.Sprintf("https://%v.api.riotgames.com/lol/tournament-stub/v4/%v", dataModel.LoLRegions[region], path)
 }
 
 func (api *NativeLoLTournamentApi) RegisterTournament(leagueId int, region, tournamentName string) (providerId int, tournamentId int, err error) {
 	res, err := api.r.Post(
-		"https://americas.api.riotgames.com/lol/tournament-stub/v4/providers",
+		getTournamentApiUrl(region, "providers"),
 		req.Header{"X-Riot-Token": api.apiKey},
 		req.BodyJSON(map[string]string{
 			"region": region,
-			"url":    "http://callbackGoesHere",
+			"url":    api.callbackUrl,
 		}))
 	if err != nil {
 		return 0, 0, err
@@ -64,7 +38,7 @@ func (api *NativeLoLTournamentApi) RegisterTournament(leagueId int, region, tour
 	}
 
 	res, err = api.r.Post(
-		"https://americas.api.riotgames.com/lol/tournament-stub/v4/tournaments",
+		getTournamentApiUrl(region, "tournaments"),
 		req.Header{"X-Riot-Token": api.apiKey},
 		req.BodyJSON(map[string]interface{}{
 			"name":       tournamentName,
@@ -88,18 +62,13 @@ func (api *NativeLoLTournamentApi) RegisterTournament(leagueId int, region, tour
 	return providerId, tournamentId, nil
 }
 
-func (api *NativeLoLTournamentApi) CreateTournamentKey(tournamentId int, metadata string) (string, error) {
+func (api *NativeLoLTournamentApi) CreateTournamentKey(region string, tournamentId int,
+	parameters *TournamentCodeParameters) (string, error) {
 	res, err := api.r.Post(
-		"https://americas.api.riotgames.com/lol/tournament-stub/v4/codes",
+		getTournamentApiUrl(region, "codes"),
 		req.Header{"X-Riot-Token": api.apiKey},
 		req.Param{"tournamentId": tournamentId},
-		req.BodyJSON(map[string]interface{}{
-			"mapType":       "SUMMONERS_RIFT",
-			"metadata":      metadata,
-			"pickType":      "TOURNAMENT_DRAFT",
-			"spectatorType": "LOBBYONLY",
-			"teamSize":      5,
-		}))
+		req.BodyJSON(parameters))
 	if err != nil {
 		return "", err
 	} else if res.Response().StatusCode != http.StatusOK {
@@ -109,18 +78,9 @@ func (api *NativeLoLTournamentApi) CreateTournamentKey(tournamentId int, metadat
 	var tournamentStringArray []string
 	if err = res.ToJSON(&tournamentStringArray); err != nil {
 		return "", err
+	} else if len(tournamentStringArray) == 0 {
+		return "", errors.New("no tournament code was created")
 	}
 
 	return tournamentStringArray[0], nil
 }
-
-func (api *NativeLoLTournamentApi) ForwardCompleteTournamentGame(body []byte) error {
-	res, err := req.Post("http://localhost:8090/tournamentCallback", req.BodyJSON(body))
-	if err != nil {
-		return err
-	} else if res.Response().StatusCode != 200 {
-		return errors.New(res.Response().Status)
-	} else {
-		return nil
-	}
-}
@@ -26,6 +26,7 @@ var MarkdownManager markdown.MdManager
 
 var LoLApi lolApi.LoLApi
 var LoLTournamentApi lolApi.LoLTournamentApi
+var TournamentCallbackSecret string
 
 // context helpers
 func getLeagueId(ctx *gin.Context) int {
@@ -55,6 +56,30 @@ func getAvailabilityId(ctx *gin.Context) int {
 	return ctx.GetInt("availabilityId")
 }
 
+func getBlackoutId(ctx *gin.Context) int {
+	return ctx.GetInt("blackoutId")
+}
+
+func getInviteId(ctx *gin.Context) int {
+	return ctx.GetInt("inviteId")
+}
+
+func getRequestId(ctx *gin.Context) int {
+	return ctx.GetInt("requestId")
+}
+
+func getRosterChangeId(ctx *gin.Context) int {
+	return ctx.GetInt("rosterChangeId")
+}
+
+func getSeasonId(ctx *gin.Context) int {
+	return ctx.GetInt("seasonId")
+}
+
+func getDivisionId(ctx *gin.Context) int {
+	return ctx.GetInt("divisionId")
+}
+
 func getExternalId(ctx *gin.Context) string {
 	return ctx.GetString("externalId")
 }
@@ -75,13 +100,19 @@ func getExternalId(ctx *gin.Context) string {
 type Entity int
 
 const (
-	User         Entity = iota
-	League       Entity = iota
-	Team         Entity = iota
-	Player       Entity = iota
-	Game         Entity = iota
-	Report       Entity = iota
-	Availability Entity = iota
+	User          Entity = iota
+	League        Entity = iota
+	Team          Entity = iota
+	Player        Entity = iota
+	Game          Entity = iota
+	Report        Entity = iota
+	Availability  Entity = iota
+	Submission    Entity = iota
+	Invite        Entity = iota
+	RosterRequest Entity = iota
+	RosterInvite  Entity = iota
+	Season        Entity = iota
+	Division      Entity = iota
 )
 
 // Re-declare so that don't have to use the package prefix to make it look nicer
@@ -134,11 +165,44 @@ func HasPermissions(ctx *gin.Context, entity Entity, accessType validation.Acces
 			entityId = getGameId(ctx)
 		}
 		hasPermissions, err = Access.Game(accessType, permissions, GameDAO, leagueId, entityId)
+	case Report:
+		entityId = getGameId(ctx)
+		hasPermissions, err = Access.Report(accessType, permissions, GameDAO, leagueId, entityId)
+	case Submission:
+		if accessType != View {
+			entityId = getGameId(ctx)
+		}
+		hasPermissions, err = Access.Submission(accessType, permissions, GameDAO, leagueId, entityId)
 	case Availability:
 		if accessType != Create {
 			entityId = getAvailabilityId(ctx)
 		}
 		hasPermissions, err = Access.Availability(accessType, permissions, LeagueDAO, leagueId, entityId)
+	case Invite:
+		if accessType != Create {
+			entityId = getInviteId(ctx)
+		}
+		hasPermissions, err = Access.Invite(accessType, permissions, LeagueDAO, leagueId, entityId)
+	case RosterRequest:
+		if accessType != Create && accessType != View {
+			entityId = getRequestId(ctx)
+		}
+		hasPermissions, err = Access.RosterRequest(accessType, permissions, TeamDAO, LeagueDAO, leagueId, getTeamId(ctx), entityId)
+	case RosterInvite:
+		if accessType != View {
+			entityId = getRequestId(ctx)
+		}
+		hasPermissions, err = Access.RosterInvite(accessType, permissions, TeamDAO, entityId)
+	case Season:
+		if accessType != Create {
+			entityId = getSeasonId(ctx)
+		}
+		hasPermissions, err = Access.Season(accessType, permissions, LeagueDAO, leagueId, entityId)
+	case Division:
+		if accessType != Create {
+			entityId = getDivisionId(ctx)
+		}
+		hasPermissions, err = Access.Division(accessType, permissions, LeagueDAO, leagueId, entityId)
 	}
 
 	if err != nil {
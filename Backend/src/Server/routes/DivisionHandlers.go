@@ -0,0 +1,105 @@
+package routes
+
+import (
+	"Server/dataModel"
+	"github.com/gin-gonic/gin"
+)
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/createDivision
+func createDivision() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var division dataModel.DivisionCore
+		endpoint{
+			Entity:     Division,
+			AccessType: Create,
+			BindData:   func(ctx *gin.Context) bool { return bindAndCheckErr(ctx, &division) },
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				return division.ValidateNew(getLeagueId(ctx), LeagueDAO)
+			},
+			Core: func(ctx *gin.Context) (interface{}, error) {
+				divisionId, err := LeagueDAO.CreateDivision(getLeagueId(ctx), division)
+				return gin.H{"divisionId": divisionId}, err
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getDivisions
+func getDivisions() gin.HandlerFunc {
+	return endpoint{
+		Entity:     League,
+		AccessType: View,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return LeagueDAO.GetDivisions(getLeagueId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getDivision
+func getDivision() gin.HandlerFunc {
+	return endpoint{
+		Entity:     Division,
+		AccessType: View,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return LeagueDAO.GetDivision(getDivisionId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/updateDivision
+func updateDivision() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var division dataModel.DivisionCore
+		endpoint{
+			Entity:     Division,
+			AccessType: Edit,
+			BindData:   func(ctx *gin.Context) bool { return bindAndCheckErr(ctx, &division) },
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				return division.ValidateEdit(getLeagueId(ctx), getDivisionId(ctx), LeagueDAO)
+			},
+			Core: func(ctx *gin.Context) (interface{}, error) {
+				return nil, LeagueDAO.UpdateDivision(getDivisionId(ctx), division)
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/deleteDivision
+func deleteDivision() gin.HandlerFunc {
+	return endpoint{
+		Entity:     Division,
+		AccessType: Delete,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return nil, LeagueDAO.DeleteDivision(getDivisionId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/setDivisionTeams
+func setDivisionTeams() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var teams dataModel.DivisionTeams
+		endpoint{
+			Entity:     Division,
+			AccessType: Edit,
+			BindData:   func(ctx *gin.Context) bool { return bindAndCheckErr(ctx, &teams) },
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				return teams.Validate(getLeagueId(ctx), TeamDAO)
+			},
+			Core: func(ctx *gin.Context) (interface{}, error) {
+				return nil, LeagueDAO.SetDivisionTeams(getLeagueId(ctx), getDivisionId(ctx), teams)
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+func RegisterDivisionHandlers(g *gin.RouterGroup) {
+	g.POST("", createDivision())
+	g.GET("", getDivisions())
+
+	withDivisionId := g.Group("/:divisionId", storeDivisionId())
+	withDivisionId.GET("", getDivision())
+	withDivisionId.PUT("", updateDivision())
+	withDivisionId.DELETE("", deleteDivision())
+	withDivisionId.PUT("/teams", setDivisionTeams())
+}
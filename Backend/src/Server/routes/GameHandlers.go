@@ -2,15 +2,17 @@ package routes
 
 import (
 	"Server/dataModel"
+	"Server/validation"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 // https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getLeagueGames
 func getAllGamesInLeague(ctx *gin.Context) {
-	games, err := GameDAO.GetAllGamesInLeague(getLeagueId(ctx))
+	games, err := GameDAO.GetAllGamesInSeason(getSeasonId(ctx))
 	if checkErr(ctx, err) {
 		return
 	}
@@ -33,7 +35,7 @@ func getSortedGames() gin.HandlerFunc {
 			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "limitMustBeInteger"})
 		}
 
-		games, err := GameDAO.GetSortedGames(getLeagueId(ctx), teamId, limit)
+		games, err := GameDAO.GetSortedGames(getSeasonId(ctx), teamId, limit)
 		if checkErr(ctx, err) {
 			return
 		}
@@ -44,13 +46,20 @@ func getSortedGames() gin.HandlerFunc {
 
 func getGamesByWeek() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		timeZoneOffsetString := ctx.DefaultQuery("timeZoneOffset", "0")
-		timeZoneOffset, err := strconv.Atoi(timeZoneOffsetString)
-		fmt.Printf("timezone offset is %v", timeZoneOffset)
-		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "IdMustBeInteger"})
+		// Weeks are in the time zone of the league unless another IANA time zone is requested
+		leagueInformation, err := LeagueDAO.GetLeagueInformation(getLeagueId(ctx))
+		if checkErr(ctx, err) {
+			return
+		}
+		location, valid, err := leagueInformation.ViewerLocation(ctx.Query("timeZone"))
+		if checkErr(ctx, err) {
+			return
+		} else if !valid {
+			ctx.JSON(http.StatusBadRequest, gin.H{"errorDescription": dataModel.InvalidTimeZone})
+			return
 		}
-		games, err := GameDAO.GetGamesByWeek(getLeagueId(ctx), timeZoneOffset)
+
+		games, err := GameDAO.GetGamesByWeek(getSeasonId(ctx), getDivisionId(ctx), location)
 		if checkErr(ctx, err) {
 			return
 		}
@@ -68,6 +77,15 @@ func getGameInformation() gin.HandlerFunc {
 	}.createEndpointHandler()
 }
 
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getSeriesGames
+func getSeriesGames() gin.HandlerFunc {
+	return endpoint{
+		Entity:     Game,
+		AccessType: View,
+		Core:       func(ctx *gin.Context) (interface{}, error) { return GameDAO.GetSeriesGames(getGameId(ctx)) },
+	}.createEndpointHandler()
+}
+
 // https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/createGame
 func createNewGame() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -117,27 +135,167 @@ func rescheduleGame() gin.HandlerFunc {
 	}
 }
 
+func getReportingTeamId(ctx *gin.Context) (int, error) {
+	permissions, err := UserDAO.GetUserWithPermissions(getLeagueId(ctx), getUserId(ctx))
+	if err != nil {
+		return 0, err
+	}
+	game, err := GameDAO.GetGameInformation(getGameId(ctx))
+	if err != nil {
+		return 0, err
+	}
+	return validation.ReportingTeamId(permissions, game), nil
+}
+
 // https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/reportGame
 func reportGameResult() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var gameResult dataModel.GameResult
+		var reportingTeamId int
 		endpoint{
-			Entity:        Game,
+			Entity:     Game,
+			AccessType: Edit,
+			BindData: func(ctx *gin.Context) bool {
+				if bindAndCheckErr(ctx, &gameResult) {
+					return true
+				}
+				var err error
+				reportingTeamId, err = getReportingTeamId(ctx)
+				return checkErr(ctx, err)
+			},
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				if reportingTeamId == 0 {
+					return gameResult.Validate(getGameId(ctx), GameDAO)
+				} else {
+					return gameResult.ValidateSubmission(getGameId(ctx), reportingTeamId, GameDAO)
+				}
+			},
+			Core: func(ctx *gin.Context) (interface{}, error) {
+				if reportingTeamId == 0 {
+					if err := GameDAO.ReportGame(getGameId(ctx), gameResult); err != nil {
+						return nil, err
+					}
+					progressToPlayoffs(getLeagueId(ctx))
+					return gin.H{"confirmed": true}, nil
+				} else {
+					return gin.H{"confirmed": false},
+						GameDAO.SubmitGameResult(getGameId(ctx), reportingTeamId, getUserId(ctx), gameResult)
+				}
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getResultSubmission
+func getResultSubmission() gin.HandlerFunc {
+	return endpoint{
+		Entity:     Report,
+		AccessType: View,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return GameDAO.GetResultSubmission(getGameId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/confirmGameResult
+func confirmGameResult() gin.HandlerFunc {
+	return endpoint{
+		Entity:     Submission,
+		AccessType: Edit,
+		IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+			return dataModel.ValidateResultConfirmation(getGameId(ctx), GameDAO)
+		},
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			if err := GameDAO.ConfirmResultSubmission(getGameId(ctx)); err != nil {
+				return nil, err
+			}
+			progressToPlayoffs(getLeagueId(ctx))
+			return nil, nil
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/disputeGameResult
+func disputeGameResult() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var dispute dataModel.ResultDispute
+		endpoint{
+			Entity:        Submission,
+			AccessType:    Edit,
+			BindData:      func(ctx *gin.Context) bool { return bindAndCheckErr(ctx, &dispute) },
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) { return dispute.Validate(getGameId(ctx), GameDAO) },
+			Core: func(ctx *gin.Context) (interface{}, error) {
+				return nil, GameDAO.DisputeResultSubmission(getGameId(ctx), dispute.Reason)
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getDisputedResults
+func getDisputedResults() gin.HandlerFunc {
+	return endpoint{
+		Entity:     Submission,
+		AccessType: View,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return GameDAO.GetDisputedResultSubmissions(getLeagueId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// Confirms the submitted results that the opposing team did not respond to within the league confirmation window,
+// then creates the playoff stages of the group stages those results completed
+func confirmExpiredResults(interval time.Duration) {
+	for range time.Tick(interval) {
+		if _, err := GameDAO.ConfirmExpiredResultSubmissions(); err != nil {
+			fmt.Printf("failed to confirm expired results: %v\n", err)
+			continue
+		}
+
+		leagueIds, err := LeagueDAO.GetLeaguesAwaitingPlayoffs()
+		if err != nil {
+			fmt.Printf("failed to get leagues awaiting playoffs: %v\n", err)
+			continue
+		}
+		for _, leagueId := range leagueIds {
+			progressToPlayoffs(leagueId)
+		}
+	}
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/amendGameResult
+func amendGameResult() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var amendment dataModel.GameResultAmendment
+		endpoint{
+			Entity:        Report,
 			AccessType:    Edit,
-			BindData:      func(ctx *gin.Context) bool { return bindAndCheckErr(ctx, &gameResult) },
-			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) { return gameResult.Validate(getGameId(ctx), GameDAO) },
+			BindData:      func(ctx *gin.Context) bool { return bindAndCheckErr(ctx, &amendment) },
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) { return amendment.Validate(getGameId(ctx), GameDAO) },
 			Core: func(ctx *gin.Context) (interface{}, error) {
-				return nil, GameDAO.ReportGame(getGameId(ctx), gameResult)
+				amendmentId, err := GameDAO.AmendGameResult(getGameId(ctx), getUserId(ctx), amendment)
+				return gin.H{"amendmentId": amendmentId}, err
 			},
 		}.createEndpointHandler()(ctx)
 	}
 }
 
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getResultAmendments
+func getResultAmendments() gin.HandlerFunc {
+	return endpoint{
+		Entity:     Report,
+		AccessType: View,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return GameDAO.GetResultAmendments(getGameId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
 func RegisterGameHandlers(g *gin.RouterGroup) {
-	g.GET("/api/v1/sortedGames", getSortedGames())
-	g.GET("/api/v1/gamesByWeek", getGamesByWeek())
+	g.GET("/api/v1/sortedGames", storeSelectedSeasonId(), getSortedGames())
+	g.GET("/api/v1/gamesByWeek", storeSelectedSeasonId(), storeSelectedDivisionId(), getGamesByWeek())
+	g.GET("/api/v1/disputedResults", getDisputedResults())
 	games := g.Group("/api/v1/games")
-	games.GET("", getAllGamesInLeague)
+	games.GET("", storeSelectedSeasonId(), getAllGamesInLeague)
 	games.GET("/:gameId", storeGameId(), getGameInformation())
 	games.POST("", createNewGame())
 
@@ -145,4 +303,10 @@ func RegisterGameHandlers(g *gin.RouterGroup) {
 	withId.DELETE("", deleteGame())
 	withId.POST("/reschedule", rescheduleGame())
 	withId.POST("/report", reportGameResult())
+	withId.PUT("/report", amendGameResult())
+	withId.GET("/report/submission", getResultSubmission())
+	withId.POST("/report/confirm", confirmGameResult())
+	withId.POST("/report/dispute", disputeGameResult())
+	withId.GET("/amendments", getResultAmendments())
+	withId.GET("/series", getSeriesGames())
 }
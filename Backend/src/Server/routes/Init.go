@@ -10,6 +10,8 @@ import (
 	"Server/validation"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"log"
+	"time"
 )
 
 func InitRoutes(conf config.Config) *gin.Engine {
@@ -31,8 +33,17 @@ func InitRoutes(conf config.Config) *gin.Engine {
 	ElmSessions = sessionManager.CreateCookieSessionManager(conf)
 	IconManager = icons.CreateGoIconManager(conf)
 	MarkdownManager = markdown.CreateGoMarkdownManager(conf)
-	LoLApi = lolApi.GetLolApiWrapper()
-	LoLTournamentApi = lolApi.GetLoLTournamentApi(conf)
+	LoLApi = lolApi.GetLoLApi(conf, LeagueOfLegendsDAO)
+	tournamentApi, err := lolApi.GetLoLTournamentApi(conf)
+	if err != nil {
+		log.Fatal("error creating tournament api: ", err)
+	}
+	LoLTournamentApi = tournamentApi
+	TournamentCallbackSecret = conf.GetTournamentCallbackSecret()
+
+	go confirmExpiredResults(time.Minute)
+	go retryTournamentCallbacks(time.Minute)
+	go provisionTournamentCodes(time.Minute, conf.GetTournamentCodeLeadTime())
 
 	RegisterLoginHandlers(app.Group("/"))
 	RegisterUserHandlers(app.Group("/api/v1/users"))
@@ -40,6 +51,9 @@ func InitRoutes(conf config.Config) *gin.Engine {
 	RegisterTeamHandlers(app.Group("/api/v1"))
 	RegisterGameHandlers(app.Group(""))
 	RegisterSchedulingHandlers(app.Group("/api/v1"))
+	RegisterStandingsHandlers(app.Group("/api/v1/standings"))
+	RegisterSeasonHandlers(app.Group("/api/v1/seasons"))
+	RegisterDivisionHandlers(app.Group("/api/v1/divisions"))
 	//
 	RegisterLeagueOfLegendsHandlers(app.Group("/api/v1/lol"))
 
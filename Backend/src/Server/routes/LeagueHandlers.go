@@ -2,7 +2,9 @@ package routes
 
 import (
 	"Server/dataModel"
+	"encoding/hex"
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/securecookie"
 	"net/http"
 )
 
@@ -67,6 +69,35 @@ func setLeagueMarkdown() gin.HandlerFunc {
 	}
 }
 
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getRosterRules
+func getRosterRules() gin.HandlerFunc {
+	return endpoint{
+		Entity:     League,
+		AccessType: View,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return LeagueDAO.GetRosterRules(getLeagueId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/setRosterRules
+func setRosterRules() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var rules dataModel.RosterRules
+		endpoint{
+			Entity:     League,
+			AccessType: Edit,
+			BindData:   func(ctx *gin.Context) bool { return bindAndCheckErr(ctx, &rules) },
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				return rules.Validate()
+			},
+			Core: func(ctx *gin.Context) (interface{}, error) {
+				return nil, LeagueDAO.SetRosterRules(getLeagueId(ctx), rules)
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
 // https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/setActiveLeague
 func setActiveLeague() gin.HandlerFunc {
 	return endpoint{
@@ -108,6 +139,82 @@ func joinActiveLeague(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, nil)
 }
 
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/joinLeagueWithInvite
+func joinLeagueWithInvite() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var invite *dataModel.LeagueInvite
+		var user *dataModel.User
+		endpoint{
+			Entity:     User,
+			AccessType: View,
+			BindData: func(ctx *gin.Context) bool {
+				var err error
+				invite, err = LeagueDAO.GetInvite(ctx.Param("token"))
+				if checkErr(ctx, err) {
+					return true
+				} else if invite == nil {
+					ctx.Status(http.StatusNotFound)
+					return true
+				}
+				user, err = UserDAO.GetUserProfile(getUserId(ctx))
+				return checkErr(ctx, err)
+			},
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				return invite.ValidateUse(user.UserId, user.Email, LeagueDAO)
+			},
+			Core: func(ctx *gin.Context) (interface{}, error) {
+				if err := LeagueDAO.UseInvite(invite.InviteId, user.UserId); err != nil {
+					return nil, err
+				}
+				if err := ElmSessions.SetActiveLeague(ctx, invite.LeagueId); err != nil {
+					return nil, err
+				}
+				return LeagueDAO.GetLeagueInformation(invite.LeagueId)
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/createLeagueInvite
+func createLeagueInvite() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var invite dataModel.LeagueInviteCore
+		endpoint{
+			Entity:        Invite,
+			AccessType:    Create,
+			BindData:      func(ctx *gin.Context) bool { return bindAndCheckErr(ctx, &invite) },
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) { return invite.Validate() },
+			Core: func(ctx *gin.Context) (interface{}, error) {
+				token := hex.EncodeToString(securecookie.GenerateRandomKey(32))
+				inviteId, err := LeagueDAO.CreateInvite(getLeagueId(ctx), getUserId(ctx), token, invite)
+				return gin.H{"inviteId": inviteId, "token": token}, err
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getLeagueInvites
+func getLeagueInvites() gin.HandlerFunc {
+	return endpoint{
+		Entity:     Invite,
+		AccessType: View,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return LeagueDAO.GetInvites(getLeagueId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/revokeLeagueInvite
+func revokeLeagueInvite() gin.HandlerFunc {
+	return endpoint{
+		Entity:     Invite,
+		AccessType: Delete,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return nil, LeagueDAO.RevokeInvite(getInviteId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
 // https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getLeagueTeamManagers
 func getTeamManagers() gin.HandlerFunc {
 	return endpoint{
@@ -175,15 +282,21 @@ func RegisterLeagueHandlers(g *gin.RouterGroup) {
 
 	g.PUT("", updateLeagueInfo())
 	g.PUT("/markdown", setLeagueMarkdown())
+	g.PUT("/rosterRules", setRosterRules())
 	g.GET("/teamManagers", getTeamManagers())
 	g.PUT("/permissions/:userId", storeTargetUserId(), setLeaguePermissions()) //TODO: test this one in integrat
+	g.POST("/invites", createLeagueInvite())
+	g.GET("/invites", getLeagueInvites())
+	g.DELETE("/invites/:inviteId", storeInviteId(), revokeLeagueInvite())
 
 	// League Interact
 	g.POST("/setActiveLeague/:leagueId", storeTargetLeagueId(), setActiveLeague())
 	g.POST("/join", joinActiveLeague)
+	g.POST("/join/:token", joinLeagueWithInvite())
 
 	// League Information
 	g.GET("", getActiveLeagueInformation())
 	g.GET("/markdown", getLeagueMarkdown())
+	g.GET("/rosterRules", getRosterRules())
 	g.GET("/publicLeagues", getPublicLeagues)
 }
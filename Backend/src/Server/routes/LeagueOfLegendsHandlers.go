@@ -2,14 +2,17 @@ package routes
 
 import (
 	"Server/dataModel"
+	"Server/lolApi"
+	"crypto/subtle"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/gorilla/securecookie"
-	"io/ioutil"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 func leagueOfLegendsGetSummonerId() gin.HandlerFunc {
@@ -30,50 +33,8 @@ func leagueOfLegendsGetSummonerId() gin.HandlerFunc {
 	}
 }
 
-//TODO: add case for adding game result to series
-func receiveCompletedTournamentGame() gin.HandlerFunc {
-	return func(ctx *gin.Context) {
-		var matchInformation dataModel.LoLMatchInformation
-		if bindAndCheckErr(ctx, &matchInformation) {
-			return
-		}
-
-		gameResult := dataModel.GameResult{
-			WinnerId: matchInformation.WinningTeamId,
-			LoserId:  matchInformation.LosingTeamId,
-			ScoreTeam1: func() int {
-				if matchInformation.WinningTeamId == matchInformation.Team1Id {
-					return 1
-				} else {
-					return 0
-				}
-			}(),
-			ScoreTeam2: func() int {
-				if matchInformation.WinningTeamId == matchInformation.Team2Id {
-					return 1
-				} else {
-					return 0
-				}
-			}(),
-		}
-		valid, problem, err := gameResult.ValidateByExternalId(matchInformation.GameId, GameDAO)
-		if DataInvalid(ctx, valid, problem, err) {
-			return
-		}
-		leagueId, gameId, err := GameDAO.ReportGameByExternalId(matchInformation.GameId, gameResult)
-		if checkErr(ctx, err) {
-			return
-		}
-		if err := LeagueOfLegendsDAO.ReportEndGameStats(
-			leagueId, gameId, &matchInformation); checkErr(ctx, err) {
-			return
-		}
-		ctx.Status(http.StatusOK)
-	}
-}
-
 func getPlayerStats(ctx *gin.Context) {
-	playerStats, err := LeagueOfLegendsDAO.GetPlayerStats(getLeagueId(ctx))
+	playerStats, err := LeagueOfLegendsDAO.GetPlayerStats(getSeasonId(ctx))
 	if checkErr(ctx, err) {
 		return
 	}
@@ -81,7 +42,7 @@ func getPlayerStats(ctx *gin.Context) {
 }
 
 func getTeamStats(ctx *gin.Context) {
-	teamStats, err := LeagueOfLegendsDAO.GetTeamStats(getLeagueId(ctx))
+	teamStats, err := LeagueOfLegendsDAO.GetTeamStats(getSeasonId(ctx))
 	if checkErr(ctx, err) {
 		return
 	}
@@ -89,7 +50,7 @@ func getTeamStats(ctx *gin.Context) {
 }
 
 func getChampionStats(ctx *gin.Context) {
-	championStats, err := LeagueOfLegendsDAO.GetChampionStats(getLeagueId(ctx))
+	championStats, err := LeagueOfLegendsDAO.GetChampionStats(getSeasonId(ctx))
 	if checkErr(ctx, err) {
 		return
 	}
@@ -98,20 +59,44 @@ func getChampionStats(ctx *gin.Context) {
 
 // https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/createLoLPlayer
 func createNewLoLPlayer() gin.HandlerFunc {
-	var player dataModel.LoLPlayerCore
-	return endpoint{
-		Entity:     Player,
-		AccessType: Create,
-		BindData:   func(ctx *gin.Context) bool { return bindRepeatedAndCheckErr(ctx, &player) },
-		IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
-			return player.ValidateNew(getLeagueId(ctx), getTeamId(ctx), LeagueOfLegendsDAO)
-		},
-		Core: func(ctx *gin.Context) (interface{}, error) {
-			playerId, err := LeagueOfLegendsDAO.CreateLoLPlayer(
-				getLeagueId(ctx), getTeamId(ctx), getExternalId(ctx), player)
-			return gin.H{"playerId": playerId}, err
-		},
-	}.createEndpointHandler()
+	return func(ctx *gin.Context) {
+		var player dataModel.LoLPlayerCore
+		var queued bool
+		endpoint{
+			Entity:     Player,
+			AccessType: Create,
+			BindData:   func(ctx *gin.Context) bool { return bindRepeatedAndCheckErr(ctx, &player) },
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				administrator, err := isLeagueAdministrator(ctx)
+				if err != nil {
+					return false, "", err
+				}
+				if queued, err = isRosterChangeQueued(ctx, administrator); err != nil {
+					return false, "", err
+				}
+				return player.ValidateNew(getLeagueId(ctx), getTeamId(ctx), administrator || queued,
+					LeagueDAO, TeamDAO, LeagueOfLegendsDAO)
+			},
+			CustomResCore: func(ctx *gin.Context) {
+				if queued {
+					queueRosterChange(ctx, dataModel.RosterChangeCore{
+						Name:           player.GameIdentifier,
+						GameIdentifier: player.GameIdentifier,
+						ExternalId:     getExternalId(ctx),
+						MainRoster:     player.MainRoster,
+						Position:       player.Position,
+					})
+					return
+				}
+				playerId, err := LeagueOfLegendsDAO.CreateLoLPlayer(
+					getLeagueId(ctx), getTeamId(ctx), getExternalId(ctx), player)
+				if checkErr(ctx, err) {
+					return
+				}
+				ctx.JSON(http.StatusCreated, gin.H{"playerId": playerId})
+			},
+		}.createEndpointHandler()(ctx)
+	}
 }
 
 // https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/updateLoLPlayer
@@ -122,7 +107,12 @@ func updateLoLPlayer() gin.HandlerFunc {
 		AccessType: Edit,
 		BindData:   func(ctx *gin.Context) bool { return bindRepeatedAndCheckErr(ctx, &player) },
 		IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
-			return player.ValidateEdit(getLeagueId(ctx), getTeamId(ctx), getPlayerId(ctx), LeagueOfLegendsDAO)
+			administrator, err := isLeagueAdministrator(ctx)
+			if err != nil {
+				return false, "", err
+			}
+			return player.ValidateEdit(getLeagueId(ctx), getTeamId(ctx), getPlayerId(ctx), administrator,
+				LeagueDAO, TeamDAO, LeagueOfLegendsDAO)
 		},
 		Core: func(ctx *gin.Context) (interface{}, error) {
 			return nil, LeagueOfLegendsDAO.UpdateLoLPlayer(
@@ -156,7 +146,11 @@ func createNewLoLTeamWithPlayers() gin.HandlerFunc {
 			return bindAndCheckErr(ctx, &team)
 		},
 		IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
-			return team.Validate(getLeagueId(ctx), TeamDAO)
+			administrator, err := isLeagueAdministrator(ctx)
+			if err != nil {
+				return false, "", err
+			}
+			return team.Validate(getLeagueId(ctx), administrator, LeagueDAO, TeamDAO)
 		},
 		CustomResCore: func(ctx *gin.Context) {
 			var err error
@@ -168,7 +162,7 @@ func createNewLoLTeamWithPlayers() gin.HandlerFunc {
 				}
 				player.ExternalId = externalId
 
-				valid, problem, err := player.ValidateNew(getLeagueId(ctx), 0, LeagueOfLegendsDAO)
+				valid, problem, err := player.ValidateWithTeam(getLeagueId(ctx), LeagueOfLegendsDAO)
 				if DataInvalid(ctx, valid, problem, err) {
 					return
 				}
@@ -208,7 +202,13 @@ func registerTournament() gin.HandlerFunc {
 				return
 			}
 
-			providerId, tournamentId, err := LoLTournamentApi.RegisterTournament(getLeagueId(ctx), "NA", leagueInfo.Name)
+			settings, err := LeagueOfLegendsDAO.GetTournamentSettings(getLeagueId(ctx))
+			if checkErr(ctx, err) {
+				return
+			}
+
+			providerId, tournamentId, err := LoLTournamentApi.RegisterTournament(
+				getLeagueId(ctx), settings.Region, leagueInfo.Name)
 			if err != nil {
 				ctx.JSON(http.StatusBadRequest, gin.H{"errorDescription": "Tournament already registered"})
 				return
@@ -223,6 +223,36 @@ func registerTournament() gin.HandlerFunc {
 	}.createEndpointHandler()
 }
 
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getLoLTournamentSettings
+func getTournamentSettings() gin.HandlerFunc {
+	return endpoint{
+		Entity:     League,
+		AccessType: View,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return LeagueOfLegendsDAO.GetTournamentSettings(getLeagueId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/setLoLTournamentSettings
+func setTournamentSettings() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var settings dataModel.LoLTournamentSettings
+		endpoint{
+			Entity:     League,
+			AccessType: Edit,
+			BindData:   func(ctx *gin.Context) bool { return bindAndCheckErr(ctx, &settings) },
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				return settings.Validate(getLeagueId(ctx), LeagueOfLegendsDAO)
+			},
+			Core: func(ctx *gin.Context) (interface{}, error) {
+				return nil, LeagueOfLegendsDAO.SetTournamentSettings(getLeagueId(ctx), settings)
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getLoLTournamentCode
 func getTournamentCode() gin.HandlerFunc {
 	return endpoint{
 		Entity:     Game,
@@ -235,98 +265,294 @@ func getTournamentCode() gin.HandlerFunc {
 				return
 			}
 
-			gameInfo, err := GameDAO.GetGameInformation(getGameId(ctx))
-			if checkErr(ctx, err) {
-				return
+			if !newCode {
+				tournamentCode, err := LeagueOfLegendsDAO.GetTournamentCode(getGameId(ctx))
+				if checkErr(ctx, err) {
+					return
+				} else if tournamentCode != "" {
+					ctx.JSON(http.StatusOK, gin.H{"tournamentCode": tournamentCode})
+					return
+				}
 			}
 
-			team1Info, err := LeagueOfLegendsDAO.GetLoLTeamStub(gameInfo.Team1.TeamId)
+			isRegistered, err := LeagueOfLegendsDAO.LeagueHasRegisteredTournament(getLeagueId(ctx))
 			if checkErr(ctx, err) {
 				return
+			} else if !isRegistered {
+				ctx.JSON(http.StatusBadRequest, gin.H{"errorDescription": "This league must first be registered as a league of legends tournament"})
+				return
 			}
 
-			team2Info, err := LeagueOfLegendsDAO.GetLoLTeamStub(gameInfo.Team2.TeamId)
+			tournamentId, err := LeagueOfLegendsDAO.GetTournamentId(getLeagueId(ctx))
 			if checkErr(ctx, err) {
 				return
 			}
 
-			if len(team1Info.MainRoster) == 0 || len(team2Info.MainRoster) == 0 {
-				ctx.JSON(http.StatusBadRequest, gin.H{"errorDescription": "Both teams must have non-empty main rosters"})
+			tournamentCode, problem, err := createTournamentCode(getGameId(ctx), getLeagueId(ctx), tournamentId)
+			if DataInvalid(ctx, problem == "", problem, err) {
 				return
 			}
+			ctx.JSON(http.StatusOK, gin.H{"tournamentCode": tournamentCode})
+		},
+	}.createEndpointHandler()
+}
 
-			hasExistingCode, err := LeagueOfLegendsDAO.HasTournamentCode(getGameId(ctx))
-			if checkErr(ctx, err) {
-				return
-			} else if hasExistingCode && !newCode {
-				tournamentCode, err := LeagueOfLegendsDAO.GetTournamentCode(getGameId(ctx))
-				if checkErr(ctx, err) {
-					return
-				} else {
-					ctx.JSON(http.StatusOK, gin.H{"tournamentCode": tournamentCode})
-				}
-			} else {
-				isRegistered, err := LeagueOfLegendsDAO.LeagueHasRegisteredTournament(getLeagueId(ctx))
-				if checkErr(ctx, err) {
-					return
-				} else if !isRegistered {
-					ctx.JSON(http.StatusBadRequest, gin.H{"errorDescription": "This league must first be registered as a league of legends tournament"})
-					return
-				}
+// Creates a code of the tournament for the game with the tournament settings of the league, replacing the code it
+// had. The metadata of the code identifies the game and its teams, so that the callback of the played game can be
+// mapped back to it. The game keeps its previous code if a new one can not be created. Returns why a code can not be
+// created, or an error
+func createTournamentCode(gameId, leagueId, tournamentId int) (string, string, error) {
+	settings, err := LeagueOfLegendsDAO.GetTournamentSettings(leagueId)
+	if err != nil {
+		return "", "", err
+	}
+	gameInfo, err := GameDAO.GetGameInformation(gameId)
+	if err != nil {
+		return "", "", err
+	}
+	team1Info, err := LeagueOfLegendsDAO.GetLoLTeamStub(gameInfo.Team1.TeamId)
+	if err != nil {
+		return "", "", err
+	}
+	team2Info, err := LeagueOfLegendsDAO.GetLoLTeamStub(gameInfo.Team2.TeamId)
+	if err != nil {
+		return "", "", err
+	}
+	if len(team1Info.MainRoster) == 0 || len(team2Info.MainRoster) == 0 {
+		return "", dataModel.TournamentTeamRosterEmpty, nil
+	}
 
-				tournamentId, err := LeagueOfLegendsDAO.GetTournamentId(getLeagueId(ctx))
-				if checkErr(ctx, err) {
-					return
-				}
+	var allowedSummonerIds []string
+	if settings.RestrictToRosters {
+		team1SummonerIds, team2SummonerIds := getRosterSummonerIds(team1Info), getRosterSummonerIds(team2Info)
+		if len(team1SummonerIds) < settings.TeamSize || len(team2SummonerIds) < settings.TeamSize {
+			return "", dataModel.TournamentRosterTooSmall, nil
+		}
+		allowedSummonerIds = append(team1SummonerIds, team2SummonerIds...)
+	}
 
-				externalId := hex.EncodeToString(securecookie.GenerateRandomKey(32))
-				fmt.Printf("adding external id: %v\n", externalId)
-				if err = GameDAO.AddExternalId(getGameId(ctx), externalId); checkErr(ctx, err) {
-					return
-				}
+	externalId := hex.EncodeToString(securecookie.GenerateRandomKey(32))
+	metadata, err := json.Marshal(lolApi.TournamentGameMetadata{
+		GameId:           externalId,
+		ElmGameId:        gameId,
+		Team1Id:          gameInfo.Team1.TeamId,
+		Team2Id:          gameInfo.Team2.TeamId,
+		Team1RefPlayerId: team1Info.MainRoster[0].ExternalId,
+	})
+	if err != nil {
+		return "", "", err
+	}
 
-				tournamentCode, err := LoLTournamentApi.CreateTournamentKey(tournamentId,
-					fmt.Sprintf("{\"gameId\": \"%v\", \"team1Id\":%v, "+
-						"\"team2Id\":%v,\"team1RefPlayerId\":\"%v\"}",
-						externalId, gameInfo.Team1.TeamId, gameInfo.Team2.TeamId, team1Info.MainRoster[0].ExternalId))
-				if err != nil {
-					ctx.JSON(http.StatusBadRequest, gin.H{"errorDescription": err.Error()})
-					return
-				}
+	tournamentCode, err := LoLTournamentApi.CreateTournamentKey(settings.Region, tournamentId,
+		&lolApi.TournamentCodeParameters{
+			AllowedSummonerIds: allowedSummonerIds,
+			MapType:            settings.MapType,
+			Metadata:           string(metadata),
+			PickType:           settings.PickType,
+			SpectatorType:      settings.SpectatorType,
+			TeamSize:           settings.TeamSize,
+		})
+	if err != nil {
+		return "", "", err
+	}
+	return tournamentCode, "", LeagueOfLegendsDAO.CreateTournamentCode(gameId, externalId, tournamentCode)
+}
 
-				if err = LeagueOfLegendsDAO.CreateTournamentCode(getGameId(ctx), tournamentCode); checkErr(ctx, err) {
-					return
-				}
+// Summoners on the main and substitute rosters of the team
+func getRosterSummonerIds(team *dataModel.LoLTeamStub) []string {
+	summonerIds := make([]string, 0)
+	for _, player := range append(append(make([]*dataModel.LoLPlayerStub, 0), team.MainRoster...),
+		team.SubstituteRoster...) {
+		if player.ExternalId != "" {
+			summonerIds = append(summonerIds, player.ExternalId)
+		}
+	}
+	return summonerIds
+}
+
+// Codes are created for at most this many games every interval, so that scheduling a season does not send a burst
+// of requests to the tournament API
+const tournamentCodeBatchSize = 20
+
+// Creates codes for the upcoming games of leagues registered as tournaments once they are within the lead time, and
+// replaces the codes of games that have been rescheduled or whose teams have changed. Games whose teams do not have
+// rosters yet are skipped until they do
+func provisionTournamentCodes(interval, leadTime time.Duration) {
+	for range time.Tick(interval) {
+		now := time.Now()
+		games, err := LeagueOfLegendsDAO.GetGamesAwaitingTournamentCodes(int(now.Unix()), int(now.Add(leadTime).Unix()))
+		if err != nil {
+			fmt.Printf("failed to get games awaiting tournament codes: %v\n", err)
+			continue
+		}
 
-				ctx.JSON(http.StatusOK, gin.H{"tournamentCode": tournamentCode})
+		created := 0
+		for _, game := range games {
+			if created == tournamentCodeBatchSize {
+				break
 			}
-		},
-	}.createEndpointHandler()
+			if _, problem, err := createTournamentCode(game.GameId, game.LeagueId, game.TournamentId); err != nil {
+				fmt.Printf("failed to create tournament code of game %v: %v\n", game.GameId, err)
+			} else if problem == "" {
+				created++
+			}
+		}
+	}
+}
+
+// Failed ingestions are retried after callbackRetryDelay, doubling with every attempt
+const (
+	maxCallbackAttempts = 10
+	callbackRetryDelay  = time.Minute
+)
+
+// Tournament callbacks must present the secret that the provider url was registered with. Without a configured
+// secret no callbacks are accepted, since anyone could report results otherwise
+func verifyTournamentCallback() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		secret := []byte(TournamentCallbackSecret)
+		if len(secret) == 0 || subtle.ConstantTimeCompare(secret, []byte(ctx.Query(lolApi.CallbackSecretParam))) != 1 {
+			ctx.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		ctx.Next()
+	}
 }
 
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/lolTournamentCallback
+// Called by the Riot tournament API when a game played with one of the tournament codes of the league ends.
+// Callbacks that could not be ingested are queued to be retried, and callbacks of matches that have already been
+// reported are acknowledged without reporting them again
 func tournamentCallback() gin.HandlerFunc {
-	//TODO: check if game is valid before doing anything else
 	return func(ctx *gin.Context) {
-		bodyBytes, err := ioutil.ReadAll(ctx.Request.Body)
-		if checkErr(ctx, err) {
+		var callback lolApi.TournamentCallback
+		if bindAndCheckErr(ctx, &callback) {
 			return
 		}
-		if err = LoLTournamentApi.ForwardCompleteTournamentGame(bodyBytes); checkErr(ctx, err) {
+
+		problem, err := ingestTournamentCallback(&callback)
+		if err != nil {
+			fmt.Printf("queueing tournament callback of match %v: %v\n", callback.MatchId(), err)
+			if checkErr(ctx, queueTournamentCallback(&callback, err)) {
+				return
+			}
+			ctx.Status(http.StatusAccepted)
 			return
-		} else {
-			ctx.Status(http.StatusOK)
 		}
+		if DataInvalid(ctx, problem == "", problem, nil) {
+			return
+		}
+		ctx.Status(http.StatusOK)
+	}
+}
+
+// The result and stats of the match are fetched from the match API rather than trusted from the callback. Returns
+// why the match can not be reported, or an error if reporting it should be retried
+func ingestTournamentCallback(callback *lolApi.TournamentCallback) (string, error) {
+	processed, err := LeagueOfLegendsDAO.IsMatchProcessed(callback.MatchId())
+	if err != nil || processed {
+		return "", err
+	}
+
+	metadata, err := callback.GameMetadata()
+	if err != nil {
+		return dataModel.InvalidTournamentMetadata, nil
+	}
+	valid, problem, err := dataModel.ValidateTournamentCode(
+		callback.ShortCode, metadata.ElmGameId, metadata.GameId, LeagueOfLegendsDAO)
+	if !valid || err != nil {
+		return problem, err
+	}
+
+	matchInformation, err := lolApi.GetTournamentMatchInformation(LoLApi, callback)
+	if err != nil {
+		return "", err
+	}
+	gameResult := dataModel.GameResult{
+		WinnerId: matchInformation.WinningTeamId,
+		LoserId:  matchInformation.LosingTeamId,
+	}
+	valid, problem, err = gameResult.ValidateByExternalId(matchInformation.GameId, GameDAO)
+	if !valid || err != nil {
+		return problem, err
+	}
+
+	leagueId, reported, err := LeagueOfLegendsDAO.ReportTournamentMatch(callback.ShortCode, matchInformation, gameResult)
+	if err != nil {
+		return "", err
+	} else if reported {
+		progressToPlayoffs(leagueId)
+	}
+	return "", nil
+}
+
+func queueTournamentCallback(callback *lolApi.TournamentCallback, ingestErr error) error {
+	body, err := json.Marshal(callback)
+	if err != nil {
+		return err
+	}
+	return LeagueOfLegendsDAO.QueueTournamentCallback(&dataModel.LoLQueuedCallback{
+		MatchId:     callback.MatchId(),
+		Callback:    string(body),
+		Attempts:    1,
+		NextAttempt: int(time.Now().Add(callbackRetryDelay).Unix()),
+		LastError:   ingestErr.Error(),
+	})
+}
+
+// Retries queued tournament callbacks once they are due. Callbacks are removed from the queue once they have been
+// ingested or can not be reported, and are kept with their last error once they run out of attempts
+func retryTournamentCallbacks(interval time.Duration) {
+	for range time.Tick(interval) {
+		callbacks, err := LeagueOfLegendsDAO.GetDueTournamentCallbacks(int(time.Now().Unix()), maxCallbackAttempts)
+		if err != nil {
+			fmt.Printf("failed to get queued tournament callbacks: %v\n", err)
+			continue
+		}
+		for _, queued := range callbacks {
+			retryTournamentCallback(queued)
+		}
+	}
+}
+
+func retryTournamentCallback(queued *dataModel.LoLQueuedCallback) {
+	var callback lolApi.TournamentCallback
+	problem := ""
+	err := json.Unmarshal([]byte(queued.Callback), &callback)
+	if err != nil {
+		problem = err.Error()
+	} else {
+		problem, err = ingestTournamentCallback(&callback)
+	}
+
+	if err == nil {
+		if problem != "" {
+			fmt.Printf("could not report tournament callback of match %v: %v\n", queued.MatchId, problem)
+		}
+		if err := LeagueOfLegendsDAO.DeleteTournamentCallback(queued.MatchId); err != nil {
+			fmt.Printf("failed to remove tournament callback of match %v: %v\n", queued.MatchId, err)
+		}
+		return
+	}
+
+	fmt.Printf("failed to retry tournament callback of match %v: %v\n", queued.MatchId, err)
+	queued.NextAttempt = int(time.Now().Add(callbackRetryDelay << uint(queued.Attempts)).Unix())
+	queued.Attempts++
+	queued.LastError = err.Error()
+	if err := LeagueOfLegendsDAO.UpdateTournamentCallback(queued); err != nil {
+		fmt.Printf("failed to update tournament callback of match %v: %v\n", queued.MatchId, err)
 	}
 }
 
 func RegisterLeagueOfLegendsHandlers(g *gin.RouterGroup) {
 	g.POST("/registerTournament", registerTournament())
+	g.GET("/tournamentSettings", getTournamentSettings())
+	g.PUT("/tournamentSettings", setTournamentSettings())
 	g.POST("/teamsWithPlayers", createNewLoLTeamWithPlayers())
-	g.POST("/receiveCompletedTournamentGame", receiveCompletedTournamentGame())
-	g.POST("/tournamentCallback", tournamentCallback())
-	g.GET("/stats/player", getPlayerStats)
-	g.GET("/stats/team", getTeamStats)
-	g.GET("/stats/champion", getChampionStats)
+	g.POST("/tournamentCallback", verifyTournamentCallback(), tournamentCallback())
+	g.GET("/stats/player", storeSelectedSeasonId(), getPlayerStats)
+	g.GET("/stats/team", storeSelectedSeasonId(), getTeamStats)
+	g.GET("/stats/champion", storeSelectedSeasonId(), getChampionStats)
 	g.POST("/games", createNewGame())
 	g.GET("/games/:gameId/tournamentCode", storeGameId(), getTournamentCode())
 	withTeamId := g.Group("/teams/:teamId", storeTeamId())
@@ -0,0 +1,201 @@
+package routes
+
+import (
+	"Server/dataModel"
+	"Server/scheduler"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getPlayoffStage
+func getPlayoffStage() gin.HandlerFunc {
+	return endpoint{
+		Entity:     Season,
+		AccessType: View,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return LeagueDAO.GetPlayoffStage(getSeasonId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/setPlayoffStage
+func setPlayoffStage() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var stage dataModel.PlayoffStageCore
+		endpoint{
+			Entity:     Season,
+			AccessType: Edit,
+			BindData:   func(ctx *gin.Context) bool { return bindAndCheckErr(ctx, &stage) },
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				return stage.Validate(getSeasonId(ctx), LeagueDAO)
+			},
+			Core: func(ctx *gin.Context) (interface{}, error) {
+				return nil, LeagueDAO.SetPlayoffStage(getSeasonId(ctx), stage)
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/deletePlayoffStage
+func deletePlayoffStage() gin.HandlerFunc {
+	return endpoint{
+		Entity:     Season,
+		AccessType: Delete,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return nil, LeagueDAO.DeletePlayoffStage(getSeasonId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/generatePlayoffStage
+func generatePlayoffStage() gin.HandlerFunc {
+	return endpoint{
+		Entity:     Season,
+		AccessType: Edit,
+		IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+			return dataModel.ValidatePlayoffStageGeneration(getLeagueId(ctx), getSeasonId(ctx), LeagueDAO, GameDAO,
+				TeamDAO)
+		},
+		CustomResCore: func(ctx *gin.Context) {
+			games, problem, err := createPlayoffStage(getLeagueId(ctx), getSeasonId(ctx))
+			if DataInvalid(ctx, problem == "", problem, err) {
+				return
+			}
+			ctx.JSON(http.StatusCreated, games)
+		},
+	}.createEndpointHandler()
+}
+
+// Creates the playoff stage of the current season of a league once every game of its group stage is complete.
+// Results have already been recorded when this is called, so failures are only logged and the stage can still be
+// generated by hand
+func progressToPlayoffs(leagueId int) {
+	seasonId, err := LeagueDAO.GetCurrentSeasonId(leagueId)
+	if err != nil {
+		fmt.Printf("failed to get current season of league %v: %v\n", leagueId, err)
+		return
+	}
+
+	valid, _, err := dataModel.ValidatePlayoffStageGeneration(leagueId, seasonId, LeagueDAO, GameDAO, TeamDAO)
+	if err != nil {
+		fmt.Printf("failed to check playoff stage of season %v: %v\n", seasonId, err)
+		return
+	} else if !valid {
+		return
+	}
+
+	if _, problem, err := createPlayoffStage(leagueId, seasonId); err != nil {
+		fmt.Printf("failed to create playoff stage of season %v: %v\n", seasonId, err)
+	} else if problem != "" {
+		fmt.Printf("could not schedule playoff stage of season %v: %v\n", seasonId, problem)
+	}
+}
+
+// Claims the playoff stage so that its games are only created once, and releases it again if they could not be.
+// Returns a description of why the games could not be scheduled, or an error
+func createPlayoffStage(leagueId, seasonId int) ([]dataModel.ScheduledGame, string, error) {
+	stage, err := LeagueDAO.GetPlayoffStage(seasonId)
+	if err != nil {
+		return nil, "", err
+	}
+	claimed, err := LeagueDAO.ClaimPlayoffStage(seasonId)
+	if err != nil {
+		return nil, "", err
+	} else if !claimed {
+		return nil, dataModel.PlayoffStageAlreadyGenerated, nil
+	}
+
+	games, problem, err := schedulePlayoffStage(leagueId, seasonId, stage)
+	if err != nil || problem != "" {
+		if releaseErr := LeagueDAO.ReleasePlayoffStage(seasonId); releaseErr != nil {
+			return nil, "", releaseErr
+		}
+	}
+	return games, problem, err
+}
+
+// The top teams of each group by their group standings are seeded into the bracket, which starts once the last
+// game of the group stage has ended. Teams that withdrew during the group stage do not advance. Group stage games
+// created by hand have no duration, so the bracket may start as soon as they do
+func schedulePlayoffStage(leagueId, seasonId int, stage *dataModel.PlayoffStage) ([]dataModel.ScheduledGame, string, error) {
+	ranking, err := computeStandings(leagueId, seasonId, 0)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var teamIds []int
+	teamDisplay := make(map[int]dataModel.TeamDisplay)
+	for _, standing := range ranking {
+		if standing.Withdrawn {
+			continue
+		}
+		teamIds = append(teamIds, standing.Team.TeamId)
+		teamDisplay[standing.Team.TeamId] = standing.Team
+	}
+
+	groups, err := getGroups(leagueId, teamIds)
+	if err != nil {
+		return nil, "", err
+	}
+	s := scheduler.Scheduler{}
+	seeds := s.SeedGroups(groups, stage.AdvancingPerGroup)
+	if len(seeds) < 2 {
+		return nil, dataModel.NotEnoughPlayoffTeams, nil
+	}
+
+	leagueInformation, err := LeagueDAO.GetLeagueInformation(leagueId)
+	if err != nil {
+		return nil, "", err
+	}
+	location, err := leagueInformation.Location()
+	if err != nil {
+		return nil, "", err
+	}
+	seasonGames, err := GameDAO.GetAllGamesInSeason(seasonId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	start := time.Unix(int64(leagueInformation.LeagueStart), 0).In(location)
+	if time.Now().After(start) {
+		start = time.Now().In(location)
+	}
+	for _, game := range seasonGames {
+		if game.Playoff {
+			continue
+		}
+		groupGameDuration := time.Duration(game.Duration) * time.Minute
+		if gameEnd := time.Unix(int64(game.GameTime), 0).In(location).Add(groupGameDuration); gameEnd.After(start) {
+			start = gameEnd
+		}
+	}
+
+	gameDuration := time.Duration(stage.GameDuration) * time.Minute
+
+	s.InitScheduler(
+		s.GetTournamentFromString(stage.TournamentType),
+		stage.RoundsPerWeek,
+		stage.ConcurrentGameNum,
+		gameDuration,
+		start,
+		time.Unix(int64(leagueInformation.LeagueEnd), 0).In(location),
+		seeds)
+	if err := addAvailabilities(&s, leagueId); err != nil {
+		return nil, "", err
+	}
+
+	scheduledGames, err := s.GetSchedule()
+	if err != nil {
+		return nil, err.Error(), nil
+	}
+
+	games := toScheduledGames(scheduledGames, teamDisplay)
+	problem, err := createScheduledGames(leagueId, games, dataModel.GameCreationInformation{
+		BestOf:   stage.BestOf,
+		Duration: stage.GameDuration,
+		Playoff:  true,
+	}, true)
+	return games, problem, err
+}
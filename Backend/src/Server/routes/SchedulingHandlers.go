@@ -5,6 +5,7 @@ import (
 	"Server/scheduler"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"net/http"
 	"time"
 )
 
@@ -116,15 +117,18 @@ func generateSchedule() gin.HandlerFunc {
 			Entity:        Game,
 			AccessType:    Create,
 			BindData:      func(ctx *gin.Context) bool { return bindAndCheckErr(ctx, &schedulingParameters) },
-			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) { return schedulingParameters.Validate() },
-			Core: func(ctx *gin.Context) (interface{}, error) {
-				// Get list of all teams and create map of team id to its display information
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				return schedulingParameters.Validate(getLeagueId(ctx), LeagueDAO, TeamDAO)
+			},
+			CustomResCore: func(ctx *gin.Context) {
+				// Get list of all teams, ordered by record for bracket seeding, and create map of team id to its
+				// display information
 				var teamIds []int
 				teamDisplay := make(map[int]dataModel.TeamDisplay)
 
 				teams, err := TeamDAO.GetAllTeamDisplaysInLeague(getLeagueId(ctx))
-				if err != nil {
-					return nil, err
+				if checkErr(ctx, err) {
+					return
 				}
 
 				for _, team := range teams {
@@ -134,18 +138,14 @@ func generateSchedule() gin.HandlerFunc {
 
 				fmt.Printf("teamids: %v \n", teamIds)
 
-				// Get Availabilities for use in scheduler
-				// TODO: add non-weekly availabilities as well here
-				availabilities, err := LeagueDAO.GetWeeklyAvailabilities(getLeagueId(ctx))
-				if err != nil {
-					return nil, err
-				}
-				fmt.Printf("availabilities: %v \n", availabilities)
-
 				// Get competition start and end times for use in scheduler
 				leagueInformation, err := LeagueDAO.GetLeagueInformation(getLeagueId(ctx))
-				if err != nil {
-					return nil, err
+				if checkErr(ctx, err) {
+					return
+				}
+				location, err := leagueInformation.Location()
+				if checkErr(ctx, err) {
+					return
 				}
 				fmt.Printf("start: %v, end: %v \n", leagueInformation.LeagueStart, leagueInformation.LeagueEnd)
 
@@ -156,41 +156,315 @@ func generateSchedule() gin.HandlerFunc {
 					schedulingParameters.RoundsPerWeek,
 					schedulingParameters.ConcurrentGameNum,
 					time.Duration(schedulingParameters.GameDuration)*time.Minute,
-					time.Unix(int64(leagueInformation.LeagueStart), 0),
-					time.Unix(int64(leagueInformation.LeagueEnd), 0),
+					time.Unix(int64(leagueInformation.LeagueStart), 0).In(location),
+					time.Unix(int64(leagueInformation.LeagueEnd), 0).In(location),
 					teamIds)
 
-				for _, availability := range availabilities {
-					s.AddWeeklyAvailability(
-						s.GetWeekdayFromString(availability.Weekday),
-						availability.Hour,
-						availability.Minute,
-						time.Duration(availability.Duration)*time.Minute)
+				if checkErr(ctx, addAvailabilities(&s, getLeagueId(ctx))) {
+					return
+				}
+
+				if checkErr(ctx, addDivisions(&s, getLeagueId(ctx), teamIds,
+					schedulingParameters.CrossDivisionGames)) {
+					return
 				}
 
 				scheduledGames, err := s.GetSchedule()
 				if err != nil {
-					print(err.Error())
-					return gin.H{"errorDescription": err.Error()}, err
+					ctx.JSON(http.StatusBadRequest, gin.H{"errorDescription": err.Error()})
+					return
 				}
 
 				fmt.Printf("games: %+v\n", scheduledGames)
 
-				var games []dataModel.GameCore
-				for _, game := range scheduledGames {
-					games = append(games, dataModel.GameCore{
-						GameTime: game.GameTime,
-						Team1:    teamDisplay[game.Team1Id],
-						Team2:    teamDisplay[game.Team2Id],
-					})
+				games := toScheduledGames(scheduledGames, teamDisplay)
+
+				// Without commit the schedule is only a preview
+				if !schedulingParameters.Commit {
+					ctx.JSON(http.StatusOK, games)
+					return
+				}
+
+				if storeSchedule(ctx, games, dataModel.GameCreationInformation{
+					BestOf:   schedulingParameters.BestOf,
+					Duration: schedulingParameters.GameDuration,
+				}, s.IsBracketTournament(s.GetTournamentFromString(schedulingParameters.TournamentType))) {
+					return
+				}
+
+				ctx.JSON(http.StatusCreated, games)
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/generateNextRound
+func generateNextRound() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var roundParameters dataModel.RoundParameters
+		endpoint{
+			Entity:     Game,
+			AccessType: Create,
+			BindData:   func(ctx *gin.Context) bool { return bindAndCheckErr(ctx, &roundParameters) },
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				return roundParameters.Validate(getLeagueId(ctx), LeagueDAO, GameDAO)
+			},
+			CustomResCore: func(ctx *gin.Context) {
+				teams, err := TeamDAO.GetAllTeamDisplaysInLeague(getLeagueId(ctx))
+				if checkErr(ctx, err) {
+					return
+				}
+
+				var teamIds []int
+				teamDisplay := make(map[int]dataModel.TeamDisplay)
+				for _, team := range teams {
+					teamIds = append(teamIds, team.TeamId)
+					teamDisplay[team.TeamId] = *team
+				}
+
+				leagueInformation, err := LeagueDAO.GetLeagueInformation(getLeagueId(ctx))
+				if checkErr(ctx, err) {
+					return
+				}
+				location, err := leagueInformation.Location()
+				if checkErr(ctx, err) {
+					return
+				}
+
+				previousGames, err := GameDAO.GetAllGamesInLeague(getLeagueId(ctx))
+				if checkErr(ctx, err) {
+					return
+				}
+
+				// The round can only start once the previous round is over
+				gameDuration := time.Duration(roundParameters.GameDuration) * time.Minute
+				start := time.Unix(int64(leagueInformation.LeagueStart), 0).In(location)
+				if time.Now().After(start) {
+					start = time.Now().In(location)
+				}
+				for _, game := range previousGames {
+					if gameEnd := time.Unix(int64(game.GameTime), 0).In(location).Add(gameDuration); gameEnd.After(start) {
+						start = gameEnd
+					}
+				}
+
+				s := scheduler.Scheduler{}
+				s.InitScheduler(
+					scheduler.Swiss,
+					1,
+					roundParameters.ConcurrentGameNum,
+					gameDuration,
+					start,
+					time.Unix(int64(leagueInformation.LeagueEnd), 0).In(location),
+					teamIds)
+
+				// Teams are paired by their record in the current season
+				seasonId, err := LeagueDAO.GetCurrentSeasonId(getLeagueId(ctx))
+				if checkErr(ctx, err) {
+					return
+				}
+				records, err := computeStandings(getLeagueId(ctx), seasonId, 0)
+				if checkErr(ctx, err) {
+					return
+				}
+				for _, record := range records {
+					s.AddTeamRecord(record.Team.TeamId, record.Wins, record.Losses)
+				}
+				for _, game := range previousGames {
+					s.AddPlayedGame(game.Team1.TeamId, game.Team2.TeamId)
+				}
+				if checkErr(ctx, addAvailabilities(&s, getLeagueId(ctx))) {
+					return
+				}
+
+				scheduledGames, err := s.GetSchedule()
+				if err != nil {
+					ctx.JSON(http.StatusBadRequest, gin.H{"errorDescription": err.Error()})
+					return
+				}
+
+				// Swiss rounds are always stored since the next round is paired from their results
+				games := toScheduledGames(scheduledGames, teamDisplay)
+				if storeSchedule(ctx, games, dataModel.GameCreationInformation{
+					BestOf:   roundParameters.BestOf,
+					Duration: roundParameters.GameDuration,
+				}, false) {
+					return
 				}
 
-				return games, nil
+				ctx.JSON(http.StatusCreated, games)
 			},
 		}.createEndpointHandler()(ctx)
 	}
 }
 
+// Leagues without divisions schedule every team together
+func addDivisions(s *scheduler.Scheduler, leagueId int, teamIds []int, crossDivisionGames int) error {
+	groups, err := getGroups(leagueId, teamIds)
+	if err != nil || len(groups) < 2 {
+		return err
+	}
+
+	s.SetDivisions(groups, crossDivisionGames)
+	return nil
+}
+
+// Splits teams into the divisions of the league, keeping the order they are given in within each group. Every team
+// is in a division once the league has any, which is checked before scheduling or seeding
+func getGroups(leagueId int, teamIds []int) ([][]int, error) {
+	divisions, err := LeagueDAO.GetDivisions(leagueId)
+	if err != nil {
+		return nil, err
+	}
+
+	division := make(map[int]int)
+	for d, members := range divisions {
+		for _, team := range members.Teams {
+			division[team.TeamId] = d
+		}
+	}
+
+	if len(divisions) == 0 {
+		return [][]int{teamIds}, nil
+	}
+	groups := make([][]int, len(divisions))
+	for _, teamId := range teamIds {
+		if d, ok := division[teamId]; ok {
+			groups[d] = append(groups[d], teamId)
+		}
+	}
+
+	nonEmpty := make([][]int, 0)
+	for _, group := range groups {
+		if len(group) > 0 {
+			nonEmpty = append(nonEmpty, group)
+		}
+	}
+	return nonEmpty, nil
+}
+
+// Adds the weekly and one-off availabilities of the league as game blocks, and keeps teams out of the blocks
+// during their blackouts
+func addAvailabilities(s *scheduler.Scheduler, leagueId int) error {
+	weeklyAvailabilities, err := LeagueDAO.GetWeeklyAvailabilities(leagueId)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("availabilities: %v \n", weeklyAvailabilities)
+	for _, availability := range weeklyAvailabilities {
+		s.AddWeeklyAvailability(
+			s.GetWeekdayFromString(availability.Weekday),
+			availability.Hour,
+			availability.Minute,
+			time.Duration(availability.Duration)*time.Minute)
+	}
+
+	availabilities, err := LeagueDAO.GetAvailabilities(leagueId)
+	if err != nil {
+		return err
+	}
+	for _, availability := range availabilities {
+		s.AddAvailability(
+			time.Unix(int64(availability.StartTime), 0),
+			time.Unix(int64(availability.EndTime), 0))
+	}
+
+	blackouts, err := TeamDAO.GetAllTeamBlackoutsInLeague(leagueId)
+	if err != nil {
+		return err
+	}
+	for _, blackout := range blackouts {
+		s.AddTeamUnavailability(
+			blackout.TeamId,
+			time.Unix(int64(blackout.StartTime), 0),
+			time.Unix(int64(blackout.EndTime), 0))
+	}
+
+	return nil
+}
+
+func toScheduledGames(scheduledGames []scheduler.Game, teamDisplay map[int]dataModel.TeamDisplay) []dataModel.ScheduledGame {
+	games := make([]dataModel.ScheduledGame, 0)
+	for _, game := range scheduledGames {
+		games = append(games, dataModel.ScheduledGame{
+			GameCore: dataModel.GameCore{
+				GameTime: game.GameTime,
+				Team1:    teamDisplay[game.Team1Id],
+				Team2:    teamDisplay[game.Team2Id],
+			},
+			Round:       game.Round,
+			Team1Source: toGameSource(game.Team1From),
+			Team2Source: toGameSource(game.Team2From),
+		})
+	}
+	return games
+}
+
+func toGameSource(source *scheduler.GameSource) *dataModel.GameSource {
+	if source == nil {
+		return nil
+	}
+	return &dataModel.GameSource{
+		GameIndex: source.GameIndex,
+		Winner:    source.Winner,
+	}
+}
+
+// Validates every game with the same rules as games created by hand and stores them all in one transaction,
+// setting their game ids. Returns true if a response was already sent because of an invalid game or an error
+func storeSchedule(ctx *gin.Context, games []dataModel.ScheduledGame, template dataModel.GameCreationInformation,
+	bracket bool) bool {
+	problem, err := createScheduledGames(getLeagueId(ctx), games, template, bracket)
+	return DataInvalid(ctx, problem == "", problem, err)
+}
+
+// Games are created with the series length, duration and stage of the template. Returns the problem with the first
+// invalid game, or an error
+func createScheduledGames(leagueId int, games []dataModel.ScheduledGame, template dataModel.GameCreationInformation,
+	bracket bool) (string, error) {
+	var bracketGames []dataModel.BracketGameCreationInformation
+	for _, game := range games {
+		gameInformation := template
+		gameInformation.Team1Id = game.Team1.TeamId
+		gameInformation.Team2Id = game.Team2.TeamId
+		gameInformation.GameTime = game.GameTime
+
+		// Later bracket games have no teams to validate until the games that feed into them are played
+		if gameInformation.Team1Id != 0 && gameInformation.Team2Id != 0 {
+			valid, problem, err := gameInformation.Validate(leagueId, LeagueDAO, TeamDAO, GameDAO)
+			if err != nil || !valid {
+				return problem, err
+			}
+		}
+
+		bracketGames = append(bracketGames, dataModel.BracketGameCreationInformation{
+			GameCreationInformation: gameInformation,
+			Team1Source:             game.Team1Source,
+			Team2Source:             game.Team2Source,
+		})
+	}
+
+	var gameIds []int
+	var err error
+	if bracket {
+		gameIds, err = GameDAO.CreateBracket(leagueId, bracketGames)
+	} else {
+		var gamesInformation []dataModel.GameCreationInformation
+		for _, game := range bracketGames {
+			gamesInformation = append(gamesInformation, game.GameCreationInformation)
+		}
+		gameIds, err = GameDAO.CreateGames(leagueId, gamesInformation)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	for i, gameId := range gameIds {
+		games[i].GameId = gameId
+	}
+	return "", nil
+}
+
 func RegisterSchedulingHandlers(g *gin.RouterGroup) {
 	availabilities := g.Group("/availabilities")
 	availabilities.POST("", createNewAvailability())
@@ -204,4 +478,5 @@ func RegisterSchedulingHandlers(g *gin.RouterGroup) {
 	weeklyAvailabilities.PUT("/:availabilityId", storeAvailabilityId(), editWeeklyAvailability())
 
 	g.POST("/schedule", generateSchedule())
+	g.POST("/schedule/nextRound", generateNextRound())
 }
@@ -0,0 +1,78 @@
+package routes
+
+import (
+	"Server/dataModel"
+	"github.com/gin-gonic/gin"
+)
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/createSeason
+func createSeason() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var season dataModel.SeasonCore
+		endpoint{
+			Entity:     Season,
+			AccessType: Create,
+			BindData:   func(ctx *gin.Context) bool { return bindAndCheckErr(ctx, &season) },
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				return season.ValidateNew(getLeagueId(ctx), LeagueDAO)
+			},
+			Core: func(ctx *gin.Context) (interface{}, error) {
+				seasonId, err := LeagueDAO.CreateSeason(getLeagueId(ctx), season)
+				return gin.H{"seasonId": seasonId}, err
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getSeasons
+func getSeasons() gin.HandlerFunc {
+	return endpoint{
+		Entity:     League,
+		AccessType: View,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return LeagueDAO.GetSeasons(getLeagueId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getSeason
+func getSeason() gin.HandlerFunc {
+	return endpoint{
+		Entity:     Season,
+		AccessType: View,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return LeagueDAO.GetSeason(getSeasonId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/rolloverSeason
+func rolloverSeason() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var rollover dataModel.SeasonRolloverCore
+		endpoint{
+			Entity:     Season,
+			AccessType: Edit,
+			BindData:   func(ctx *gin.Context) bool { return bindAndCheckErr(ctx, &rollover) },
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				return rollover.Validate(getLeagueId(ctx), getSeasonId(ctx), LeagueDAO, TeamDAO)
+			},
+			Core: func(ctx *gin.Context) (interface{}, error) {
+				return nil, LeagueDAO.RolloverSeason(getLeagueId(ctx), getSeasonId(ctx), rollover)
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+func RegisterSeasonHandlers(g *gin.RouterGroup) {
+	g.POST("", createSeason())
+	g.GET("", getSeasons())
+
+	withSeasonId := g.Group("/:seasonId", storeSeasonId())
+	withSeasonId.GET("", getSeason())
+	withSeasonId.POST("/rollover", rolloverSeason())
+	withSeasonId.GET("/playoffStage", getPlayoffStage())
+	withSeasonId.PUT("/playoffStage", setPlayoffStage())
+	withSeasonId.DELETE("/playoffStage", deletePlayoffStage())
+	withSeasonId.POST("/playoffStage/generate", generatePlayoffStage())
+}
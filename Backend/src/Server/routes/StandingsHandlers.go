@@ -0,0 +1,156 @@
+package routes
+
+import (
+	"Server/dataModel"
+	"Server/standings"
+	"github.com/gin-gonic/gin"
+)
+
+// Standings of a season are computed from the games of its group stage between the teams taking part in it. Teams
+// that withdrew stay in the standings so that the results of their games, including the forfeits of their remaining
+// games, still count for their opponents
+func computeStandings(leagueId, seasonId, divisionId int) ([]*dataModel.Standing, error) {
+	standingsConfiguration, err := LeagueDAO.GetStandingsConfiguration(leagueId)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := TeamDAO.GetSeasonTeamDisplays(seasonId)
+	if err != nil {
+		return nil, err
+	}
+	games, err := GameDAO.GetAllGamesInSeason(seasonId)
+	if err != nil {
+		return nil, err
+	}
+	withdrawnTeamIds, err := TeamDAO.GetWithdrawnTeamIds(seasonId)
+	if err != nil {
+		return nil, err
+	}
+	withdrawn := make(map[int]bool)
+	for _, teamId := range withdrawnTeamIds {
+		withdrawn[teamId] = true
+	}
+
+	teamIds := make([]int, 0)
+	teamDisplays := make(map[int]dataModel.TeamDisplay)
+	for _, team := range teams {
+		teamIds = append(teamIds, team.TeamId)
+		teamDisplays[team.TeamId] = *team
+	}
+
+	results := make([]standings.Result, 0)
+	for _, game := range games {
+		if game.Complete && !game.Playoff {
+			results = append(results, standings.Result{
+				Team1Id:    game.Team1.TeamId,
+				Team2Id:    game.Team2.TeamId,
+				WinnerId:   game.WinnerId,
+				Draw:       game.Outcome == dataModel.DrawOutcome,
+				ScoreTeam1: game.ScoreTeam1,
+				ScoreTeam2: game.ScoreTeam2,
+			})
+		}
+	}
+
+	ranking := make([]*dataModel.Standing, 0)
+	for _, standing := range standings.Compute(teamIds, results, standings.Configuration{
+		WinPoints:   standingsConfiguration.WinPoints,
+		DrawPoints:  standingsConfiguration.DrawPoints,
+		LossPoints:  standingsConfiguration.LossPoints,
+		Tiebreakers: standingsConfiguration.Tiebreakers,
+	}) {
+		ranking = append(ranking, &dataModel.Standing{
+			Team:               teamDisplays[standing.TeamId],
+			Rank:               standing.Rank,
+			Played:             standing.Played,
+			Wins:               standing.Wins,
+			Draws:              standing.Draws,
+			Losses:             standing.Losses,
+			Points:             standing.Points,
+			GameDifferential:   standing.GameDifferential,
+			StrengthOfSchedule: standing.StrengthOfSchedule,
+			SonnebornBerger:    standing.SonnebornBerger,
+			Withdrawn:          withdrawn[standing.TeamId],
+		})
+	}
+	if divisionId != 0 {
+		return filterDivisionStandings(ranking, divisionId)
+	}
+	return ranking, nil
+}
+
+// Division standings keep the results of games against other divisions, and rank the teams of the division by
+// their position in the league standings. Teams tied in the league stay tied in the division
+func filterDivisionStandings(ranking []*dataModel.Standing, divisionId int) ([]*dataModel.Standing, error) {
+	division, err := LeagueDAO.GetDivision(divisionId)
+	if err != nil {
+		return nil, err
+	}
+	inDivision := make(map[int]bool)
+	for _, team := range division.Teams {
+		inDivision[team.TeamId] = true
+	}
+
+	divisionRanking := make([]*dataModel.Standing, 0)
+	previousLeagueRank := 0
+	for _, standing := range ranking {
+		if !inDivision[standing.Team.TeamId] {
+			continue
+		}
+		leagueRank := standing.Rank
+		if len(divisionRanking) == 0 || leagueRank != previousLeagueRank {
+			standing.Rank = len(divisionRanking) + 1
+		} else {
+			standing.Rank = divisionRanking[len(divisionRanking)-1].Rank
+		}
+		previousLeagueRank = leagueRank
+		divisionRanking = append(divisionRanking, standing)
+	}
+	return divisionRanking, nil
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getStandings
+func getStandings() gin.HandlerFunc {
+	return endpoint{
+		Entity:     League,
+		AccessType: View,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return computeStandings(getLeagueId(ctx), getSeasonId(ctx), getDivisionId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getStandingsConfiguration
+func getStandingsConfiguration() gin.HandlerFunc {
+	return endpoint{
+		Entity:     League,
+		AccessType: View,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return LeagueDAO.GetStandingsConfiguration(getLeagueId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/setStandingsConfiguration
+func setStandingsConfiguration() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var standingsConfiguration dataModel.StandingsConfiguration
+		endpoint{
+			Entity:     League,
+			AccessType: Edit,
+			BindData:   func(ctx *gin.Context) bool { return bindAndCheckErr(ctx, &standingsConfiguration) },
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				return standingsConfiguration.Validate()
+			},
+			Core: func(ctx *gin.Context) (interface{}, error) {
+				return nil, LeagueDAO.SetStandingsConfiguration(getLeagueId(ctx), standingsConfiguration)
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+func RegisterStandingsHandlers(g *gin.RouterGroup) {
+	g.GET("", storeSelectedSeasonId(), storeSelectedDivisionId(), getStandings())
+	g.GET("/configuration", getStandingsConfiguration())
+	g.PUT("/configuration", setStandingsConfiguration())
+}
@@ -6,6 +6,30 @@ import (
 	"net/http"
 )
 
+func isLeagueAdministrator(ctx *gin.Context) (bool, error) {
+	permissions, err := UserDAO.GetUserWithPermissions(getLeagueId(ctx), getUserId(ctx))
+	if err != nil {
+		return false, err
+	}
+	return permissions.LeaguePermissions.Administrator, nil
+}
+
+func isRosterChangeQueued(ctx *gin.Context, administrator bool) (bool, error) {
+	rules, err := LeagueDAO.GetRosterRules(getLeagueId(ctx))
+	if err != nil {
+		return false, err
+	}
+	return rules.QueuesChanges(administrator), nil
+}
+
+func queueRosterChange(ctx *gin.Context, change dataModel.RosterChangeCore) {
+	rosterChangeId, err := TeamDAO.CreateRosterChange(getTeamId(ctx), getUserId(ctx), change)
+	if checkErr(ctx, err) {
+		return
+	}
+	ctx.JSON(http.StatusAccepted, gin.H{"rosterChangeId": rosterChangeId})
+}
+
 // https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/createTeam
 func createNewTeam() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -31,7 +55,13 @@ func createNewTeam() gin.HandlerFunc {
 					return true
 				}
 			},
-			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) { return team.ValidateNew(getLeagueId(ctx), TeamDAO) },
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				administrator, err := isLeagueAdministrator(ctx)
+				if err != nil {
+					return false, "", err
+				}
+				return team.ValidateNew(getLeagueId(ctx), administrator, LeagueDAO, TeamDAO)
+			},
 			Core: func(ctx *gin.Context) (interface{}, error) {
 				_, err := ctx.FormFile("icon")
 				if err == nil {
@@ -62,7 +92,11 @@ func createNewTeamWithPlayers() gin.HandlerFunc {
 				return bindAndCheckErr(ctx, &team)
 			},
 			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
-				return team.Validate(getLeagueId(ctx), TeamDAO)
+				administrator, err := isLeagueAdministrator(ctx)
+				if err != nil {
+					return false, "", err
+				}
+				return team.Validate(getLeagueId(ctx), administrator, LeagueDAO, TeamDAO)
 			},
 			Core: func(ctx *gin.Context) (interface{}, error) {
 				var err error
@@ -178,12 +212,75 @@ func editTeam() gin.HandlerFunc {
 	}
 }
 
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getPendingTeams
+func getPendingTeams() gin.HandlerFunc {
+	return endpoint{
+		Entity:     League,
+		AccessType: Edit,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return TeamDAO.GetPendingTeams(getLeagueId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getArchivedTeams
+func getArchivedTeams() gin.HandlerFunc {
+	return endpoint{
+		Entity:     League,
+		AccessType: View,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return TeamDAO.GetArchivedTeams(getLeagueId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/approveTeam
+func approveTeam() gin.HandlerFunc {
+	return endpoint{
+		Entity:     League,
+		AccessType: Edit,
+		IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+			return dataModel.ValidateTeamPending(getLeagueId(ctx), getTeamId(ctx), TeamDAO)
+		},
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return nil, TeamDAO.ApproveTeam(getTeamId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/rejectTeam
+func rejectTeam() gin.HandlerFunc {
+	return endpoint{
+		Entity:     League,
+		AccessType: Edit,
+		IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+			return dataModel.ValidateTeamPending(getLeagueId(ctx), getTeamId(ctx), TeamDAO)
+		},
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return nil, TeamDAO.DeleteTeam(getTeamId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// Teams that have games are withdrawn from the league instead, keeping the results of the games played
 func deleteTeam() gin.HandlerFunc {
 	return endpoint{
 		Entity:     Team,
 		AccessType: Delete,
 		Core: func(ctx *gin.Context) (interface{}, error) {
-			return nil, TeamDAO.DeleteTeam(getTeamId(ctx))
+			active, err := TeamDAO.IsTeamActive(getLeagueId(ctx), getTeamId(ctx))
+			if err != nil {
+				return nil, err
+			} else if active {
+				// Forfeiting the remaining games of the team can complete the group stage
+				if err := TeamDAO.WithdrawTeam(getTeamId(ctx)); err != nil {
+					return nil, err
+				}
+				progressToPlayoffs(getLeagueId(ctx))
+				return nil, nil
+			} else {
+				return nil, TeamDAO.DeleteTeam(getTeamId(ctx))
+			}
 		},
 	}.createEndpointHandler()
 }
@@ -208,16 +305,36 @@ func editTeamManagerPermissions() gin.HandlerFunc {
 func createNewPlayer() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var player dataModel.PlayerCore
+		var queued bool
 		endpoint{
 			Entity:     Player,
 			AccessType: Create,
 			BindData:   func(ctx *gin.Context) bool { return bindAndCheckErr(ctx, &player) },
 			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
-				return player.ValidateNew(getLeagueId(ctx), getTeamId(ctx), TeamDAO)
+				administrator, err := isLeagueAdministrator(ctx)
+				if err != nil {
+					return false, "", err
+				}
+				if queued, err = isRosterChangeQueued(ctx, administrator); err != nil {
+					return false, "", err
+				}
+				return player.ValidateNew(getLeagueId(ctx), getTeamId(ctx), administrator || queued,
+					LeagueDAO, TeamDAO)
 			},
-			Core: func(ctx *gin.Context) (interface{}, error) {
+			CustomResCore: func(ctx *gin.Context) {
+				if queued {
+					queueRosterChange(ctx, dataModel.RosterChangeCore{
+						Name:           player.Name,
+						GameIdentifier: player.GameIdentifier,
+						MainRoster:     player.MainRoster,
+					})
+					return
+				}
 				playerId, err := TeamDAO.CreatePlayer(getLeagueId(ctx), getTeamId(ctx), player)
-				return gin.H{"playerId": playerId}, err
+				if checkErr(ctx, err) {
+					return
+				}
+				ctx.JSON(http.StatusCreated, gin.H{"playerId": playerId})
 			},
 		}.createEndpointHandler()(ctx)
 	}
@@ -232,7 +349,12 @@ func updatePlayer() gin.HandlerFunc {
 			AccessType: Edit,
 			BindData:   func(ctx *gin.Context) bool { return bindAndCheckErr(ctx, &player) },
 			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
-				return player.ValidateEdit(getLeagueId(ctx), getTeamId(ctx), getPlayerId(ctx), TeamDAO)
+				administrator, err := isLeagueAdministrator(ctx)
+				if err != nil {
+					return false, "", err
+				}
+				return player.ValidateEdit(getLeagueId(ctx), getTeamId(ctx), getPlayerId(ctx), administrator,
+					LeagueDAO, TeamDAO)
 			},
 			Core: func(ctx *gin.Context) (interface{}, error) {
 				return nil, TeamDAO.UpdatePlayer(getPlayerId(ctx), player)
@@ -243,11 +365,298 @@ func updatePlayer() gin.HandlerFunc {
 
 // https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/deletePlayer
 func deletePlayer() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var queued bool
+		endpoint{
+			Entity:     Player,
+			AccessType: Delete,
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				administrator, err := isLeagueAdministrator(ctx)
+				if err != nil {
+					return false, "", err
+				}
+				if queued, err = isRosterChangeQueued(ctx, administrator); err != nil {
+					return false, "", err
+				}
+				return dataModel.ValidatePlayerRemoval(getLeagueId(ctx), getTeamId(ctx), getPlayerId(ctx),
+					administrator || queued, LeagueDAO, TeamDAO)
+			},
+			CustomResCore: func(ctx *gin.Context) {
+				if queued {
+					queueRosterChange(ctx, dataModel.RosterChangeCore{PlayerId: getPlayerId(ctx)})
+					return
+				}
+				if checkErr(ctx, TeamDAO.DeletePlayer(getPlayerId(ctx))) {
+					return
+				}
+				ctx.Status(http.StatusOK)
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/transferPlayer
+func transferPlayer() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var transfer dataModel.PlayerTransferCore
+		endpoint{
+			Entity:     Player,
+			AccessType: Edit,
+			BindData: func(ctx *gin.Context) bool {
+				if bindAndCheckErr(ctx, &transfer) {
+					return true
+				}
+				// The player joins the roster of the other team, so it must be editable by this user as well
+				permissions, err := UserDAO.GetUserWithPermissions(getLeagueId(ctx), getUserId(ctx))
+				if checkErr(ctx, err) {
+					return true
+				}
+				canEdit, err := Access.Team(Edit, permissions, TeamDAO, LeagueDAO, getLeagueId(ctx), transfer.TeamId)
+				return accessForbidden(ctx, canEdit, err)
+			},
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				administrator, err := isLeagueAdministrator(ctx)
+				if err != nil {
+					return false, "", err
+				}
+				return transfer.Validate(getLeagueId(ctx), getTeamId(ctx), getPlayerId(ctx), administrator,
+					LeagueDAO, TeamDAO)
+			},
+			Core: func(ctx *gin.Context) (interface{}, error) {
+				transferId, err := TeamDAO.TransferPlayer(getPlayerId(ctx), getTeamId(ctx), getUserId(ctx), transfer)
+				return gin.H{"transferId": transferId}, err
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getTeamTransfers
+func getTeamTransfers() gin.HandlerFunc {
+	return endpoint{
+		Entity:     Team,
+		AccessType: View,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return TeamDAO.GetTeamTransfers(getTeamId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getLeagueTransfers
+func getLeagueTransfers() gin.HandlerFunc {
+	return endpoint{
+		Entity:     League,
+		AccessType: View,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return TeamDAO.GetLeagueTransfers(getLeagueId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/requestToJoinTeam
+func requestToJoinTeam() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request dataModel.RosterRequestCore
+		endpoint{
+			Entity:     RosterRequest,
+			AccessType: Create,
+			BindData:   func(ctx *gin.Context) bool { return bindAndCheckErr(ctx, &request) },
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				return request.Validate(getLeagueId(ctx), getTeamId(ctx), getUserId(ctx), TeamDAO)
+			},
+			Core: func(ctx *gin.Context) (interface{}, error) {
+				requestId, err := TeamDAO.CreateRosterRequest(getTeamId(ctx), getUserId(ctx), false, request)
+				return gin.H{"requestId": requestId}, err
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/inviteToTeam
+func inviteToTeam() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var invite dataModel.RosterInviteCore
+		var userId int
+		endpoint{
+			Entity:     Team,
+			AccessType: Edit,
+			BindData: func(ctx *gin.Context) bool {
+				if bindAndCheckErr(ctx, &invite) {
+					return true
+				}
+				var err error
+				userId, err = UserDAO.GetUserIdByEmail(invite.Email)
+				return checkErr(ctx, err)
+			},
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				return invite.Validate(getLeagueId(ctx), getTeamId(ctx), userId, TeamDAO)
+			},
+			Core: func(ctx *gin.Context) (interface{}, error) {
+				requestId, err := TeamDAO.CreateRosterRequest(getTeamId(ctx), userId, true, invite.RosterRequestCore)
+				return gin.H{"requestId": requestId}, err
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getTeamRosterRequests
+func getTeamRosterRequests() gin.HandlerFunc {
 	return endpoint{
-		Entity:     Player,
+		Entity:     RosterRequest,
+		AccessType: View,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return TeamDAO.GetTeamRosterRequests(getTeamId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/approveRosterRequest
+func approveRosterRequest() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request *dataModel.RosterRequest
+		endpoint{
+			Entity:     RosterRequest,
+			AccessType: Edit,
+			BindData: func(ctx *gin.Context) bool {
+				var err error
+				request, err = TeamDAO.GetRosterRequest(getRequestId(ctx))
+				return checkErr(ctx, err)
+			},
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				administrator, err := isLeagueAdministrator(ctx)
+				if err != nil {
+					return false, "", err
+				}
+				return request.ValidateAcceptance(administrator, LeagueDAO, TeamDAO)
+			},
+			Core: func(ctx *gin.Context) (interface{}, error) {
+				playerId, err := TeamDAO.AcceptRosterRequest(getRequestId(ctx))
+				return gin.H{"playerId": playerId}, err
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/deleteRosterRequest
+func deleteRosterRequest() gin.HandlerFunc {
+	return endpoint{
+		Entity:     RosterRequest,
 		AccessType: Delete,
 		Core: func(ctx *gin.Context) (interface{}, error) {
-			return nil, TeamDAO.DeletePlayer(getPlayerId(ctx))
+			return nil, TeamDAO.DeleteRosterRequest(getRequestId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getRosterChanges
+func getRosterChanges() gin.HandlerFunc {
+	return endpoint{
+		Entity:     League,
+		AccessType: Edit,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return TeamDAO.GetRosterChanges(getLeagueId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/approveRosterChange
+func approveRosterChange() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var change *dataModel.RosterChange
+		endpoint{
+			Entity:     League,
+			AccessType: Edit,
+			BindData: func(ctx *gin.Context) bool {
+				var err error
+				change, err = TeamDAO.GetRosterChange(getLeagueId(ctx), getRosterChangeId(ctx))
+				return checkErr(ctx, err)
+			},
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				return change.ValidateApproval(LeagueDAO, TeamDAO)
+			},
+			Core: func(ctx *gin.Context) (interface{}, error) {
+				playerId, err := applyRosterChange(change)
+				if err != nil {
+					return nil, err
+				}
+				return gin.H{"playerId": playerId}, TeamDAO.DeleteRosterChange(change.RosterChangeId)
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+// Returns the id of the added or removed player
+func applyRosterChange(change *dataModel.RosterChange) (int, error) {
+	if change.PlayerId != 0 {
+		return change.PlayerId, TeamDAO.DeletePlayer(change.PlayerId)
+	} else if change.ExternalId != "" {
+		return LeagueOfLegendsDAO.CreateLoLPlayer(change.LeagueId, change.TeamId, change.ExternalId,
+			dataModel.LoLPlayerCore{
+				GameIdentifier: change.GameIdentifier,
+				MainRoster:     change.MainRoster,
+				Position:       change.Position,
+				ExternalId:     change.ExternalId,
+			})
+	}
+	return TeamDAO.CreatePlayer(change.LeagueId, change.TeamId, dataModel.PlayerCore{
+		Name:           change.Name,
+		GameIdentifier: change.GameIdentifier,
+		MainRoster:     change.MainRoster,
+	})
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/rejectRosterChange
+func rejectRosterChange() gin.HandlerFunc {
+	return endpoint{
+		Entity:     League,
+		AccessType: Edit,
+		IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+			change, err := TeamDAO.GetRosterChange(getLeagueId(ctx), getRosterChangeId(ctx))
+			if err != nil {
+				return false, "", err
+			}
+			return dataModel.ValidateRosterChangeExists(change)
+		},
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return nil, TeamDAO.DeleteRosterChange(getRosterChangeId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/createTeamBlackout
+func createTeamBlackout() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var blackout dataModel.TeamBlackoutCore
+		endpoint{
+			Entity:        Team,
+			AccessType:    Edit,
+			BindData:      func(ctx *gin.Context) bool { return bindAndCheckErr(ctx, &blackout) },
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) { return blackout.Validate() },
+			Core: func(ctx *gin.Context) (interface{}, error) {
+				blackoutId, err := TeamDAO.AddTeamBlackout(getTeamId(ctx), blackout)
+				return gin.H{"blackoutId": blackoutId}, err
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getTeamBlackouts
+func getTeamBlackouts() gin.HandlerFunc {
+	return endpoint{
+		Entity:     Team,
+		AccessType: View,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return TeamDAO.GetTeamBlackouts(getTeamId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/deleteTeamBlackout
+func deleteTeamBlackout() gin.HandlerFunc {
+	return endpoint{
+		Entity:     Team,
+		AccessType: Edit,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return nil, TeamDAO.DeleteTeamBlackout(getTeamId(ctx), getBlackoutId(ctx))
 		},
 	}.createEndpointHandler()
 }
@@ -257,12 +666,21 @@ func RegisterTeamHandlers(g *gin.RouterGroup) {
 	g.POST("/teamsWithPlayers", createNewTeamWithPlayers())
 	g.GET("/teams", getAllTeams())
 	g.GET("/teamsWithRosters", getAllTeamsWithRosters())
+	g.GET("/pendingTeams", getPendingTeams())
+	g.GET("/archivedTeams", getArchivedTeams())
+	g.GET("/transfers", getLeagueTransfers())
+	g.GET("/rosterChanges", getRosterChanges())
+	withRosterChangeId := g.Group("/rosterChanges/:rosterChangeId", storeRosterChangeId())
+	withRosterChangeId.POST("/approve", approveRosterChange())
+	withRosterChangeId.DELETE("", rejectRosterChange())
 
 	withTeamId := g.Group("/teams/:teamId", storeTeamId())
 	withTeamId.GET("", getTeamInfo())
 	withTeamId.GET("/withRosters", getTeamWithRosters())
 	withTeamId.PUT("", editTeam())
 	withTeamId.DELETE("", deleteTeam())
+	withTeamId.POST("/approve", approveTeam())
+	withTeamId.POST("/reject", rejectTeam())
 
 	withTeamId.PUT("/permissions/:userId", storeTargetUserId(), editTeamManagerPermissions()) //TODO: test this one
 
@@ -270,4 +688,17 @@ func RegisterTeamHandlers(g *gin.RouterGroup) {
 	withPlayerId := withTeamId.Group("/players/:playerId", storePlayerId())
 	withPlayerId.PUT("", updatePlayer())
 	withPlayerId.DELETE("", deletePlayer())
+	withPlayerId.POST("/transfer", transferPlayer())
+	withTeamId.GET("/transfers", getTeamTransfers())
+
+	withTeamId.POST("/rosterRequests", requestToJoinTeam())
+	withTeamId.POST("/rosterInvites", inviteToTeam())
+	withTeamId.GET("/rosterRequests", getTeamRosterRequests())
+	withRequestId := withTeamId.Group("/rosterRequests/:requestId", storeRequestId())
+	withRequestId.POST("/approve", approveRosterRequest())
+	withRequestId.DELETE("", deleteRosterRequest())
+
+	withTeamId.POST("/blackouts", createTeamBlackout())
+	withTeamId.GET("/blackouts", getTeamBlackouts())
+	withTeamId.DELETE("/blackouts/:blackoutId", storeBlackoutId(), deleteTeamBlackout())
 }
@@ -55,8 +55,60 @@ func getUserLeaguePermissions() gin.HandlerFunc {
 	}.createEndpointHandler()
 }
 
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/getUserRosterInvites
+func getUserRosterInvites() gin.HandlerFunc {
+	return endpoint{
+		Entity:     RosterInvite,
+		AccessType: View,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return TeamDAO.GetUserRosterInvites(getUserId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/acceptRosterInvite
+func acceptRosterInvite() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var invite *dataModel.RosterRequest
+		endpoint{
+			Entity:     RosterInvite,
+			AccessType: Edit,
+			BindData: func(ctx *gin.Context) bool {
+				var err error
+				invite, err = TeamDAO.GetRosterRequest(getRequestId(ctx))
+				return checkErr(ctx, err)
+			},
+			IsDataInvalid: func(ctx *gin.Context) (bool, string, error) {
+				permissions, err := UserDAO.GetUserWithPermissions(invite.LeagueId, getUserId(ctx))
+				if err != nil {
+					return false, "", err
+				}
+				return invite.ValidateAcceptance(permissions.LeaguePermissions.Administrator, LeagueDAO, TeamDAO)
+			},
+			Core: func(ctx *gin.Context) (interface{}, error) {
+				playerId, err := TeamDAO.AcceptRosterRequest(getRequestId(ctx))
+				return gin.H{"playerId": playerId}, err
+			},
+		}.createEndpointHandler()(ctx)
+	}
+}
+
+// https://artemigkh.github.io/ELM-Electronic-League-Manager/#operation/declineRosterInvite
+func declineRosterInvite() gin.HandlerFunc {
+	return endpoint{
+		Entity:     RosterInvite,
+		AccessType: Delete,
+		Core: func(ctx *gin.Context) (interface{}, error) {
+			return nil, TeamDAO.DeleteRosterRequest(getRequestId(ctx))
+		},
+	}.createEndpointHandler()
+}
+
 func RegisterUserHandlers(g *gin.RouterGroup) {
 	g.POST("", createNewUser())
 	g.GET("", getProfile())
 	g.GET("leaguePermissions", getUserLeaguePermissions())
+	g.GET("rosterInvites", getUserRosterInvites())
+	g.POST("rosterInvites/:requestId/accept", storeRequestId(), acceptRosterInvite())
+	g.DELETE("rosterInvites/:requestId", storeRequestId(), declineRosterInvite())
 }
@@ -61,3 +61,86 @@ func storePlayerId() gin.HandlerFunc {
 func storeAvailabilityId() gin.HandlerFunc {
 	return storeUrlId("availabilityId", "availabilityId")
 }
+
+func storeBlackoutId() gin.HandlerFunc {
+	return storeUrlId("blackoutId", "blackoutId")
+}
+
+func storeInviteId() gin.HandlerFunc {
+	return storeUrlId("inviteId", "inviteId")
+}
+
+func storeRequestId() gin.HandlerFunc {
+	return storeUrlId("requestId", "requestId")
+}
+
+func storeRosterChangeId() gin.HandlerFunc {
+	return storeUrlId("rosterChangeId", "rosterChangeId")
+}
+
+func storeSeasonId() gin.HandlerFunc {
+	return storeUrlId("seasonId", "seasonId")
+}
+
+// Games, standings and statistics are of the current season of the league unless another season is selected
+func storeSelectedSeasonId() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		seasonIdString := ctx.Query("seasonId")
+		if seasonIdString == "" {
+			seasonId, err := LeagueDAO.GetCurrentSeasonId(getLeagueId(ctx))
+			if checkErr(ctx, err) {
+				ctx.Abort()
+			} else {
+				ctx.Set("seasonId", seasonId)
+				ctx.Next()
+			}
+			return
+		}
+
+		seasonId, err := strconv.Atoi(seasonIdString)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "IdMustBeInteger"})
+			return
+		}
+		exists, err := LeagueDAO.DoesSeasonExistInLeague(getLeagueId(ctx), seasonId)
+		if checkErr(ctx, err) {
+			ctx.Abort()
+		} else if !exists {
+			ctx.AbortWithStatus(http.StatusNotFound)
+		} else {
+			ctx.Set("seasonId", seasonId)
+			ctx.Next()
+		}
+	}
+}
+
+func storeDivisionId() gin.HandlerFunc {
+	return storeUrlId("divisionId", "divisionId")
+}
+
+// Standings and games can be limited to a division of the league, and include every division if none is selected
+func storeSelectedDivisionId() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		divisionIdString := ctx.Query("divisionId")
+		if divisionIdString == "" {
+			ctx.Set("divisionId", 0)
+			ctx.Next()
+			return
+		}
+
+		divisionId, err := strconv.Atoi(divisionIdString)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "IdMustBeInteger"})
+			return
+		}
+		exists, err := LeagueDAO.DoesDivisionExistInLeague(getLeagueId(ctx), divisionId)
+		if checkErr(ctx, err) {
+			ctx.Abort()
+		} else if !exists {
+			ctx.AbortWithStatus(http.StatusNotFound)
+		} else {
+			ctx.Set("divisionId", divisionId)
+			ctx.Next()
+		}
+	}
+}
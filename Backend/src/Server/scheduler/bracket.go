@@ -0,0 +1,208 @@
+package scheduler
+
+const (
+	seedSource = iota
+	winnerSource
+	loserSource
+)
+
+// A slot in the full power-of-two bracket, filled either by a seed or by the result of an earlier match
+type bracketSource struct {
+	kind  int
+	seed  int
+	match int
+}
+
+type bracketMatch struct {
+	sides [2]bracketSource
+}
+
+// What a bracket slot resolves to once byes are removed: nobody, a known team, or the result of a real game
+type resolvedSource struct {
+	empty bool
+	team  int
+	from  *GameSource
+}
+
+// Standard seed order for a bracket of the given size (1 plays size, 2 plays size-1, and so on),
+// arranged so that the top seeds can only meet in the latest rounds
+func seedOrder(size int) []int {
+	order := []int{1}
+	for len(order) < size {
+		next := make([]int, 0, len(order)*2)
+		for _, seed := range order {
+			next = append(next, seed, len(order)*2+1-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+func bracketSize(numTeams int) int {
+	size := 1
+	for size < numTeams {
+		size *= 2
+	}
+	return size
+}
+
+func winnerOf(match int) bracketSource {
+	return bracketSource{kind: winnerSource, match: match}
+}
+
+func loserOf(match int) bracketSource {
+	return bracketSource{kind: loserSource, match: match}
+}
+
+// Pairs up sources in order, appending one match per pair and returning the indices of the new matches
+func pairSources(matches *[]bracketMatch, sources []bracketSource) []int {
+	var indices []int
+	for i := 0; i+1 < len(sources); i += 2 {
+		*matches = append(*matches, bracketMatch{[2]bracketSource{sources[i], sources[i+1]}})
+		indices = append(indices, len(*matches)-1)
+	}
+	return indices
+}
+
+// Creates the winners bracket and returns the match indices of each of its rounds
+func winnersBracket(matches *[]bracketMatch, size int) [][]int {
+	var sources []bracketSource
+	for _, seed := range seedOrder(size) {
+		sources = append(sources, bracketSource{kind: seedSource, seed: seed - 1})
+	}
+
+	var rounds [][]int
+	for len(sources) > 1 {
+		round := pairSources(matches, sources)
+		rounds = append(rounds, round)
+		sources = make([]bracketSource, 0)
+		for _, match := range round {
+			sources = append(sources, winnerOf(match))
+		}
+	}
+	return rounds
+}
+
+func singleEliminationMatches(size int) []bracketMatch {
+	var matches []bracketMatch
+	winnersBracket(&matches, size)
+	return matches
+}
+
+func doubleEliminationMatches(size int) []bracketMatch {
+	var matches []bracketMatch
+	winnerRounds := winnersBracket(&matches, size)
+
+	// With only one winners round there is no losers bracket; the loser goes straight to the grand final
+	lowerChampion := loserOf(winnerRounds[0][0])
+	if len(winnerRounds) > 1 {
+		// First losers round pairs up the losers of the first winners round
+		var dropped []bracketSource
+		for _, match := range winnerRounds[0] {
+			dropped = append(dropped, loserOf(match))
+		}
+		survivors := pairSources(&matches, dropped)
+
+		for r := 1; r < len(winnerRounds); r++ {
+			// Survivors of the losers bracket play the teams dropping from this winners round. The order of
+			// the dropping teams is reversed every other round to avoid immediate rematches
+			var round []int
+			for i, survivor := range survivors {
+				dropIndex := i
+				if r%2 == 1 {
+					dropIndex = len(winnerRounds[r]) - 1 - i
+				}
+				matches = append(matches, bracketMatch{[2]bracketSource{
+					winnerOf(survivor),
+					loserOf(winnerRounds[r][dropIndex]),
+				}})
+				round = append(round, len(matches)-1)
+			}
+
+			// Then the winners of those matches play each other, unless only one is left
+			if len(round) > 1 {
+				var sources []bracketSource
+				for _, match := range round {
+					sources = append(sources, winnerOf(match))
+				}
+				round = pairSources(&matches, sources)
+			}
+			survivors = round
+		}
+		lowerChampion = winnerOf(survivors[0])
+	}
+
+	upperChampion := winnerOf(winnerRounds[len(winnerRounds)-1][0])
+	matches = append(matches, bracketMatch{[2]bracketSource{upperChampion, lowerChampion}})
+	return matches
+}
+
+// Converts the full bracket into the list of games that actually have to be played. Matches missing a side
+// because of byes are removed and the present side is advanced directly to the next match
+func resolveBracket(matches []bracketMatch, teams []int) []sgame {
+	var games []sgame
+	winners := make([]resolvedSource, len(matches))
+	losers := make([]resolvedSource, len(matches))
+
+	resolve := func(source bracketSource) resolvedSource {
+		switch source.kind {
+		case seedSource:
+			if source.seed < len(teams) {
+				return resolvedSource{team: teams[source.seed]}
+			}
+			return resolvedSource{empty: true}
+		case winnerSource:
+			return winners[source.match]
+		default:
+			return losers[source.match]
+		}
+	}
+
+	for i, match := range matches {
+		side1 := resolve(match.sides[0])
+		side2 := resolve(match.sides[1])
+		if side1.empty || side2.empty {
+			if side1.empty {
+				winners[i] = side2
+			} else {
+				winners[i] = side1
+			}
+			losers[i] = resolvedSource{empty: true}
+			continue
+		}
+
+		// A game is played in the round after the latest of the games that feed into it
+		round := 1
+		for _, side := range []resolvedSource{side1, side2} {
+			if side.from != nil && games[side.from.GameIndex].round+1 > round {
+				round = games[side.from.GameIndex].round + 1
+			}
+		}
+
+		games = append(games, sgame{
+			team1:     side1.team,
+			team2:     side2.team,
+			round:     round,
+			team1From: side1.from,
+			team2From: side2.from,
+		})
+		winners[i] = resolvedSource{from: &GameSource{GameIndex: len(games) - 1, Winner: true}}
+		losers[i] = resolvedSource{from: &GameSource{GameIndex: len(games) - 1, Winner: false}}
+	}
+	return games
+}
+
+// teams must be given in seed order, the first team being the top seed
+func singleElimination(teams []int) []sgame {
+	if len(teams) < 2 {
+		return nil
+	}
+	return resolveBracket(singleEliminationMatches(bracketSize(len(teams))), teams)
+}
+
+func doubleElimination(teams []int) []sgame {
+	if len(teams) < 2 {
+		return nil
+	}
+	return resolveBracket(doubleEliminationMatches(bracketSize(len(teams))), teams)
+}
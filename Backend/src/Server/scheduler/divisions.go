@@ -0,0 +1,60 @@
+package scheduler
+
+// Round robin tournaments of a league with divisions are played within each division. Teams can also play a
+// number of games against teams of other divisions
+func (s *Scheduler) SetDivisions(divisions [][]int, crossDivisionGames int) {
+	s.divisions = divisions
+	s.crossDivisionGames = crossDivisionGames
+}
+
+func (s *Scheduler) getDivisionGames() []sgame {
+	var requiredGames []sgame
+	for _, division := range s.divisions {
+		requiredGames = append(requiredGames, getRequiredGames(s.tournamentType, division)...)
+	}
+	return append(requiredGames, crossDivision(s.divisions, s.crossDivisionGames)...)
+}
+
+// Pairs teams of different divisions round by round, so that every team plays up to gamesPerTeam games against
+// other divisions without rematches. Teams are interleaved across divisions and each round pairs every team with
+// the next free team further along, shifting by one each round so that opponents vary. A team can be left without
+// an opponent in a round if the number of teams is odd or every remaining team is in its division
+func crossDivision(divisions [][]int, gamesPerTeam int) []sgame {
+	division := make(map[int]int)
+	var teams []int
+	for i := 0; ; i++ {
+		added := false
+		for d, members := range divisions {
+			if i < len(members) {
+				division[members[i]] = d
+				teams = append(teams, members[i])
+				added = true
+			}
+		}
+		if !added {
+			break
+		}
+	}
+
+	var requiredGames []sgame
+	played := make(map[[2]int]bool)
+	for round := 0; round < gamesPerTeam; round++ {
+		paired := make(map[int]bool)
+		for i, team := range teams {
+			if paired[team] {
+				continue
+			}
+			for offset := 1; offset < len(teams); offset++ {
+				opponent := teams[(i+round+offset)%len(teams)]
+				if paired[opponent] || division[opponent] == division[team] || played[pairKey(team, opponent)] {
+					continue
+				}
+				paired[team], paired[opponent] = true, true
+				played[pairKey(team, opponent)] = true
+				requiredGames = append(requiredGames, sgame{team1: team, team2: opponent})
+				break
+			}
+		}
+	}
+	return requiredGames
+}
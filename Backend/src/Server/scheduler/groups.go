@@ -0,0 +1,51 @@
+package scheduler
+
+// Orders the teams advancing from a group stage into a bracket, given the teams of each group ranked by their
+// group standings. Group winners are seeded first, then runners-up and so on, with teams of earlier groups seeded
+// higher within each tier. Teams of the same group are then kept apart in the first round where possible by
+// swapping seeds within a tier, so that with two groups 1A plays 2B and 1B plays 2A
+func (s *Scheduler) SeedGroups(groups [][]int, advancing int) []int {
+	var seeds, group, tier []int
+	for rank := 0; rank < advancing; rank++ {
+		for g, teams := range groups {
+			if rank < len(teams) {
+				seeds = append(seeds, teams[rank])
+				group = append(group, g)
+				tier = append(tier, rank)
+			}
+		}
+	}
+
+	// First round pairs of seed indices; seeds paired with a bye can not clash
+	order := seedOrder(bracketSize(len(seeds)))
+	opponent := make(map[int]int)
+	for i := 0; i+1 < len(order); i += 2 {
+		seed1, seed2 := order[i]-1, order[i+1]-1
+		if seed1 < len(seeds) && seed2 < len(seeds) {
+			opponent[seed1], opponent[seed2] = seed2, seed1
+		}
+	}
+	clashes := func(seed int) bool {
+		other, ok := opponent[seed]
+		return ok && group[seed] == group[other]
+	}
+
+	for seed := range seeds {
+		if !clashes(seed) || seed < opponent[seed] {
+			continue
+		}
+		for swap := range seeds {
+			if swap == seed || tier[swap] != tier[seed] {
+				continue
+			}
+			seeds[seed], seeds[swap] = seeds[swap], seeds[seed]
+			group[seed], group[swap] = group[swap], group[seed]
+			if !clashes(seed) && !clashes(swap) {
+				break
+			}
+			seeds[seed], seeds[swap] = seeds[swap], seeds[seed]
+			group[seed], group[swap] = group[swap], group[seed]
+		}
+	}
+	return seeds
+}
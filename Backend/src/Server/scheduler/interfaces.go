@@ -3,19 +3,34 @@ package scheduler
 import "time"
 
 const (
-	RoundRobin       = iota
-	DoubleRoundRobin = iota
+	RoundRobin        = iota
+	DoubleRoundRobin  = iota
+	SingleElimination = iota
+	DoubleElimination = iota
+	Swiss             = iota
 )
 
+// Points to an earlier game in the same schedule whose winner or loser fills a team slot
+type GameSource struct {
+	GameIndex int
+	Winner    bool
+}
+
 type Game struct {
-	Team1Id  int
-	Team2Id  int
-	GameTime int
+	Team1Id   int
+	Team2Id   int
+	GameTime  int
+	Round     int
+	Team1From *GameSource
+	Team2From *GameSource
 }
 
 var tournamentStringToEnum = map[string]int{
-	"roundrobin":       RoundRobin,
-	"doubleroundrobin": DoubleRoundRobin,
+	"roundrobin":        RoundRobin,
+	"doubleroundrobin":  DoubleRoundRobin,
+	"singleelimination": SingleElimination,
+	"doubleelimination": DoubleElimination,
+	"swiss":             Swiss,
 }
 
 var weekdays = map[string]time.Weekday{
@@ -34,9 +49,17 @@ type IScheduler interface {
 
 	InitScheduler(tournamentType, roundsPerWeek, concurrentGameNum int, gameDuration time.Duration, start, end time.Time, teams []int)
 	AddWeeklyAvailability(dayOfWeek time.Weekday, hour, minute int, duration time.Duration)
+	AddAvailability(start, end time.Time)
+	AddTeamUnavailability(teamId int, start, end time.Time)
+	AddTeamRecord(teamId, wins, losses int)
+	AddPlayedGame(team1Id, team2Id int)
+	SetDivisions(divisions [][]int, crossDivisionGames int)
+	SeedGroups(groups [][]int, advancing int) []int
 	GetSchedule() ([]Game, error)
 
 	IsTournamentTypeSupported(tournament string) bool
+	IsBracketTournament(tournamentType int) bool
+	IsRoundByRoundTournament(tournament string) bool
 }
 
 type GameBlock struct {
@@ -46,13 +69,30 @@ type GameBlock struct {
 	teams    []int
 }
 
+type unavailability struct {
+	start time.Time
+	end   time.Time
+}
+
+type teamRecord struct {
+	wins   int
+	losses int
+}
+
 type Scheduler struct {
-	tournamentType    int
-	roundsPerWeek     int
-	concurrentGameNum int
-	gameDuration      time.Duration
-	start             time.Time
-	end               time.Time
-	teams             []int
-	gameBlocks        []*GameBlock
+	tournamentType     int
+	roundsPerWeek      int
+	concurrentGameNum  int
+	gameDuration       time.Duration
+	start              time.Time
+	end                time.Time
+	teams              []int
+	gameBlocks         []*GameBlock
+	unavailabilities   map[int][]unavailability
+	records            map[int]teamRecord
+	playedGames        map[[2]int]int
+	playedGameCount    int
+	gamesPlayed        map[int]int
+	divisions          [][]int
+	crossDivisionGames int
 }
@@ -3,7 +3,6 @@ package scheduler
 import (
 	"fmt"
 	"github.com/pkg/errors"
-	"math"
 	"sort"
 	"strings"
 	"time"
@@ -17,6 +16,15 @@ func (s *Scheduler) IsTournamentTypeSupported(tournament string) bool {
 	return ok
 }
 
+func (s *Scheduler) IsBracketTournament(tournamentType int) bool {
+	return tournamentType == SingleElimination || tournamentType == DoubleElimination
+}
+
+// Swiss tournaments can only be generated one round at a time since pairings depend on previous results
+func (s *Scheduler) IsRoundByRoundTournament(tournament string) bool {
+	return tournamentStringToEnum[strings.ToLower(tournament)] == Swiss
+}
+
 func (s *Scheduler) GetWeekdayFromString(weekday string) time.Weekday {
 	return weekdays[weekday]
 }
@@ -31,46 +39,113 @@ func (s *Scheduler) InitScheduler(tournamentType, roundsPerWeek, concurrentGameN
 	s.start = start
 	s.end = end
 	s.teams = teams
+	s.unavailabilities = make(map[int][]unavailability)
+	s.records = make(map[int]teamRecord)
+	s.playedGames = make(map[[2]int]int)
+	s.playedGameCount = 0
+	s.gamesPlayed = make(map[int]int)
+	s.divisions = nil
+	s.crossDivisionGames = 0
+}
+
+// Records are used to pair teams in tournaments that are generated one round at a time
+func (s *Scheduler) AddTeamRecord(teamId, wins, losses int) {
+	s.records[teamId] = teamRecord{wins, losses}
+}
+
+// Played games are used to avoid rematches in tournaments that are generated one round at a time. They are added
+// in the order they were played, so that a rematch can be made between the teams that met the longest time ago
+func (s *Scheduler) AddPlayedGame(team1Id, team2Id int) {
+	s.playedGameCount++
+	s.playedGames[pairKey(team1Id, team2Id)] = s.playedGameCount
+	s.gamesPlayed[team1Id]++
+	s.gamesPlayed[team2Id]++
 }
 
 func leq(t1, t2 time.Time) bool {
 	return t1.Before(t2) || t1.Equal(t2)
 }
 
+// Weekly availabilities are expanded in the location of the league start, so that they keep the same wall clock
+// time across daylight saving time transitions
 func (s *Scheduler) AddWeeklyAvailability(dayOfWeek time.Weekday, hour, minute int, duration time.Duration) {
 	fmt.Printf("League start: %v\n", s.start.Format(time.UnixDate))
-	weekCursor := time.Time(s.start)
-	weekCursor = time.Date(
-		weekCursor.Year(),
-		weekCursor.Month(),
-		weekCursor.Day(),
+	weekCursor := time.Date(
+		s.start.Year(),
+		s.start.Month(),
+		s.start.Day(),
 		hour,
 		minute,
 		0,
 		0,
-		weekCursor.Location(),
+		s.start.Location(),
 	)
 	for weekCursor.Weekday() != dayOfWeek {
-		weekCursor = weekCursor.Add(time.Hour * 24)
+		weekCursor = weekCursor.AddDate(0, 0, 1)
 	}
-	fmt.Printf("week cursor start: %v\n", s.start.Format(time.UnixDate))
+	fmt.Printf("week cursor start: %v\n", weekCursor.Format(time.UnixDate))
 	fmt.Printf("League end: %v\n", s.end.Format(time.UnixDate))
 	for weekCursor.Before(s.end) {
-		blockCursor := weekCursor.Add(time.Hour*time.Duration(hour) + time.Minute*time.Duration(minute))
-		for leq(blockCursor.Add(s.gameDuration), weekCursor.Add(time.Hour*time.Duration(hour)+
-			time.Minute*time.Duration(minute)+duration)) {
-
-			s.gameBlocks = append(s.gameBlocks, &GameBlock{
-				blockCursor,
-				blockCursor.Add(s.gameDuration), 0,
-				s.teams,
-			})
+		blockCursor := weekCursor
+		for leq(blockCursor.Add(s.gameDuration), weekCursor.Add(duration)) {
+			if !blockCursor.Before(s.start) {
+				s.gameBlocks = append(s.gameBlocks, &GameBlock{
+					blockCursor,
+					blockCursor.Add(s.gameDuration), 0,
+					s.teams,
+				})
+			}
 			blockCursor = blockCursor.Add(s.gameDuration)
 		}
 
 		weekCursor = weekCursor.AddDate(0, 0, 7)
 		fmt.Printf("week cursor current: %v\n", weekCursor.Format(time.UnixDate))
-		fmt.Printf("League end: %v\n", s.end.Format(time.UnixDate))
+	}
+}
+
+// Splits a one-off availability window into as many consecutive game blocks as fit inside it. The window is
+// clipped to the competition period, so no game is scheduled before the league starts or after it ends
+func (s *Scheduler) AddAvailability(start, end time.Time) {
+	if start.Before(s.start) {
+		start = s.start
+	}
+	if end.After(s.end) {
+		end = s.end
+	}
+	blockCursor := start
+	for leq(blockCursor.Add(s.gameDuration), end) {
+		s.gameBlocks = append(s.gameBlocks, &GameBlock{
+			blockCursor,
+			blockCursor.Add(s.gameDuration), 0,
+			s.teams,
+		})
+		blockCursor = blockCursor.Add(s.gameDuration)
+	}
+}
+
+// Teams are left out of every game block that overlaps with one of their unavailable periods
+func (s *Scheduler) AddTeamUnavailability(teamId int, start, end time.Time) {
+	s.unavailabilities[teamId] = append(s.unavailabilities[teamId], unavailability{start, end})
+}
+
+func (s *Scheduler) isTeamAvailable(teamId int, block *GameBlock) bool {
+	for _, period := range s.unavailabilities[teamId] {
+		if period.start.Before(block.End) && block.Start.Before(period.end) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Scheduler) applyTeamUnavailabilities() {
+	for _, block := range s.gameBlocks {
+		teams := make([]int, 0, len(s.teams))
+		for _, team := range s.teams {
+			if s.isTeamAvailable(team, block) {
+				teams = append(teams, team)
+			}
+		}
+		block.teams = teams
 	}
 }
 
@@ -95,6 +170,7 @@ func (s *Scheduler) GetSchedule() ([]Game, error) {
 	if len(s.gameBlocks) == 0 {
 		return nil, errors.New("Zero Available Game Blocks")
 	}
+	s.applyTeamUnavailabilities()
 
 	// split up game blocks into weeks
 	weekGameBlocks := make([][]*GameBlock, 0)
@@ -119,96 +195,109 @@ func (s *Scheduler) GetSchedule() ([]Game, error) {
 		}
 	}
 
-	requiredGames := getRequiredGames(s.tournamentType, s.teams)
+	var requiredGames []sgame
+	if s.tournamentType == Swiss {
+		var err error
+		if requiredGames, err = s.swissRound(); err != nil {
+			return nil, err
+		}
+	} else if len(s.divisions) > 0 && !s.IsBracketTournament(s.tournamentType) {
+		requiredGames = s.getDivisionGames()
+	} else {
+		requiredGames = getRequiredGames(s.tournamentType, s.teams)
+	}
 	if len(requiredGames) > len(s.gameBlocks)*s.concurrentGameNum {
 		return nil, errors.New(fmt.Sprintf("Number of available game blocks (%v) is "+
 			"less than number of required games (%v).", len(s.gameBlocks)*s.concurrentGameNum, len(requiredGames)))
 	}
 
-	var games []Game
+	if s.IsBracketTournament(s.tournamentType) || s.tournamentType == Swiss {
+		return s.getRoundSchedule(requiredGames, weekGameBlocks)
+	}
 
-	if s.roundsPerWeek <= 0 {
-		for _, game := range requiredGames {
-			scheduled := false
-			for i, block := range s.gameBlocks {
-				if in(game.team1, block.teams) && in(game.team2, block.teams) {
-					scheduled = true
-					games = append(games, Game{game.team1, game.team2, int(block.Start.Unix())})
-					block.NumGames += 1
-
-					if block.NumGames >= s.concurrentGameNum {
-						s.gameBlocks = append(s.gameBlocks[:i], s.gameBlocks[i+1:]...)
-					}
-					break
-				}
-			}
-			if !scheduled {
-				return nil, errors.New("Scheduling failed due to constraints")
-			}
-		}
-	} else {
+	return s.getSearchSchedule(requiredGames, weekGameBlocks)
+}
 
-		gameIndex := 0
+// Known teams must be available in the block; teams of games decided by earlier results are not known yet
+func bracketGameFits(game sgame, block *GameBlock) bool {
+	return (game.team1From != nil || in(game.team1, block.teams)) &&
+		(game.team2From != nil || in(game.team2, block.teams))
+}
 
-		gamesPerRound := int(math.Ceil(float64(len(s.teams)) / 2))
-		gamesPerWeek := gamesPerRound * s.roundsPerWeek
-		totalRounds := len(requiredGames) / gamesPerRound
-		totalWeeks := totalRounds / s.roundsPerWeek
+// Schedules games round by round, so that every game starts after all the games of the previous round (which
+// in brackets feed into it) have ended. The returned games are in the same order as requiredGames so that
+// their sources stay valid
+func (s *Scheduler) getRoundSchedule(requiredGames []sgame, weekGameBlocks [][]*GameBlock) ([]Game, error) {
+	type weekBlock struct {
+		week  int
+		block *GameBlock
+	}
+	var blocks []weekBlock
+	for week, weekBlocks := range weekGameBlocks {
+		for _, block := range weekBlocks {
+			blocks = append(blocks, weekBlock{week, block})
+		}
+	}
 
-		if totalWeeks > len(weekGameBlocks) {
-			return nil, errors.New(fmt.Sprintf("Number of required weeks(%v) "+
-				"for specified number of rounds per week is larger than amount of available weeks(%v)",
-				totalWeeks, len(weekGameBlocks)))
+	var rounds []int
+	for _, game := range requiredGames {
+		if !in(game.round, rounds) {
+			rounds = append(rounds, game.round)
 		}
+	}
+	sort.Ints(rounds)
 
-		for weekNum, weekBlocks := range weekGameBlocks {
-			if gamesPerWeek > len(weekBlocks) {
-				return nil, errors.New(fmt.Sprintf("Number of game blocks(%v) on week %v is"+
-					"smaller than number of required games for week %v (%v)",
-					len(weekBlocks), weekNum+1, weekNum+1, gamesPerWeek))
+	games := make([]Game, len(requiredGames))
+	earliestStart := s.start
+	earliestWeek := 0
+	roundsThisWeek := 0
+	for _, round := range rounds {
+		roundEnd := earliestStart
+		lastWeek := earliestWeek
+		for i, game := range requiredGames {
+			if game.round != round {
+				continue
 			}
 
-			for i := 0; i < gamesPerWeek; i++ {
-				// schedule gamesPerWeek sequential games in a row
-				gameToSchedule := requiredGames[gameIndex]
-				scheduled := false
-				for i, block := range weekBlocks {
-					if in(gameToSchedule.team1, block.teams) && in(gameToSchedule.team2, block.teams) {
-						scheduled = true
-						gameIndex++
-						games = append(games, Game{
-							gameToSchedule.team1,
-							gameToSchedule.team2,
-							int(block.Start.Unix())})
-						block.NumGames += 1
-
-						if block.NumGames >= s.concurrentGameNum {
-							weekBlocks = append(weekBlocks[:i], weekBlocks[i+1:]...)
-						}
-						break
-					}
+			scheduled := false
+			for _, wb := range blocks {
+				if wb.week < earliestWeek || wb.block.Start.Before(earliestStart) ||
+					wb.block.NumGames >= s.concurrentGameNum || !bracketGameFits(game, wb.block) {
+					continue
 				}
-				if !scheduled {
-					return nil, errors.New(fmt.Sprintf("Scheduling failed on week %v due to constraints", weekNum+1))
+				scheduled = true
+				wb.block.NumGames += 1
+				games[i] = Game{
+					Team1Id:   game.team1,
+					Team2Id:   game.team2,
+					GameTime:  int(wb.block.Start.Unix()),
+					Round:     game.round,
+					Team1From: game.team1From,
+					Team2From: game.team2From,
 				}
-				if gameIndex >= len(requiredGames) {
-					break
+				if wb.block.End.After(roundEnd) {
+					roundEnd = wb.block.End
+				}
+				if wb.week > lastWeek {
+					lastWeek = wb.week
 				}
-
-				//games = append(games, Game{
-				//	requiredGames[gameIndex].team1,
-				//	requiredGames[gameIndex].team2,
-				//	weekBlocks[blockIndex].Start.Unix()})
-				//gameIndex++
-				//if gameIndex >= len(requiredGames) {
-				//	break
-				//}
-				//
-				//blockIndex++
-			}
-			if gameIndex >= len(requiredGames) {
 				break
 			}
+			if !scheduled {
+				return nil, errors.New(fmt.Sprintf("Not enough game blocks to schedule round %v", round))
+			}
+		}
+
+		// The next round can start once every game of this round is over, limited to roundsPerWeek rounds a week
+		earliestStart = roundEnd
+		if lastWeek > earliestWeek {
+			roundsThisWeek = 0
+		}
+		earliestWeek = lastWeek
+		roundsThisWeek++
+		if s.roundsPerWeek > 0 && roundsThisWeek >= s.roundsPerWeek {
+			earliestWeek++
+			roundsThisWeek = 0
 		}
 	}
 
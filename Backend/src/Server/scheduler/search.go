@@ -0,0 +1,488 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Hard constraints a game block can violate for a game
+const (
+	TeamAvailabilityConstraint   = "team availability"
+	ConcurrentGamesConstraint    = "concurrent games"
+	TeamAlreadyPlayingConstraint = "team already playing"
+	RoundsPerWeekConstraint      = "rounds per week"
+)
+
+const (
+	// Bounds the backtracking so that infeasible leagues fail in reasonable time
+	maxSearchSteps = 50000
+	// Passes of local search done on the first feasible schedule to improve the soft objectives
+	maxImprovementPasses = 10
+
+	spreadWeight     = 1
+	backToBackWeight = 3
+)
+
+// Reports the game that could not be placed in any game block, and the constraint that excluded the most blocks.
+// A team that can not play all of its games in the game blocks available to it is reported with TeamId instead
+type InfeasibleError struct {
+	Constraint string
+	Team1Id    int
+	Team2Id    int
+	Rejections map[string]int
+	TeamId     int
+	Capacity   int
+	Required   int
+}
+
+func (e *InfeasibleError) Error() string {
+	if e.TeamId != 0 {
+		return fmt.Sprintf("Scheduling failed due to the %v constraint: team %v can play at most %v of its %v games",
+			e.Constraint, e.TeamId, e.Capacity, e.Required)
+	}
+	var counts []string
+	for _, constraint := range []string{TeamAvailabilityConstraint, ConcurrentGamesConstraint,
+		TeamAlreadyPlayingConstraint, RoundsPerWeekConstraint} {
+		if e.Rejections[constraint] > 0 {
+			counts = append(counts, fmt.Sprintf("%v: %v", constraint, e.Rejections[constraint]))
+		}
+	}
+	return fmt.Sprintf("Scheduling failed due to the %v constraint: no game block fits the game between "+
+		"teams %v and %v (game blocks excluded by %v)", e.Constraint, e.Team1Id, e.Team2Id, strings.Join(counts, ", "))
+}
+
+// Teams are numbered by their position in teamIds so that the state of the search is kept in slices
+type search struct {
+	s             *Scheduler
+	games         []sgame
+	blocks        []*GameBlock
+	weeks         []int
+	teamIds       []int
+	gameTeams     [][2]int
+	blockTeams    [][]bool
+	overlapping   [][]int
+	gameBlocks    [][]int
+	teamGames     [][]int
+	domains       []int
+	assigned      []int
+	teamBlocks    [][]int
+	teamBusy      [][]int
+	teamWeekGames [][]int
+	steps         int
+	deepest       int
+	failure       *InfeasibleError
+}
+
+func newSearch(s *Scheduler, games []sgame, weekGameBlocks [][]*GameBlock) *search {
+	srch := &search{
+		s:        s,
+		games:    games,
+		domains:  make([]int, len(games)),
+		assigned: make([]int, len(games)),
+		deepest:  -1,
+	}
+	for week, weekBlocks := range weekGameBlocks {
+		for _, block := range weekBlocks {
+			srch.blocks = append(srch.blocks, block)
+			srch.weeks = append(srch.weeks, week)
+		}
+	}
+	for i := range srch.assigned {
+		srch.assigned[i] = -1
+	}
+
+	teamIndex := make(map[int]int)
+	addTeam := func(team int) int {
+		if index, ok := teamIndex[team]; ok {
+			return index
+		}
+		teamIndex[team] = len(srch.teamIds)
+		srch.teamIds = append(srch.teamIds, team)
+		srch.teamGames = append(srch.teamGames, nil)
+		srch.teamBlocks = append(srch.teamBlocks, nil)
+		srch.teamBusy = append(srch.teamBusy, make([]int, len(srch.blocks)))
+		srch.teamWeekGames = append(srch.teamWeekGames, make([]int, len(weekGameBlocks)))
+		return teamIndex[team]
+	}
+	for _, team := range s.teams {
+		addTeam(team)
+	}
+	for gameIndex, game := range games {
+		teams := [2]int{addTeam(game.team1), addTeam(game.team2)}
+		srch.gameTeams = append(srch.gameTeams, teams)
+		for _, team := range teams {
+			srch.teamGames[team] = append(srch.teamGames[team], gameIndex)
+		}
+	}
+
+	// Block availability and overlaps are looked up for every candidate, so they are worked out once
+	for _, block := range srch.blocks {
+		teams := make([]bool, len(srch.teamIds))
+		for _, team := range block.teams {
+			if index, ok := teamIndex[team]; ok {
+				teams[index] = true
+			}
+		}
+		srch.blockTeams = append(srch.blockTeams, teams)
+
+		var overlapping []int
+		for otherIndex, other := range srch.blocks {
+			if other.Start.Before(block.End) && block.Start.Before(other.End) {
+				overlapping = append(overlapping, otherIndex)
+			}
+		}
+		srch.overlapping = append(srch.overlapping, overlapping)
+	}
+
+	// Games only ever fit in the blocks both of their teams are available in
+	for gameIndex, teams := range srch.gameTeams {
+		var blocks []int
+		for blockIndex := range srch.blocks {
+			if srch.blockTeams[blockIndex][teams[0]] && srch.blockTeams[blockIndex][teams[1]] {
+				blocks = append(blocks, blockIndex)
+			}
+		}
+		srch.gameBlocks = append(srch.gameBlocks, blocks)
+		srch.domains[gameIndex] = len(blocks)
+	}
+	return srch
+}
+
+// Returns the hard constraint that keeps the game out of the block, or an empty string if it fits
+func (srch *search) violation(gameIndex, blockIndex int) string {
+	teams := srch.gameTeams[gameIndex]
+	if !srch.blockTeams[blockIndex][teams[0]] || !srch.blockTeams[blockIndex][teams[1]] {
+		return TeamAvailabilityConstraint
+	}
+	if srch.blocks[blockIndex].NumGames >= srch.s.concurrentGameNum {
+		return ConcurrentGamesConstraint
+	}
+	if srch.teamBusy[teams[0]][blockIndex] > 0 || srch.teamBusy[teams[1]][blockIndex] > 0 {
+		return TeamAlreadyPlayingConstraint
+	}
+	if srch.s.roundsPerWeek > 0 {
+		week := srch.weeks[blockIndex]
+		if srch.teamWeekGames[teams[0]][week] >= srch.s.roundsPerWeek ||
+			srch.teamWeekGames[teams[1]][week] >= srch.s.roundsPerWeek {
+			return RoundsPerWeekConstraint
+		}
+	}
+	return ""
+}
+
+// The increase of the soft objective if the game is played in the block: games of a team are spread evenly
+// across weeks by penalizing the square of its games in a week, and games soon after another are penalized
+func (srch *search) cost(gameIndex, blockIndex int) int {
+	block := srch.blocks[blockIndex]
+	cost := 0
+	for _, team := range srch.gameTeams[gameIndex] {
+		cost += spreadWeight * (2*srch.teamWeekGames[team][srch.weeks[blockIndex]] + 1)
+		for _, other := range srch.teamBlocks[team] {
+			if isBackToBack(srch.blocks[other], block, srch.s.gameDuration) {
+				cost += backToBackWeight
+			}
+		}
+	}
+	return cost
+}
+
+func isBackToBack(block1, block2 *GameBlock, gameDuration time.Duration) bool {
+	return block2.Start.Sub(block1.End) < gameDuration && block1.Start.Sub(block2.End) < gameDuration
+}
+
+func (srch *search) place(gameIndex, blockIndex int) {
+	srch.assigned[gameIndex] = blockIndex
+	srch.blocks[blockIndex].NumGames += 1
+	for _, team := range srch.gameTeams[gameIndex] {
+		srch.teamBlocks[team] = append(srch.teamBlocks[team], blockIndex)
+		srch.teamWeekGames[team][srch.weeks[blockIndex]]++
+		for _, other := range srch.overlapping[blockIndex] {
+			srch.teamBusy[team][other]++
+		}
+	}
+}
+
+func (srch *search) unplace(gameIndex int) {
+	blockIndex := srch.assigned[gameIndex]
+	srch.assigned[gameIndex] = -1
+	srch.blocks[blockIndex].NumGames -= 1
+	for _, team := range srch.gameTeams[gameIndex] {
+		for i, other := range srch.teamBlocks[team] {
+			if other == blockIndex {
+				srch.teamBlocks[team] = append(srch.teamBlocks[team][:i], srch.teamBlocks[team][i+1:]...)
+				break
+			}
+		}
+		srch.teamWeekGames[team][srch.weeks[blockIndex]]--
+		for _, other := range srch.overlapping[blockIndex] {
+			srch.teamBusy[team][other]--
+		}
+	}
+}
+
+// Placing or removing a game changes the feasible blocks of the other games of its teams, and of every game once
+// the block fills up or has room again
+func (srch *search) updateDomains(gameIndex int, blockFull bool) {
+	if blockFull {
+		for other := range srch.games {
+			if srch.assigned[other] == -1 {
+				srch.domains[other] = srch.feasibleCount(other)
+			}
+		}
+		return
+	}
+	for _, team := range srch.gameTeams[gameIndex] {
+		for _, other := range srch.teamGames[team] {
+			if srch.assigned[other] == -1 {
+				srch.domains[other] = srch.feasibleCount(other)
+			}
+		}
+	}
+}
+
+// Feasible blocks for the game ordered by cost, earlier blocks first on equal cost
+func (srch *search) candidates(gameIndex int) ([]int, map[string]int) {
+	var candidates []int
+	costs := make(map[int]int)
+	rejections := make(map[string]int)
+	for blockIndex := range srch.blocks {
+		if constraint := srch.violation(gameIndex, blockIndex); constraint != "" {
+			rejections[constraint]++
+		} else {
+			candidates = append(candidates, blockIndex)
+			costs[blockIndex] = srch.cost(gameIndex, blockIndex)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return costs[candidates[i]] < costs[candidates[j]]
+	})
+	return candidates, rejections
+}
+
+func (srch *search) feasibleCount(gameIndex int) int {
+	count := 0
+	for _, blockIndex := range srch.gameBlocks[gameIndex] {
+		if srch.violation(gameIndex, blockIndex) == "" {
+			count++
+		}
+	}
+	return count
+}
+
+// Remembers why the game could not be placed if the search got further than it has before
+func (srch *search) fail(gameIndex, placed int) {
+	if placed <= srch.deepest {
+		return
+	}
+	srch.deepest = placed
+	_, rejections := srch.candidates(gameIndex)
+	srch.failure = &InfeasibleError{
+		Team1Id:    srch.games[gameIndex].team1,
+		Team2Id:    srch.games[gameIndex].team2,
+		Rejections: rejections,
+	}
+	for _, constraint := range []string{TeamAvailabilityConstraint, ConcurrentGamesConstraint,
+		TeamAlreadyPlayingConstraint, RoundsPerWeekConstraint} {
+		if rejections[constraint] > rejections[srch.failure.Constraint] {
+			srch.failure.Constraint = constraint
+		}
+	}
+}
+
+// Places the game with the fewest feasible blocks next, with backtracking. A branch is abandoned as soon as a game
+// that is not placed yet is left without any feasible block
+func (srch *search) backtrack(placed int) bool {
+	if placed == len(srch.games) {
+		return true
+	}
+	srch.steps++
+	if srch.steps > maxSearchSteps {
+		return false
+	}
+
+	next := -1
+	for gameIndex := range srch.games {
+		if srch.assigned[gameIndex] != -1 {
+			continue
+		} else if srch.domains[gameIndex] == 0 {
+			srch.fail(gameIndex, placed)
+			return false
+		} else if next == -1 || srch.domains[gameIndex] < srch.domains[next] {
+			next = gameIndex
+		}
+	}
+
+	candidates, _ := srch.candidates(next)
+	for _, blockIndex := range candidates {
+		srch.place(next, blockIndex)
+		srch.updateDomains(next, srch.blocks[blockIndex].NumGames == srch.s.concurrentGameNum)
+		if srch.backtrack(placed + 1) {
+			return true
+		}
+		srch.unplace(next)
+		srch.updateDomains(next, srch.blocks[blockIndex].NumGames == srch.s.concurrentGameNum-1)
+		if srch.steps > maxSearchSteps {
+			return false
+		}
+	}
+	return false
+}
+
+// A team can play at most one game in each game block it is available in, and at most roundsPerWeek games a
+// week. A team with more games than that makes the schedule infeasible before any game is placed
+func (srch *search) checkTeamCapacity() *InfeasibleError {
+	for team, teamId := range srch.teamIds {
+		required := len(srch.teamGames[team])
+		available := 0
+		weekAvailable := make(map[int]int)
+		for blockIndex := range srch.blocks {
+			if srch.blockTeams[blockIndex][team] {
+				available++
+				weekAvailable[srch.weeks[blockIndex]]++
+			}
+		}
+		if available < required {
+			return &InfeasibleError{Constraint: TeamAvailabilityConstraint, TeamId: teamId,
+				Capacity: available, Required: required}
+		}
+
+		if srch.s.roundsPerWeek > 0 {
+			weekly := 0
+			for _, count := range weekAvailable {
+				if count > srch.s.roundsPerWeek {
+					count = srch.s.roundsPerWeek
+				}
+				weekly += count
+			}
+			if weekly < required {
+				return &InfeasibleError{Constraint: RoundsPerWeekConstraint, TeamId: teamId,
+					Capacity: weekly, Required: required}
+			}
+		}
+	}
+	return nil
+}
+
+// Local search on a feasible schedule: moves single games to the feasible block with the lowest cost until no
+// move improves the soft objectives
+func (srch *search) improve() {
+	for pass := 0; pass < maxImprovementPasses; pass++ {
+		improved := false
+		for gameIndex := range srch.games {
+			current := srch.assigned[gameIndex]
+			srch.unplace(gameIndex)
+			best, bestCost := current, srch.cost(gameIndex, current)
+			for blockIndex := range srch.blocks {
+				if srch.violation(gameIndex, blockIndex) != "" {
+					continue
+				}
+				if cost := srch.cost(gameIndex, blockIndex); cost < bestCost {
+					best, bestCost = blockIndex, cost
+				}
+			}
+			srch.place(gameIndex, best)
+			if best != current {
+				improved = true
+			}
+		}
+		if !improved {
+			return
+		}
+	}
+}
+
+// Chooses which team takes the team1 slot so that every team has at most one more team1 than team2 slot or
+// the other way around. Games are split into walks that start at teams with an odd number of unoriented games
+// where possible, and every game of a walk is oriented along it so that only the ends of walks gain a slot
+func (srch *search) balanceSlots() {
+	order := make([]int, len(srch.games))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return srch.blocks[srch.assigned[order[i]]].Start.Before(srch.blocks[srch.assigned[order[j]]].Start)
+	})
+
+	teamGames := make(map[int][]int)
+	for _, gameIndex := range order {
+		game := srch.games[gameIndex]
+		teamGames[game.team1] = append(teamGames[game.team1], gameIndex)
+		teamGames[game.team2] = append(teamGames[game.team2], gameIndex)
+	}
+	oriented := make([]bool, len(srch.games))
+	remaining := func(team int) int {
+		count := 0
+		for _, gameIndex := range teamGames[team] {
+			if !oriented[gameIndex] {
+				count++
+			}
+		}
+		return count
+	}
+
+	for {
+		start := -1
+		for _, team := range srch.s.teams {
+			if remaining(team)%2 == 1 {
+				start = team
+				break
+			} else if start == -1 && remaining(team) > 0 {
+				start = team
+			}
+		}
+		if start == -1 {
+			return
+		}
+
+		for team := start; ; {
+			next := -1
+			for _, gameIndex := range teamGames[team] {
+				if !oriented[gameIndex] {
+					next = gameIndex
+					break
+				}
+			}
+			if next == -1 {
+				break
+			}
+			oriented[next] = true
+			game := &srch.games[next]
+			if game.team1 != team {
+				game.team1, game.team2 = game.team2, game.team1
+			}
+			team = game.team2
+		}
+	}
+}
+
+// Searches for a schedule that satisfies every hard constraint and then improves it on the soft objectives
+func (s *Scheduler) getSearchSchedule(requiredGames []sgame, weekGameBlocks [][]*GameBlock) ([]Game, error) {
+	games := make([]sgame, len(requiredGames))
+	copy(games, requiredGames)
+	srch := newSearch(s, games, weekGameBlocks)
+
+	if err := srch.checkTeamCapacity(); err != nil {
+		return nil, err
+	}
+	if !srch.backtrack(0) {
+		if srch.failure == nil {
+			return nil, fmt.Errorf("Scheduling failed: no schedule was found within %v search steps", maxSearchSteps)
+		}
+		return nil, srch.failure
+	}
+	srch.improve()
+	srch.balanceSlots()
+
+	var scheduled []Game
+	for gameIndex, game := range srch.games {
+		scheduled = append(scheduled, Game{
+			Team1Id:  game.team1,
+			Team2Id:  game.team2,
+			GameTime: int(srch.blocks[srch.assigned[gameIndex]].Start.Unix()),
+			Round:    game.round,
+		})
+	}
+	return scheduled, nil
+}
@@ -0,0 +1,134 @@
+package scheduler
+
+import (
+	"github.com/pkg/errors"
+	"sort"
+)
+
+func pairKey(team1Id, team2Id int) [2]int {
+	if team1Id > team2Id {
+		return [2]int{team2Id, team1Id}
+	}
+	return [2]int{team1Id, team2Id}
+}
+
+// Bounds the backtracking of the pairings, which grows exponentially in late rounds where few pairings avoid a
+// rematch
+const maxPairingSteps = 200000
+
+// Pairs teams in order, each with the closest following team it has not played yet, backtracking when
+// the remaining teams can not all be paired without rematches. Gives up once steps exceeds maxPairingSteps
+func (s *Scheduler) pairWithoutRematches(teams []int, steps *int) ([]sgame, bool) {
+	if len(teams) == 0 {
+		return nil, true
+	}
+	*steps++
+	if *steps > maxPairingSteps {
+		return nil, false
+	}
+	for i := 1; i < len(teams); i++ {
+		if s.playedGames[pairKey(teams[0], teams[i])] > 0 {
+			continue
+		}
+		if games, ok := s.pairWithoutRematches(withoutPair(teams, i), steps); ok {
+			return append([]sgame{{team1: teams[0], team2: teams[i]}}, games...), true
+		}
+	}
+	return nil, false
+}
+
+// Pairs teams in order without backtracking, each with the closest following team it has not played yet or,
+// when it has played every remaining team, with the one it played the longest time ago
+func (s *Scheduler) pairLeastRecentRematches(teams []int) []sgame {
+	var games []sgame
+	for len(teams) > 1 {
+		opponent := 1
+		for i := 1; i < len(teams); i++ {
+			if s.playedGames[pairKey(teams[0], teams[i])] == 0 {
+				opponent = i
+				break
+			} else if s.playedGames[pairKey(teams[0], teams[i])] < s.playedGames[pairKey(teams[0], teams[opponent])] {
+				opponent = i
+			}
+		}
+		games = append(games, sgame{team1: teams[0], team2: teams[opponent]})
+		teams = withoutPair(teams, opponent)
+	}
+	return games
+}
+
+// The teams other than the first and the one at index i
+func withoutPair(teams []int, i int) []int {
+	remaining := make([]int, 0, len(teams)-2)
+	remaining = append(remaining, teams[1:i]...)
+	return append(remaining, teams[i+1:]...)
+}
+
+// The teams other than the one at index bye, or every team when bye is -1
+func withoutBye(ranked []int, bye int) []int {
+	teams := make([]int, 0, len(ranked))
+	for i, team := range ranked {
+		if i != bye {
+			teams = append(teams, team)
+		}
+	}
+	return teams
+}
+
+// Generates the pairings of the next swiss round. Teams are ranked by record so that teams with equal
+// records play each other where possible, falling back to the closest record that avoids a rematch. If no pairing
+// without a rematch is found within maxPairingSteps, the least recent rematches are allowed instead
+func (s *Scheduler) swissRound() ([]sgame, error) {
+	if len(s.teams) < 2 {
+		return nil, errors.New("At least two teams are needed to generate a round")
+	}
+
+	ranked := make([]int, len(s.teams))
+	copy(ranked, s.teams)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		recordI, recordJ := s.records[ranked[i]], s.records[ranked[j]]
+		if recordI.wins-recordI.losses != recordJ.wins-recordJ.losses {
+			return recordI.wins-recordI.losses > recordJ.wins-recordJ.losses
+		}
+		return recordI.wins > recordJ.wins
+	})
+
+	round := 1
+	for _, team := range ranked {
+		if s.gamesPlayed[team]+1 > round {
+			round = s.gamesPlayed[team] + 1
+		}
+	}
+
+	// With an odd number of teams the bye goes to the lowest ranked team that has not had one yet, which
+	// is a team that has played as many games as possible
+	byeCandidates := []int{-1}
+	if len(ranked)%2 == 1 {
+		byeCandidates = make([]int, 0)
+		for i := len(ranked) - 1; i >= 0; i-- {
+			byeCandidates = append(byeCandidates, i)
+		}
+		sort.SliceStable(byeCandidates, func(i, j int) bool {
+			return s.gamesPlayed[ranked[byeCandidates[i]]] > s.gamesPlayed[ranked[byeCandidates[j]]]
+		})
+	}
+
+	steps := 0
+	for _, bye := range byeCandidates {
+		if games, ok := s.pairWithoutRematches(withoutBye(ranked, bye), &steps); ok {
+			return withRound(games, round), nil
+		}
+	}
+	if steps > maxPairingSteps {
+		return withRound(s.pairLeastRecentRematches(withoutBye(ranked, byeCandidates[0])), round), nil
+	}
+
+	return nil, errors.New("Every possible pairing for the next round contains a rematch")
+}
+
+func withRound(games []sgame, round int) []sgame {
+	for i := range games {
+		games[i].round = round
+	}
+	return games
+}
@@ -1,8 +1,11 @@
 package scheduler
 
 type sgame struct {
-	team1 int
-	team2 int
+	team1     int
+	team2     int
+	round     int
+	team1From *GameSource
+	team2From *GameSource
 }
 
 func roundRobin(teams []int) []sgame {
@@ -16,7 +19,7 @@ func roundRobin(teams []int) []sgame {
 	for round := 0; round < len(teams)-1; round++ {
 		for i := startIndex; i < len(teams)/2; i++ {
 			requiredGames = append(requiredGames,
-				sgame{teams[i], teams[len(teams)-1-i]})
+				sgame{team1: teams[i], team2: teams[len(teams)-1-i]})
 		}
 		teams = append([]int{teams[0], teams[len(teams)-1]}, teams[1:len(teams)-1]...)
 	}
@@ -28,6 +31,10 @@ func getRequiredGames(tournamentType int, teams []int) []sgame {
 		return roundRobin(teams)
 	} else if tournamentType == DoubleRoundRobin {
 		return append(roundRobin(teams), roundRobin(teams)...)
+	} else if tournamentType == SingleElimination {
+		return singleElimination(teams)
+	} else if tournamentType == DoubleElimination {
+		return doubleElimination(teams)
 	}
 	return nil
 }
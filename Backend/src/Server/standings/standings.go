@@ -0,0 +1,202 @@
+package standings
+
+import "sort"
+
+// Tiebreakers a league can select, applied in the order selected to teams with equal points
+const (
+	HeadToHead         = "headToHead"
+	GameDifferential   = "gameDifferential"
+	StrengthOfSchedule = "strengthOfSchedule"
+	SonnebornBerger    = "sonnebornBerger"
+)
+
+func IsTiebreaker(tiebreaker string) bool {
+	switch tiebreaker {
+	case HeadToHead, GameDifferential, StrengthOfSchedule, SonnebornBerger:
+		return true
+	default:
+		return false
+	}
+}
+
+type Configuration struct {
+	WinPoints   int
+	DrawPoints  int
+	LossPoints  int
+	Tiebreakers []string
+}
+
+// A completed game. Draws have no winner, and a game without a winner that is not a draw is a double forfeit
+// which both teams lose
+type Result struct {
+	Team1Id    int
+	Team2Id    int
+	WinnerId   int
+	Draw       bool
+	ScoreTeam1 int
+	ScoreTeam2 int
+}
+
+type Standing struct {
+	TeamId             int
+	Rank               int
+	Played             int
+	Wins               int
+	Draws              int
+	Losses             int
+	Points             int
+	GameDifferential   int
+	StrengthOfSchedule float64
+	SonnebornBerger    float64
+}
+
+type table struct {
+	config    Configuration
+	standings map[int]*Standing
+	results   []Result
+}
+
+// Points the team earned in the game, and the fraction of the game it won used by Sonneborn-Berger
+func (t *table) outcome(result Result, teamId int) (int, float64) {
+	if result.Draw {
+		return t.config.DrawPoints, 0.5
+	} else if result.WinnerId == teamId {
+		return t.config.WinPoints, 1
+	} else {
+		return t.config.LossPoints, 0
+	}
+}
+
+func (t *table) addResult(result Result) {
+	for _, side := range [][3]int{
+		{result.Team1Id, result.ScoreTeam1, result.ScoreTeam2},
+		{result.Team2Id, result.ScoreTeam2, result.ScoreTeam1}} {
+		standing := t.standings[side[0]]
+		standing.Played++
+		standing.GameDifferential += side[1] - side[2]
+		points, _ := t.outcome(result, side[0])
+		standing.Points += points
+		if result.Draw {
+			standing.Draws++
+		} else if result.WinnerId == side[0] {
+			standing.Wins++
+		} else {
+			standing.Losses++
+		}
+	}
+}
+
+// Strength of schedule is the average points of the opponents faced, and Sonneborn-Berger sums the points
+// of the opponents beaten and half the points of the opponents drawn
+func (t *table) addOpponentScores() {
+	for _, result := range t.results {
+		for _, teams := range [][2]int{{result.Team1Id, result.Team2Id}, {result.Team2Id, result.Team1Id}} {
+			standing, opponent := t.standings[teams[0]], t.standings[teams[1]]
+			_, fraction := t.outcome(result, teams[0])
+			standing.StrengthOfSchedule += float64(opponent.Points) / float64(standing.Played)
+			standing.SonnebornBerger += fraction * float64(opponent.Points)
+		}
+	}
+}
+
+// Points earned by each team of the group in games played between teams of the group
+func (t *table) headToHead(group []*Standing) map[int]float64 {
+	points := make(map[int]float64)
+	inGroup := make(map[int]bool)
+	for _, standing := range group {
+		inGroup[standing.TeamId] = true
+	}
+	for _, result := range t.results {
+		if inGroup[result.Team1Id] && inGroup[result.Team2Id] {
+			for _, teamId := range []int{result.Team1Id, result.Team2Id} {
+				earned, _ := t.outcome(result, teamId)
+				points[teamId] += float64(earned)
+			}
+		}
+	}
+	return points
+}
+
+func (t *table) tiebreakerValues(tiebreaker string, group []*Standing) map[int]float64 {
+	if tiebreaker == HeadToHead {
+		return t.headToHead(group)
+	}
+	values := make(map[int]float64)
+	for _, standing := range group {
+		switch tiebreaker {
+		case GameDifferential:
+			values[standing.TeamId] = float64(standing.GameDifferential)
+		case StrengthOfSchedule:
+			values[standing.TeamId] = standing.StrengthOfSchedule
+		case SonnebornBerger:
+			values[standing.TeamId] = standing.SonnebornBerger
+		}
+	}
+	return values
+}
+
+// Orders a group of teams with equal points by the tiebreakers in turn, returning the groups of teams that are
+// still tied after every tiebreaker. When head-to-head separates some of the teams, it is applied again among
+// only the teams that are still tied before moving on to the next tiebreaker
+func (t *table) breakTies(group []*Standing, tiebreakers []string) [][]*Standing {
+	if len(group) <= 1 || len(tiebreakers) == 0 {
+		return [][]*Standing{group}
+	}
+
+	values := t.tiebreakerValues(tiebreakers[0], group)
+	sort.SliceStable(group, func(i, j int) bool {
+		return values[group[i].TeamId] > values[group[j].TeamId]
+	})
+
+	var ordered [][]*Standing
+	start := 0
+	for i := 1; i <= len(group); i++ {
+		if i == len(group) || values[group[i].TeamId] != values[group[start].TeamId] {
+			if tiebreakers[0] == HeadToHead && i-start < len(group) {
+				ordered = append(ordered, t.breakTies(group[start:i], tiebreakers)...)
+			} else {
+				ordered = append(ordered, t.breakTies(group[start:i], tiebreakers[1:])...)
+			}
+			start = i
+		}
+	}
+	return ordered
+}
+
+// Ranks the teams by points and then by the configured tiebreakers. Teams that are still tied share a rank and
+// keep the order they were given in. Results involving teams that are not ranked are ignored
+func Compute(teamIds []int, results []Result, config Configuration) []*Standing {
+	t := &table{config: config, standings: make(map[int]*Standing)}
+	ordered := make([]*Standing, 0, len(teamIds))
+	for _, teamId := range teamIds {
+		t.standings[teamId] = &Standing{TeamId: teamId}
+		ordered = append(ordered, t.standings[teamId])
+	}
+	for _, result := range results {
+		if t.standings[result.Team1Id] != nil && t.standings[result.Team2Id] != nil {
+			t.results = append(t.results, result)
+			t.addResult(result)
+		}
+	}
+	t.addOpponentScores()
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Points > ordered[j].Points
+	})
+
+	position := 0
+	for start := 0; start < len(ordered); {
+		end := start
+		for end < len(ordered) && ordered[end].Points == ordered[start].Points {
+			end++
+		}
+		for _, tied := range t.breakTies(ordered[start:end], config.Tiebreakers) {
+			for _, standing := range tied {
+				standing.Rank = position + 1
+			}
+			position += len(tied)
+		}
+		start = end
+	}
+	return ordered
+}
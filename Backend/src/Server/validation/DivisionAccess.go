@@ -0,0 +1,24 @@
+package validation
+
+import (
+	"Server/dataModel"
+	"errors"
+)
+
+func (a *AccessChecker) Division(accessType AccessType, permissions *dataModel.UserWithPermissions,
+	leagueDao dataModel.LeagueDAO, leagueId, divisionId int) (bool, error) {
+	switch accessType {
+	case View:
+		return leagueDao.DoesDivisionExistInLeague(leagueId, divisionId)
+	case Edit, Delete:
+		if permissions.LeaguePermissions.Administrator {
+			return leagueDao.DoesDivisionExistInLeague(leagueId, divisionId)
+		} else {
+			return false, nil
+		}
+	case Create:
+		return permissions.LeaguePermissions.Administrator, nil
+	default:
+		return false, errors.New("invalid access type to check")
+	}
+}
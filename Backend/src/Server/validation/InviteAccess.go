@@ -0,0 +1,22 @@
+package validation
+
+import (
+	"Server/dataModel"
+	"errors"
+)
+
+func (a *AccessChecker) Invite(accessType AccessType, permissions *dataModel.UserWithPermissions,
+	leagueDao dataModel.LeagueDAO, leagueId, inviteId int) (bool, error) {
+	switch accessType {
+	case View, Create:
+		return permissions.LeaguePermissions.Administrator, nil
+	case Edit, Delete:
+		if permissions.LeaguePermissions.Administrator {
+			return leagueDao.DoesInviteExistInLeague(leagueId, inviteId)
+		} else {
+			return false, nil
+		}
+	default:
+		return false, errors.New("invalid access type to check")
+	}
+}
@@ -0,0 +1,68 @@
+package validation
+
+import (
+	"Server/dataModel"
+	"errors"
+)
+
+// Results of games that have been reported can only be changed by those managing the league's games
+func (a *AccessChecker) Report(accessType AccessType, permissions *dataModel.UserWithPermissions,
+	gameDao dataModel.GameDAO, leagueId, gameId int) (bool, error) {
+	switch accessType {
+	case View:
+		return gameDao.DoesGameExistInLeague(leagueId, gameId)
+	case Edit:
+		if permissions.LeaguePermissions.Administrator || permissions.LeaguePermissions.EditGames {
+			return gameDao.DoesGameExistInLeague(leagueId, gameId)
+		} else {
+			return false, nil
+		}
+	default:
+		return false, errors.New("invalid access type to check")
+	}
+}
+
+// The team a user reports results of the game for, or 0 when the user manages the league's games and reports
+// results that are final without confirmation
+func ReportingTeamId(permissions *dataModel.UserWithPermissions, game *dataModel.Game) int {
+	if permissions.LeaguePermissions.Administrator || permissions.LeaguePermissions.EditGames {
+		return 0
+	} else if teamAdministrator(permissions, game.Team1.TeamId) || teamGames(permissions, game.Team1.TeamId) {
+		return game.Team1.TeamId
+	} else {
+		return game.Team2.TeamId
+	}
+}
+
+// Disputed results are resolved by those managing the league's games, and a submitted result is confirmed or
+// disputed by the team that did not submit it
+func (a *AccessChecker) Submission(accessType AccessType, permissions *dataModel.UserWithPermissions,
+	gameDao dataModel.GameDAO, leagueId, gameId int) (bool, error) {
+	switch accessType {
+	case View:
+		return permissions.LeaguePermissions.Administrator || permissions.LeaguePermissions.EditGames, nil
+	case Edit:
+		gameExists, err := gameDao.DoesGameExistInLeague(leagueId, gameId)
+		if err != nil || !gameExists {
+			return false, err
+		} else if permissions.LeaguePermissions.Administrator || permissions.LeaguePermissions.EditGames {
+			return true, nil
+		}
+
+		submission, err := gameDao.GetResultSubmission(gameId)
+		if err != nil || submission == nil {
+			return false, err
+		}
+		game, err := gameDao.GetGameInformation(gameId)
+		if err != nil {
+			return false, err
+		}
+		opposingTeamId := game.Team1.TeamId
+		if submission.TeamId == game.Team1.TeamId {
+			opposingTeamId = game.Team2.TeamId
+		}
+		return teamAdministrator(permissions, opposingTeamId) || teamGames(permissions, opposingTeamId), nil
+	default:
+		return false, errors.New("invalid access type to check")
+	}
+}
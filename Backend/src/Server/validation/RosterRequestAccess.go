@@ -0,0 +1,53 @@
+package validation
+
+import (
+	"Server/dataModel"
+	"errors"
+)
+
+// Requests from users are answered by the managers of the team, who can also withdraw the invites they sent
+func (a *AccessChecker) RosterRequest(accessType AccessType, permissions *dataModel.UserWithPermissions,
+	teamDao dataModel.TeamDAO, leagueDao dataModel.LeagueDAO, leagueId, teamId, requestId int) (bool, error) {
+	switch accessType {
+	case View:
+		return a.Team(Edit, permissions, teamDao, leagueDao, leagueId, teamId)
+	case Create:
+		if permissions.UserId == 0 {
+			return false, nil
+		}
+		return changeableTeam(teamDao, leagueId, teamId)
+	case Edit:
+		if canEdit, err := a.Team(Edit, permissions, teamDao, leagueDao, leagueId, teamId); err != nil || !canEdit {
+			return false, err
+		}
+		request, err := teamDao.GetRosterRequest(requestId)
+		if err != nil || request == nil {
+			return false, err
+		}
+		return request.TeamId == teamId && !request.FromTeam, nil
+	case Delete:
+		if canEdit, err := a.Team(Edit, permissions, teamDao, leagueDao, leagueId, teamId); err != nil || !canEdit {
+			return false, err
+		}
+		return teamDao.DoesRosterRequestExist(teamId, requestId)
+	default:
+		return false, errors.New("invalid access type to check")
+	}
+}
+
+// Invites from teams are answered by the invited user, whichever league is active
+func (a *AccessChecker) RosterInvite(accessType AccessType, permissions *dataModel.UserWithPermissions,
+	teamDao dataModel.TeamDAO, requestId int) (bool, error) {
+	switch accessType {
+	case View:
+		return permissions.UserId != 0, nil
+	case Edit, Delete:
+		request, err := teamDao.GetRosterRequest(requestId)
+		if err != nil || request == nil {
+			return false, err
+		}
+		return request.FromTeam && request.UserId == permissions.UserId, nil
+	default:
+		return false, errors.New("invalid access type to check")
+	}
+}
@@ -0,0 +1,24 @@
+package validation
+
+import (
+	"Server/dataModel"
+	"errors"
+)
+
+func (a *AccessChecker) Season(accessType AccessType, permissions *dataModel.UserWithPermissions,
+	leagueDao dataModel.LeagueDAO, leagueId, seasonId int) (bool, error) {
+	switch accessType {
+	case View:
+		return leagueDao.DoesSeasonExistInLeague(leagueId, seasonId)
+	case Edit, Delete:
+		if permissions.LeaguePermissions.Administrator {
+			return leagueDao.DoesSeasonExistInLeague(leagueId, seasonId)
+		} else {
+			return false, nil
+		}
+	case Create:
+		return permissions.LeaguePermissions.Administrator, nil
+	default:
+		return false, errors.New("invalid access type to check")
+	}
+}
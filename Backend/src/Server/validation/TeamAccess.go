@@ -3,7 +3,6 @@ package validation
 import (
 	"Server/dataModel"
 	"github.com/pkg/errors"
-	"time"
 )
 
 func teamPermissions(userPermissions *dataModel.UserWithPermissions, teamId int) *dataModel.TeamPermissions {
@@ -39,6 +38,16 @@ func teamGames(userPermissions *dataModel.UserWithPermissions, teamId int) bool
 	}
 }
 
+// Withdrawn teams are kept for the results of their games, but can no longer be changed
+func changeableTeam(teamDao dataModel.TeamDAO, leagueId, teamId int) (bool, error) {
+	exists, err := teamDao.DoesTeamExistInLeague(leagueId, teamId)
+	if err != nil || !exists {
+		return false, err
+	}
+	archived, err := teamDao.IsTeamArchived(leagueId, teamId)
+	return !archived, err
+}
+
 func (a *AccessChecker) Team(accessType AccessType, permissions *dataModel.UserWithPermissions,
 	teamDao dataModel.TeamDAO, leagueDao dataModel.LeagueDAO, leagueId, teamId int) (bool, error) {
 	switch accessType {
@@ -50,7 +59,7 @@ func (a *AccessChecker) Team(accessType AccessType, permissions *dataModel.UserW
 			return true, nil
 		} else if permissions.LeaguePermissions.Administrator ||
 			permissions.LeaguePermissions.EditTeams {
-			return teamDao.DoesTeamExistInLeague(leagueId, teamId)
+			return changeableTeam(teamDao, leagueId, teamId)
 		} else {
 			return false, nil
 		}
@@ -59,7 +68,7 @@ func (a *AccessChecker) Team(accessType AccessType, permissions *dataModel.UserW
 			return true, nil
 		} else if permissions.LeaguePermissions.Administrator ||
 			permissions.LeaguePermissions.EditTeams {
-			return teamDao.DoesTeamExistInLeague(leagueId, teamId)
+			return changeableTeam(teamDao, leagueId, teamId)
 		} else {
 			return false, nil
 		}
@@ -67,14 +76,12 @@ func (a *AccessChecker) Team(accessType AccessType, permissions *dataModel.UserW
 		if permissions.LeaguePermissions.Administrator || permissions.LeaguePermissions.CreateTeams {
 			return true, nil
 		} else {
-			// if during signup period and allows public signups
+			// if league allows public signups, the signup period is checked when validating the new team
 			leagueInfo, err := leagueDao.GetLeagueInformation(leagueId)
 			if err != nil {
 				return false, err
 			} else {
-				return leagueInfo.PublicJoin &&
-					time.Now().Unix() > int64(leagueInfo.SignupStart) &&
-					time.Now().Unix() < int64(leagueInfo.SignupEnd), nil
+				return leagueInfo.PublicJoin, nil
 			}
 		}
 	default:
@@ -23,7 +23,21 @@ type Access interface {
 		leagueId, teamId, playerId int) (bool, error)
 	Game(accessType AccessType, permissions *dataModel.UserWithPermissions,
 		gameDao dataModel.GameDAO, leagueId, gameId int) (bool, error)
+	Report(accessType AccessType, permissions *dataModel.UserWithPermissions,
+		gameDao dataModel.GameDAO, leagueId, gameId int) (bool, error)
+	Submission(accessType AccessType, permissions *dataModel.UserWithPermissions,
+		gameDao dataModel.GameDAO, leagueId, gameId int) (bool, error)
 	Availability(accessType AccessType, permissions *dataModel.UserWithPermissions,
 		leagueDao dataModel.LeagueDAO, leagueId, availabilityId int) (bool, error)
+	Invite(accessType AccessType, permissions *dataModel.UserWithPermissions,
+		leagueDao dataModel.LeagueDAO, leagueId, inviteId int) (bool, error)
+	RosterRequest(accessType AccessType, permissions *dataModel.UserWithPermissions,
+		teamDao dataModel.TeamDAO, leagueDao dataModel.LeagueDAO, leagueId, teamId, requestId int) (bool, error)
+	RosterInvite(accessType AccessType, permissions *dataModel.UserWithPermissions,
+		teamDao dataModel.TeamDAO, requestId int) (bool, error)
+	Season(accessType AccessType, permissions *dataModel.UserWithPermissions,
+		leagueDao dataModel.LeagueDAO, leagueId, seasonId int) (bool, error)
+	Division(accessType AccessType, permissions *dataModel.UserWithPermissions,
+		leagueDao dataModel.LeagueDAO, leagueId, divisionId int) (bool, error)
 }
 type AccessChecker struct{}
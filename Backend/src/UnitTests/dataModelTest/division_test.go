@@ -0,0 +1,76 @@
+package dataModelTest
+
+import (
+	"Server/dataModel"
+	"testing"
+)
+
+type divisionLeagueDao struct {
+	dataModel.LeagueDAO
+	divisions []*dataModel.Division
+}
+
+func (d *divisionLeagueDao) GetDivisions(leagueId int) ([]*dataModel.Division, error) {
+	return d.divisions, nil
+}
+
+type divisionTeamDao struct {
+	dataModel.TeamDAO
+	teams []*dataModel.TeamDisplay
+}
+
+func (d *divisionTeamDao) GetAllTeamDisplaysInLeague(leagueId int) ([]*dataModel.TeamDisplay, error) {
+	return d.teams, nil
+}
+
+// Four teams, of which the first three are split into two divisions
+func partlyDividedLeague() (*divisionLeagueDao, *divisionTeamDao) {
+	teams := []*dataModel.TeamDisplay{
+		{TeamId: 1, Name: "Team 1"},
+		{TeamId: 2, Name: "Team 2"},
+		{TeamId: 3, Name: "Team 3"},
+		{TeamId: 4, Name: "Team 4"},
+	}
+	return &divisionLeagueDao{divisions: []*dataModel.Division{
+		{DivisionId: 1, Teams: teams[:2]},
+		{DivisionId: 2, Teams: teams[2:3]},
+	}}, &divisionTeamDao{teams: teams}
+}
+
+func Test_RoundRobinRequiresEveryTeamInDivision(t *testing.T) {
+	leagueDao, teamDao := partlyDividedLeague()
+	params := dataModel.SchedulingParameters{TournamentType: "roundrobin", RoundsPerWeek: 1, ConcurrentGameNum: 1,
+		GameDuration: 60}
+
+	valid, problem, err := params.Validate(1, leagueDao, teamDao)
+	if valid || err != nil || problem != dataModel.TeamsWithoutDivision+"Team 4" {
+		t.Errorf("expected team 4 to be reported without a division, got valid %v, problem %v and error %v",
+			valid, problem, err)
+	}
+
+	leagueDao.divisions[1].Teams = append(leagueDao.divisions[1].Teams, teamDao.teams[3])
+	if valid, problem, err = params.Validate(1, leagueDao, teamDao); !valid {
+		t.Errorf("expected schedule with every team in a division to be valid, got problem %v and error %v",
+			problem, err)
+	}
+}
+
+func Test_LeaguesWithoutDivisionsScheduleEveryTeam(t *testing.T) {
+	_, teamDao := partlyDividedLeague()
+	params := dataModel.SchedulingParameters{TournamentType: "roundrobin", RoundsPerWeek: 1, ConcurrentGameNum: 1,
+		GameDuration: 60}
+
+	if valid, problem, err := params.Validate(1, &divisionLeagueDao{}, teamDao); !valid {
+		t.Errorf("expected league without divisions to be valid, got problem %v and error %v", problem, err)
+	}
+}
+
+func Test_BracketsIgnoreDivisions(t *testing.T) {
+	leagueDao, teamDao := partlyDividedLeague()
+	params := dataModel.SchedulingParameters{TournamentType: "singleelimination", RoundsPerWeek: 1,
+		ConcurrentGameNum: 1, GameDuration: 60}
+
+	if valid, problem, err := params.Validate(1, leagueDao, teamDao); !valid {
+		t.Errorf("expected bracket to be scheduled across divisions, got problem %v and error %v", problem, err)
+	}
+}
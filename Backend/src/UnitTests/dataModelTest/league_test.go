@@ -0,0 +1,44 @@
+package dataModelTest
+
+import (
+	"Server/dataModel"
+	"testing"
+	"time"
+)
+
+func Test_ViewerLocationDefaultsToLeagueTimeZone(t *testing.T) {
+	league := dataModel.League{TimeZone: "America/New_York"}
+
+	location, valid, err := league.ViewerLocation("")
+	if err != nil || !valid {
+		t.Fatalf("expected league time zone to be valid, got valid %v and error %v", valid, err)
+	}
+	if location.String() != "America/New_York" {
+		t.Errorf("expected league time zone America/New_York, got %v", location)
+	}
+
+	location, valid, err = (&dataModel.League{}).ViewerLocation("")
+	if err != nil || !valid || location != time.UTC {
+		t.Errorf("expected UTC for a league without a time zone, got %v", location)
+	}
+}
+
+func Test_ViewerLocationUsesRequestedTimeZone(t *testing.T) {
+	league := dataModel.League{TimeZone: "America/New_York"}
+
+	location, valid, err := league.ViewerLocation("Europe/Berlin")
+	if err != nil || !valid {
+		t.Fatalf("expected requested time zone to be valid, got valid %v and error %v", valid, err)
+	}
+	if location.String() != "Europe/Berlin" {
+		t.Errorf("expected requested time zone Europe/Berlin, got %v", location)
+	}
+}
+
+func Test_ViewerLocationInvalidTimeZone(t *testing.T) {
+	league := dataModel.League{TimeZone: "America/New_York"}
+
+	if _, valid, err := league.ViewerLocation("Not/A_Zone"); err != nil || valid {
+		t.Errorf("expected requested time zone to be invalid, got valid %v and error %v", valid, err)
+	}
+}
@@ -0,0 +1,190 @@
+package dataModelTest
+
+import (
+	"Server/dataModel"
+	"testing"
+	"time"
+)
+
+// Only the methods used by roster change validation are implemented, any other call panics
+type rosterLeagueDao struct {
+	dataModel.LeagueDAO
+	rules dataModel.RosterRules
+}
+
+func (d *rosterLeagueDao) GetRosterRules(leagueId int) (*dataModel.RosterRules, error) {
+	return &d.rules, nil
+}
+
+type rosterTeamDao struct {
+	dataModel.TeamDAO
+	teams map[int][]*dataModel.Player
+}
+
+func (d *rosterTeamDao) GetAllTeamsInLeague(leagueId int) ([]*dataModel.TeamWithPlayers, error) {
+	teams := make([]*dataModel.TeamWithPlayers, 0)
+	for teamId, players := range d.teams {
+		teams = append(teams, &dataModel.TeamWithPlayers{TeamId: teamId, Players: players})
+	}
+	return teams, nil
+}
+
+func (d *rosterTeamDao) GetPendingTeams(leagueId int) ([]*dataModel.TeamWithPlayers, error) {
+	return nil, nil
+}
+
+func (d *rosterTeamDao) GetArchivedTeams(leagueId int) ([]*dataModel.TeamWithPlayers, error) {
+	return nil, nil
+}
+
+func (d *rosterTeamDao) GetRoster(teamId int) ([]*dataModel.RosterSlot, error) {
+	roster := make([]*dataModel.RosterSlot, 0)
+	for _, player := range d.teams[teamId] {
+		roster = append(roster, &dataModel.RosterSlot{PlayerId: player.PlayerId, MainRoster: player.MainRoster})
+	}
+	return roster, nil
+}
+
+func (d *rosterTeamDao) DoesPlayerExist(leagueId, teamId, playerId int) (bool, error) {
+	for _, player := range d.teams[teamId] {
+		if player.PlayerId == playerId {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Two teams of two main roster players each, with rosters locked an hour ago
+func lockedLeague() (*rosterLeagueDao, *rosterTeamDao) {
+	leagueDao := &rosterLeagueDao{rules: dataModel.RosterRules{
+		MinMainRoster: 2,
+		MaxMainRoster: 3,
+		RosterLock:    int(time.Now().Add(-time.Hour).Unix()),
+	}}
+	teamDao := &rosterTeamDao{teams: map[int][]*dataModel.Player{
+		1: {
+			{PlayerId: 1, GameIdentifier: "player1", MainRoster: true},
+			{PlayerId: 2, GameIdentifier: "player2", MainRoster: true},
+		},
+		2: {
+			{PlayerId: 3, GameIdentifier: "player3", MainRoster: true},
+			{PlayerId: 4, GameIdentifier: "player4", MainRoster: true},
+		},
+	}}
+	return leagueDao, teamDao
+}
+
+func Test_RosterChangesQueuedAfterLock(t *testing.T) {
+	unlocked := dataModel.RosterRules{}
+	if unlocked.Locked() || unlocked.QueuesChanges(false) {
+		t.Errorf("expected rosters without a lock to never be locked")
+	}
+
+	upcoming := dataModel.RosterRules{RosterLock: int(time.Now().Add(time.Hour).Unix())}
+	if upcoming.Locked() || upcoming.QueuesChanges(false) {
+		t.Errorf("expected rosters to be open before the roster lock")
+	}
+
+	passed := dataModel.RosterRules{RosterLock: int(time.Now().Add(-time.Hour).Unix())}
+	if !passed.Locked() {
+		t.Errorf("expected rosters to be locked after the roster lock")
+	}
+	if !passed.QueuesChanges(false) {
+		t.Errorf("expected changes by team managers to be queued after the roster lock")
+	}
+	if passed.QueuesChanges(true) {
+		t.Errorf("expected changes by league administrators to be made directly after the roster lock")
+	}
+}
+
+func Test_RosterChangesRejectedByTeamManagersAfterLock(t *testing.T) {
+	leagueDao, teamDao := lockedLeague()
+	player := dataModel.PlayerCore{Name: "New Player", GameIdentifier: "player5", MainRoster: true}
+
+	valid, problem, err := player.ValidateNew(1, 1, false, leagueDao, teamDao)
+	if valid || err != nil || problem != dataModel.RosterLocked {
+		t.Errorf("expected team manager to be locked out, got valid %v, problem %v and error %v",
+			valid, problem, err)
+	}
+
+	if valid, problem, err = player.ValidateNew(1, 1, true, leagueDao, teamDao); !valid {
+		t.Errorf("expected league administrator to add the player, got problem %v and error %v", problem, err)
+	}
+}
+
+func Test_RosterChangeMustExist(t *testing.T) {
+	valid, problem, err := dataModel.ValidateRosterChangeExists(nil)
+	if valid || err != nil || problem != dataModel.RosterChangeDoesNotExist {
+		t.Errorf("expected missing roster change to be invalid, got valid %v, problem %v and error %v",
+			valid, problem, err)
+	}
+
+	var change *dataModel.RosterChange
+	if valid, problem, err = change.ValidateApproval(nil, nil); valid || err != nil ||
+		problem != dataModel.RosterChangeDoesNotExist {
+		t.Errorf("expected missing roster change to not be approved, got valid %v, problem %v and error %v",
+			valid, problem, err)
+	}
+}
+
+func Test_QueuedRosterChangesApprovedAfterLock(t *testing.T) {
+	leagueDao, teamDao := lockedLeague()
+
+	addition := dataModel.RosterChange{LeagueId: 1, TeamId: 1, Name: "New Player", GameIdentifier: "player5",
+		MainRoster: true}
+	if valid, problem, err := addition.ValidateApproval(leagueDao, teamDao); !valid {
+		t.Errorf("expected queued addition to be approved, got problem %v and error %v", problem, err)
+	}
+
+	removal := dataModel.RosterChange{LeagueId: 1, TeamId: 1, PlayerId: 1}
+	leagueDao.rules.MinMainRoster = 1
+	if valid, problem, err := removal.ValidateApproval(leagueDao, teamDao); !valid {
+		t.Errorf("expected queued removal to be approved, got problem %v and error %v", problem, err)
+	}
+}
+
+func Test_QueuedRosterChangesCheckedAgainstCurrentRoster(t *testing.T) {
+	leagueDao, teamDao := lockedLeague()
+
+	// Another team registered the game identifier while the addition was queued
+	addition := dataModel.RosterChange{LeagueId: 1, TeamId: 1, Name: "New Player", GameIdentifier: "player3",
+		MainRoster: true}
+	valid, problem, err := addition.ValidateApproval(leagueDao, teamDao)
+	if valid || err != nil || problem != dataModel.PlayerGameIdentifierInUse {
+		t.Errorf("expected taken game identifier to be rejected, got valid %v, problem %v and error %v",
+			valid, problem, err)
+	}
+
+	// The team filled its main roster while the addition was queued
+	leagueDao.rules.MaxMainRoster = 2
+	addition.GameIdentifier = "player5"
+	if valid, problem, err = addition.ValidateApproval(leagueDao, teamDao); valid || err != nil ||
+		problem != dataModel.MainRosterTooLarge {
+		t.Errorf("expected addition to a full roster to be rejected, got valid %v, problem %v and error %v",
+			valid, problem, err)
+	}
+
+	// Removing a player would leave the team below the minimum roster
+	removal := dataModel.RosterChange{LeagueId: 1, TeamId: 1, PlayerId: 1}
+	if valid, problem, err = removal.ValidateApproval(leagueDao, teamDao); valid || err != nil ||
+		problem != dataModel.MainRosterTooSmall {
+		t.Errorf("expected removal below the minimum roster to be rejected, got valid %v, problem %v and error %v",
+			valid, problem, err)
+	}
+}
+
+func Test_QueuedRemovalOfTransferredPlayerIsStale(t *testing.T) {
+	leagueDao, teamDao := lockedLeague()
+	leagueDao.rules.MinMainRoster = 0
+
+	// Player 1 was transferred to team 2 after their removal from team 1 was queued
+	removal := dataModel.RosterChange{LeagueId: 1, TeamId: 1, PlayerId: 1}
+	teamDao.teams[2] = append(teamDao.teams[2], teamDao.teams[1][0])
+	teamDao.teams[1] = teamDao.teams[1][1:]
+
+	valid, problem, err := removal.ValidateApproval(leagueDao, teamDao)
+	if valid || err != nil || problem != dataModel.RosterChangeStale {
+		t.Errorf("expected removal of transferred player to be stale, got valid %v, problem %v and error %v",
+			valid, problem, err)
+	}
+}
@@ -0,0 +1,52 @@
+package databaseTest
+
+import (
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var schemaFiles = []string{
+	"../../../Database/createTables.sql",
+	"../../../Database/leagueOfLegendsTables.sql",
+}
+
+var createTable = regexp.MustCompile(`(?s)CREATE TABLE (\w+) \((.*?)\n\);`)
+
+// Games are deleted by hand and by withdraw_team, including games that already have a tournament code provisioned, so
+// every row referencing a game has to be deleted along with it
+func Test_GameReferencesCascadeOnDelete(t *testing.T) {
+	for _, file := range schemaFiles {
+		schema, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatalf("failed to read schema %v: %v", file, err)
+		}
+
+		for _, table := range createTable.FindAllStringSubmatch(string(schema), -1) {
+			for _, column := range strings.Split(table[2], "\n") {
+				if strings.Contains(column, "REFERENCES game(game_id)") && !strings.Contains(column, "ON DELETE CASCADE") {
+					t.Errorf("%v: column of %v referencing game is not deleted with the game: %v",
+						file, table[1], strings.TrimSpace(column))
+				}
+			}
+		}
+	}
+}
+
+func Test_ProvisionedTournamentCodeDeletedWithGame(t *testing.T) {
+	schema, err := ioutil.ReadFile("../../../Database/leagueOfLegendsTables.sql")
+	if err != nil {
+		t.Fatalf("failed to read schema: %v", err)
+	}
+
+	for _, table := range createTable.FindAllStringSubmatch(string(schema), -1) {
+		if table[1] == "lol_game" {
+			if !strings.Contains(table[2], "REFERENCES game(game_id) ON DELETE CASCADE") {
+				t.Error("tournament codes of a game would prevent the game from being deleted")
+			}
+			return
+		}
+	}
+	t.Error("lol_game table not found")
+}
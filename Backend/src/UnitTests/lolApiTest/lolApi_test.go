@@ -0,0 +1,146 @@
+package lolApiTest
+
+import (
+	"Server/lolApi"
+	"reflect"
+	"testing"
+)
+
+func getApi(server *fakeServer) lolApi.LoLApi {
+	return lolApi.NewLoLApi(server.URL, server.URL, apiKey, server.Client(), nil)
+}
+
+func Test_GetSummonerId(t *testing.T) {
+	server := newFakeServer(t)
+	defer server.Close()
+
+	summonerId, err := getApi(server).GetSummonerId("Faker")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summonerId != "summoner-faker" {
+		t.Errorf("expected summoner-faker, got %v", summonerId)
+	}
+}
+
+func Test_UnknownSummoner(t *testing.T) {
+	server := newFakeServer(t)
+	defer server.Close()
+
+	_, err := getApi(server).GetSummonerId("Nobody")
+	statusErr, ok := err.(*lolApi.StatusError)
+	if !ok {
+		t.Fatalf("expected a status error, got %v", err)
+	}
+	if statusErr.StatusCode != 404 {
+		t.Errorf("expected status 404, got %v", statusErr.StatusCode)
+	}
+}
+
+func Test_GetSummonerInformation(t *testing.T) {
+	server := newFakeServer(t)
+	defer server.Close()
+
+	information := getApi(server).GetSummonerInformation(
+		[]string{"summoner-faker", "summoner-doublelift", "summoner-unknown"})
+
+	// The higher of the solo and flex queue ranks is shown, and other queues are ignored
+	expected := map[string]lolApi.SummonerInformation{
+		"summoner-faker":      {GameIdentifier: "Faker", Rank: "I", Tier: "CHALLENGER"},
+		"summoner-doublelift": {GameIdentifier: "Doublelift"},
+		"summoner-unknown":    {},
+	}
+	for id, summoner := range expected {
+		if information[id] == nil || *information[id] != summoner {
+			t.Errorf("expected %+v for %v, got %+v", summoner, id, information[id])
+		}
+	}
+}
+
+func Test_GetMatchStats(t *testing.T) {
+	server := newFakeServer(t)
+	defer server.Close()
+
+	match, err := getApi(server).GetMatchStats("3087654321")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if match.MatchId != "3087654321" || match.Duration != 1843 || match.Timestamp != 1564976400 {
+		t.Errorf("unexpected match information %v, %v, %v", match.MatchId, match.Duration, match.Timestamp)
+	}
+	if expected := []string{"Zed", "Thresh"}; !reflect.DeepEqual(match.BannedChampions, expected) {
+		t.Errorf("expected bans %v, got %v", expected, match.BannedChampions)
+	}
+	if expected := []string{"Lee Sin", "Annie"}; !reflect.DeepEqual(match.WinningChampions, expected) {
+		t.Errorf("expected winning champions %v, got %v", expected, match.WinningChampions)
+	}
+	if expected := []string{"Ahri", "Miss Fortune"}; !reflect.DeepEqual(match.LosingChampions, expected) {
+		t.Errorf("expected losing champions %v, got %v", expected, match.LosingChampions)
+	}
+	if expected := []string{"summoner-faker", "summoner-teddy"}; !reflect.DeepEqual(match.WinningTeamSummonerIds, expected) {
+		t.Errorf("expected winning summoners %v, got %v", expected, match.WinningTeamSummonerIds)
+	}
+	if match.WinningTeamStats.Side != 200 || !match.WinningTeamStats.FirstTower || match.WinningTeamStats.FirstBlood {
+		t.Errorf("unexpected winning team stats %+v", match.WinningTeamStats)
+	}
+	if match.LosingTeamStats.Side != 100 || !match.LosingTeamStats.FirstBlood {
+		t.Errorf("unexpected losing team stats %+v", match.LosingTeamStats)
+	}
+
+	if len(match.PlayerStats) != 4 {
+		t.Fatalf("expected stats of 4 players, got %v", len(match.PlayerStats))
+	}
+	faker := match.PlayerStats[2]
+	if faker.Id != "summoner-faker" || faker.Name != "Faker" || faker.ChampionPicked != "Lee Sin" || !faker.Win ||
+		faker.Kills != 7 || faker.Deaths != 2 || faker.Assists != 9 || faker.Gold != 12100 || faker.Cs != 150 ||
+		faker.Damage != 20412 || faker.Wards != 15 {
+		t.Errorf("unexpected player stats %+v", faker)
+	}
+}
+
+func Test_ChampionNamesLoadedOnce(t *testing.T) {
+	server := newFakeServer(t)
+	defer server.Close()
+
+	api := getApi(server)
+	for i := 0; i < 2; i++ {
+		if _, err := api.GetMatchStats("3087654321"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if count := server.requestCount("/cdn/9.15.1/data/en_US/champion.json"); count != 1 {
+		t.Errorf("expected champions to be loaded once, loaded %v times", count)
+	}
+}
+
+func Test_TournamentMatchInformation(t *testing.T) {
+	server := newFakeServer(t)
+	defer server.Close()
+
+	callback := lolApi.TournamentCallback{
+		GameId:    3087654321,
+		ShortCode: "NA0418d-8899c00b-5bd8-4d4c-a2c5-0a7e8b5e7f2d",
+		MetaData:  `{"gameId":"external-game","elmGameId":3,"team1Id":7,"team2Id":9,"team1RefPlayerId":"summoner-faker"}`,
+	}
+	match, err := lolApi.GetTournamentMatchInformation(getApi(server), &callback)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if match.GameId != "external-game" || match.Team1Id != 7 || match.Team2Id != 9 {
+		t.Errorf("unexpected game %v between %v and %v", match.GameId, match.Team1Id, match.Team2Id)
+	}
+	if match.WinningTeamId != 7 || match.LosingTeamId != 9 {
+		t.Errorf("expected team 7 to beat team 9, got winner %v and loser %v", match.WinningTeamId, match.LosingTeamId)
+	}
+}
+
+func Test_MalformedTournamentMetadata(t *testing.T) {
+	callback := lolApi.TournamentCallback{GameId: 3087654321, MetaData: "not metadata"}
+	if _, err := callback.GameMetadata(); err == nil {
+		t.Errorf("expected malformed metadata to be rejected")
+	}
+	if matchId := callback.MatchId(); matchId != "3087654321" {
+		t.Errorf("expected match id 3087654321, got %v", matchId)
+	}
+}
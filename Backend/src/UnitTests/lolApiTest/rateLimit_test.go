@@ -0,0 +1,76 @@
+package lolApiTest
+
+import (
+	"Server/lolApi"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const fakerByName = "/lol/summoner/v4/summoners/by-name/Faker"
+
+func Test_RetryAfterRateLimited(t *testing.T) {
+	server := newFakeServer(t)
+	defer server.Close()
+	server.respond(fakerByName, fakeResponse{
+		status: http.StatusTooManyRequests,
+		header: map[string]string{"Retry-After": "1", "X-Rate-Limit-Type": "method"},
+	})
+
+	start := time.Now()
+	summonerId, err := getApi(server).GetSummonerId("Faker")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summonerId != "summoner-faker" {
+		t.Errorf("expected summoner-faker, got %v", summonerId)
+	}
+	if count := server.requestCount(fakerByName); count != 2 {
+		t.Errorf("expected 2 requests, got %v", count)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected to wait for the Retry-After period, retried after %v", elapsed)
+	}
+}
+
+func Test_MethodRateLimit(t *testing.T) {
+	server := newFakeServer(t)
+	defer server.Close()
+	server.setHeader("X-Method-Rate-Limit", "1:1")
+
+	api := getApi(server)
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if _, err := api.GetSummonerId("Faker"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected the second request to wait for the method rate limit, made after %v", elapsed)
+	}
+
+	// Other methods are not held back by the limit of the method
+	start = time.Now()
+	api.GetSummonerInformation([]string{"summoner-faker"})
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("expected other methods not to wait, waited %v", elapsed)
+	}
+}
+
+func Test_UnavailableApi(t *testing.T) {
+	server := newFakeServer(t)
+	defer server.Close()
+	server.setUnavailable(true)
+
+	_, err := getApi(server).GetSummonerId("Faker")
+	statusErr, ok := err.(*lolApi.StatusError)
+	if !ok {
+		t.Fatalf("expected a status error, got %v", err)
+	}
+	if statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %v", statusErr.StatusCode)
+	}
+	if count := server.requestCount(fakerByName); count != 3 {
+		t.Errorf("expected 3 attempts, got %v", count)
+	}
+}
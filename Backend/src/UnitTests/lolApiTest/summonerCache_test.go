@@ -0,0 +1,98 @@
+package lolApiTest
+
+import (
+	"Server/dataModel"
+	"Server/lolApi"
+	"sync"
+	"testing"
+	"time"
+)
+
+const fakerSummoner = "/lol/summoner/v4/summoners/summoner-faker"
+
+type fakeStore struct {
+	lock      sync.Mutex
+	summoners map[string]*dataModel.LoLCachedSummoner
+}
+
+func (s *fakeStore) GetCachedSummoners(externalIds []string) ([]*dataModel.LoLCachedSummoner, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	summoners := make([]*dataModel.LoLCachedSummoner, 0)
+	for _, id := range externalIds {
+		if summoner, ok := s.summoners[id]; ok {
+			summoners = append(summoners, summoner)
+		}
+	}
+	return summoners, nil
+}
+
+func (s *fakeStore) CacheSummoner(summoner *dataModel.LoLCachedSummoner) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.summoners[summoner.ExternalId] = summoner
+	return nil
+}
+
+func Test_CachedSummoners(t *testing.T) {
+	server := newFakeServer(t)
+	defer server.Close()
+
+	api := lolApi.NewLoLApi(server.URL, server.URL, apiKey, server.Client(),
+		lolApi.NewSummonerCache(time.Hour, nil))
+	for i := 0; i < 2; i++ {
+		information := api.GetSummonerInformation([]string{"summoner-faker", "summoner-faker"})
+		if faker := information["summoner-faker"]; faker.Tier != "CHALLENGER" || faker.Stale {
+			t.Errorf("unexpected summoner information %+v", faker)
+		}
+	}
+	if count := server.requestCount(fakerSummoner); count != 1 {
+		t.Errorf("expected summoner to be fetched once, fetched %v times", count)
+	}
+}
+
+func Test_StaleSummoners(t *testing.T) {
+	server := newFakeServer(t)
+	defer server.Close()
+
+	api := getApi(server)
+	api.GetSummonerInformation([]string{"summoner-faker"})
+	server.setUnavailable(true)
+
+	information := api.GetSummonerInformation([]string{"summoner-faker", "summoner-doublelift"})
+	expected := lolApi.SummonerInformation{GameIdentifier: "Faker", Rank: "I", Tier: "CHALLENGER", Stale: true}
+	if faker := information["summoner-faker"]; *faker != expected {
+		t.Errorf("expected %+v, got %+v", expected, faker)
+	}
+	if doublelift := information["summoner-doublelift"]; *doublelift != (lolApi.SummonerInformation{}) {
+		t.Errorf("expected empty information for a summoner that was never cached, got %+v", doublelift)
+	}
+}
+
+func Test_StoredSummoners(t *testing.T) {
+	server := newFakeServer(t)
+	defer server.Close()
+
+	store := &fakeStore{summoners: map[string]*dataModel.LoLCachedSummoner{
+		"summoner-faker": {
+			ExternalId:     "summoner-faker",
+			GameIdentifier: "Hide on bush",
+			Rank:           "II",
+			Tier:           "GRANDMASTER",
+			Updated:        int(time.Now().Unix()),
+		},
+	}}
+	api := lolApi.NewLoLApi(server.URL, server.URL, apiKey, server.Client(),
+		lolApi.NewSummonerCache(time.Hour, store))
+
+	information := api.GetSummonerInformation([]string{"summoner-faker", "summoner-doublelift"})
+	if faker := information["summoner-faker"]; faker.GameIdentifier != "Hide on bush" || faker.Tier != "GRANDMASTER" {
+		t.Errorf("expected the stored summoner, got %+v", faker)
+	}
+	if count := server.requestCount(fakerSummoner); count != 0 {
+		t.Errorf("expected stored summoner not to be fetched, fetched %v times", count)
+	}
+	if doublelift, ok := store.summoners["summoner-doublelift"]; !ok || doublelift.GameIdentifier != "Doublelift" {
+		t.Errorf("expected fetched summoner to be stored, got %+v", doublelift)
+	}
+}
@@ -0,0 +1,102 @@
+package schedulerTest
+
+import (
+	"Server/scheduler"
+	"testing"
+	"time"
+)
+
+func getBracketSchedule(t *testing.T, tournamentType int, teams []int) []scheduler.Game {
+	s := scheduler.Scheduler{}
+	est, _ := time.LoadLocation("America/New_York")
+	s.InitScheduler(tournamentType, 0, 2, time.Hour,
+		time.Date(2018, time.October, 15, 0, 0, 0, 0, est),
+		time.Date(2018, time.December, 23, 0, 0, 0, 0, est),
+		teams)
+	s.AddWeeklyAvailability(time.Saturday, 12+4, 0, time.Hour*6)
+	games, err := s.GetSchedule()
+	if err != nil {
+		t.Fatalf("unexpected scheduling error: %v", err)
+	}
+	return games
+}
+
+// Every game fed by earlier results must start after those games have ended
+func checkSourcesPlayedFirst(t *testing.T, games []scheduler.Game) {
+	for i, game := range games {
+		for _, source := range []*scheduler.GameSource{game.Team1From, game.Team2From} {
+			if source == nil {
+				continue
+			}
+			if source.GameIndex >= i {
+				t.Errorf("game %v is fed by later game %v", i, source.GameIndex)
+			} else if games[source.GameIndex].GameTime+3600 > game.GameTime {
+				t.Errorf("game %v starts before feeding game %v ends", i, source.GameIndex)
+			}
+		}
+	}
+}
+
+func Test_SingleEliminationWithByes(t *testing.T) {
+	games := getBracketSchedule(t, scheduler.SingleElimination, []int{1, 2, 3, 4, 5, 6})
+
+	if len(games) != 5 {
+		t.Fatalf("expected 5 games for 6 teams, got %v", len(games))
+	}
+
+	// Seeds 1 and 2 get byes, so only 4 vs 5 and 3 vs 6 are played in the first round
+	firstRound := make(map[[2]int]bool)
+	for _, game := range games {
+		if game.Round == 1 {
+			firstRound[[2]int{game.Team1Id, game.Team2Id}] = true
+		}
+	}
+	if len(firstRound) != 2 || !firstRound[[2]int{4, 5}] || !firstRound[[2]int{3, 6}] {
+		t.Errorf("unexpected first round games: %v", firstRound)
+	}
+
+	// The top seeds wait in the second round for the winners of the first round
+	for _, game := range games {
+		if game.Round == 2 && (game.Team1Id != 1 && game.Team1Id != 2 || game.Team2From == nil || !game.Team2From.Winner) {
+			t.Errorf("unexpected second round game: %+v", game)
+		}
+	}
+
+	final := games[len(games)-1]
+	if final.Round != 3 || final.Team1From == nil || final.Team2From == nil {
+		t.Errorf("unexpected final: %+v", final)
+	}
+	checkSourcesPlayedFirst(t, games)
+}
+
+func Test_DoubleElimination(t *testing.T) {
+	games := getBracketSchedule(t, scheduler.DoubleElimination, []int{1, 2, 3, 4, 5, 6, 7, 8})
+
+	if len(games) != 14 {
+		t.Fatalf("expected 14 games for 8 teams, got %v", len(games))
+	}
+
+	// Every game except the grand final sends its loser somewhere or eliminates them
+	loserDrops := 0
+	for _, game := range games {
+		for _, source := range []*scheduler.GameSource{game.Team1From, game.Team2From} {
+			if source != nil && !source.Winner {
+				loserDrops++
+			}
+		}
+	}
+	if loserDrops != 7 {
+		t.Errorf("expected the 7 winners bracket losers to drop to the losers bracket, got %v", loserDrops)
+	}
+	checkSourcesPlayedFirst(t, games)
+}
+
+func Test_DoubleEliminationWithByes(t *testing.T) {
+	games := getBracketSchedule(t, scheduler.DoubleElimination, []int{1, 2, 3, 4, 5})
+
+	// 2n - 2 games without a bracket reset
+	if len(games) != 8 {
+		t.Fatalf("expected 8 games for 5 teams, got %v", len(games))
+	}
+	checkSourcesPlayedFirst(t, games)
+}
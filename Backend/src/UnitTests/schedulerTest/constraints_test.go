@@ -0,0 +1,158 @@
+package schedulerTest
+
+import (
+	"Server/scheduler"
+	"testing"
+	"time"
+)
+
+var constraintsStart = time.Date(2018, time.October, 15, 0, 0, 0, 0, time.UTC)
+
+func Test_ScheduleRespectsConstraintsAndBalancesSlots(t *testing.T) {
+	s := scheduler.Scheduler{}
+	s.InitScheduler(scheduler.DoubleRoundRobin, 1, 2, time.Hour,
+		constraintsStart, constraintsStart.AddDate(0, 3, 0), []int{1, 2, 3, 4, 5, 6})
+	s.AddWeeklyAvailability(time.Saturday, 12+4, 0, time.Hour*4)
+	games, err := s.GetSchedule()
+	if err != nil {
+		t.Fatalf("unexpected scheduling error: %v", err)
+	}
+	if len(games) != 30 {
+		t.Fatalf("expected 30 games, got %v", len(games))
+	}
+
+	gamesInBlock := make(map[int]int)
+	teamTimes := make(map[int]map[int]bool)
+	teamWeeks := make(map[int]map[int]bool)
+	balance := make(map[int]int)
+	for _, game := range games {
+		gamesInBlock[game.GameTime]++
+		week := int(time.Unix(int64(game.GameTime), 0).Sub(constraintsStart) / (7 * 24 * time.Hour))
+		for _, team := range []int{game.Team1Id, game.Team2Id} {
+			if teamTimes[team] == nil {
+				teamTimes[team] = make(map[int]bool)
+				teamWeeks[team] = make(map[int]bool)
+			}
+			if teamTimes[team][game.GameTime] {
+				t.Errorf("team %v plays twice at %v", team, game.GameTime)
+			}
+			if teamWeeks[team][week] {
+				t.Errorf("team %v plays more than one round in week %v", team, week)
+			}
+			teamTimes[team][game.GameTime] = true
+			teamWeeks[team][week] = true
+		}
+		balance[game.Team1Id]++
+		balance[game.Team2Id]--
+	}
+
+	for gameTime, num := range gamesInBlock {
+		if num > 2 {
+			t.Errorf("%v games scheduled at %v with 2 concurrent games", num, gameTime)
+		}
+	}
+	for team, difference := range balance {
+		if difference > 1 || difference < -1 {
+			t.Errorf("team %v has unbalanced team1 and team2 slots (%v)", team, difference)
+		}
+	}
+}
+
+func Test_InfeasibleScheduleReportsTeamAvailability(t *testing.T) {
+	s := scheduler.Scheduler{}
+	s.InitScheduler(scheduler.RoundRobin, 0, 2, time.Hour,
+		constraintsStart, constraintsStart.AddDate(0, 1, 0), []int{1, 2, 3, 4})
+	s.AddAvailability(constraintsStart.Add(18*time.Hour), constraintsStart.Add(24*time.Hour))
+	s.AddTeamUnavailability(3, constraintsStart, constraintsStart.AddDate(0, 1, 0))
+
+	_, err := s.GetSchedule()
+	infeasible, ok := err.(*scheduler.InfeasibleError)
+	if !ok {
+		t.Fatalf("expected an infeasible schedule error, got %v", err)
+	}
+	if infeasible.Constraint != scheduler.TeamAvailabilityConstraint {
+		t.Errorf("expected the team availability constraint, got %v", infeasible.Constraint)
+	}
+}
+
+func Test_InfeasibleScheduleReportsRoundsPerWeek(t *testing.T) {
+	s := scheduler.Scheduler{}
+	s.InitScheduler(scheduler.RoundRobin, 1, 2, time.Hour,
+		constraintsStart, constraintsStart.AddDate(0, 0, 14), []int{1, 2, 3, 4})
+	s.AddWeeklyAvailability(time.Saturday, 12, 0, time.Hour*8)
+
+	_, err := s.GetSchedule()
+	infeasible, ok := err.(*scheduler.InfeasibleError)
+	if !ok {
+		t.Fatalf("expected an infeasible schedule error, got %v", err)
+	}
+	if infeasible.Constraint != scheduler.RoundsPerWeekConstraint {
+		t.Errorf("expected the rounds per week constraint, got %v", infeasible)
+	}
+}
+
+func twentyTeams() []int {
+	var teams []int
+	for team := 1; team <= 20; team++ {
+		teams = append(teams, team)
+	}
+	return teams
+}
+
+func Test_InfeasibleScheduleReportsTeamWithTooFewWeeks(t *testing.T) {
+	s := scheduler.Scheduler{}
+	s.InitScheduler(scheduler.RoundRobin, 1, 10, time.Hour,
+		constraintsStart, constraintsStart.AddDate(0, 0, 7*19), twentyTeams())
+	s.AddWeeklyAvailability(time.Saturday, 12, 0, time.Hour*2)
+	s.AddTeamUnavailability(7, constraintsStart, constraintsStart.AddDate(0, 0, 7*3))
+
+	_, err := s.GetSchedule()
+	infeasible, ok := err.(*scheduler.InfeasibleError)
+	if !ok {
+		t.Fatalf("expected an infeasible schedule error, got %v", err)
+	}
+	if infeasible.TeamId != 7 || infeasible.Constraint != scheduler.RoundsPerWeekConstraint {
+		t.Errorf("expected team 7 to be limited by the rounds per week constraint, got %v", infeasible)
+	}
+	if infeasible.Capacity != 16 || infeasible.Required != 19 {
+		t.Errorf("expected team 7 to fit 16 of its 19 games, got %v", infeasible)
+	}
+}
+
+// Every team is available for exactly as many weeks as it has games, but 19 teams are available in the first
+// week so one of them can not play. Only the search can find this out, and it must give up in reasonable time
+func Test_InfeasibleScheduleFoundBySearch(t *testing.T) {
+	s := scheduler.Scheduler{}
+	s.InitScheduler(scheduler.RoundRobin, 1, 10, time.Hour,
+		constraintsStart, constraintsStart.AddDate(0, 0, 7*20), twentyTeams())
+	s.AddWeeklyAvailability(time.Saturday, 12, 0, time.Hour)
+	s.AddTeamUnavailability(1, constraintsStart, constraintsStart.AddDate(0, 0, 7))
+	for team := 2; team <= 20; team++ {
+		s.AddTeamUnavailability(team, constraintsStart.AddDate(0, 0, 7*19), constraintsStart.AddDate(0, 0, 7*20))
+	}
+
+	_, err := s.GetSchedule()
+	infeasible, ok := err.(*scheduler.InfeasibleError)
+	if !ok {
+		t.Fatalf("expected an infeasible schedule error, got %v", err)
+	}
+	if infeasible.Team1Id == 0 || infeasible.Team2Id == 0 || infeasible.Constraint == "" {
+		t.Errorf("expected the game that could not be placed to be reported, got %v", infeasible)
+	}
+}
+
+func Test_TwentyTeamDoubleRoundRobin(t *testing.T) {
+	s := scheduler.Scheduler{}
+	s.InitScheduler(scheduler.DoubleRoundRobin, 1, 10, time.Hour,
+		constraintsStart, constraintsStart.AddDate(0, 0, 7*40), twentyTeams())
+	s.AddWeeklyAvailability(time.Saturday, 12, 0, time.Hour*2)
+	s.AddTeamUnavailability(7, constraintsStart, constraintsStart.AddDate(0, 0, 7))
+
+	games, err := s.GetSchedule()
+	if err != nil {
+		t.Fatalf("unexpected scheduling error: %v", err)
+	}
+	if len(games) != 380 {
+		t.Errorf("expected 380 games, got %v", len(games))
+	}
+}
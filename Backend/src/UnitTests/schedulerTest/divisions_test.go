@@ -0,0 +1,83 @@
+package schedulerTest
+
+import (
+	"Server/scheduler"
+	"testing"
+	"time"
+)
+
+func getDivisionSchedule(t *testing.T, divisions [][]int, crossDivisionGames int) []scheduler.Game {
+	var teams []int
+	for _, division := range divisions {
+		teams = append(teams, division...)
+	}
+
+	s := scheduler.Scheduler{}
+	s.InitScheduler(scheduler.RoundRobin, 0, 4, time.Hour,
+		constraintsStart, constraintsStart.AddDate(0, 3, 0), teams)
+	s.AddWeeklyAvailability(time.Saturday, 12, 0, time.Hour*8)
+	s.SetDivisions(divisions, crossDivisionGames)
+	games, err := s.GetSchedule()
+	if err != nil {
+		t.Fatalf("unexpected scheduling error: %v", err)
+	}
+	return games
+}
+
+func Test_RoundRobinWithinDivisions(t *testing.T) {
+	divisions := [][]int{{1, 2, 3, 4}, {5, 6, 7, 8}}
+	games := getDivisionSchedule(t, divisions, 0)
+
+	// Each division of 4 plays its own round robin of 6 games
+	if len(games) != 12 {
+		t.Fatalf("expected 12 games, got %v", len(games))
+	}
+	for _, game := range games {
+		if (game.Team1Id <= 4) != (game.Team2Id <= 4) {
+			t.Errorf("game between teams %v and %v of different divisions", game.Team1Id, game.Team2Id)
+		}
+	}
+}
+
+func Test_CrossDivisionGames(t *testing.T) {
+	divisions := [][]int{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 10, 11}}
+	games := getDivisionSchedule(t, divisions, 2)
+
+	division := map[int]int{}
+	for d, members := range divisions {
+		for _, team := range members {
+			division[team] = d
+		}
+	}
+
+	crossGames := make(map[int]int)
+	matchups := make(map[[2]int]bool)
+	withinGames := 0
+	for _, game := range games {
+		key := [2]int{game.Team1Id, game.Team2Id}
+		if game.Team1Id > game.Team2Id {
+			key = [2]int{game.Team2Id, game.Team1Id}
+		}
+		if matchups[key] {
+			t.Errorf("teams %v and %v play each other twice", game.Team1Id, game.Team2Id)
+		}
+		matchups[key] = true
+
+		if division[game.Team1Id] == division[game.Team2Id] {
+			withinGames++
+		} else {
+			crossGames[game.Team1Id]++
+			crossGames[game.Team2Id]++
+		}
+	}
+
+	if withinGames != 6+6+3 {
+		t.Errorf("expected 15 games within divisions, got %v", withinGames)
+	}
+	// With 11 teams one team sits out of each round of cross division games
+	for team := range division {
+		if crossGames[team] > 2 || crossGames[team] < 1 {
+			t.Errorf("team %v plays %v cross division games, expected up to 2", team, crossGames[team])
+		}
+	}
+}
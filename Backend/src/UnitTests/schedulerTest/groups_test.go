@@ -0,0 +1,41 @@
+package schedulerTest
+
+import (
+	"Server/scheduler"
+	"reflect"
+	"testing"
+)
+
+func Test_SeedTwoGroups(t *testing.T) {
+	s := scheduler.Scheduler{}
+	// Group A ranked 1, 2, 3 and group B ranked 4, 5, 6
+	seeds := s.SeedGroups([][]int{{1, 2, 3}, {4, 5, 6}}, 2)
+
+	// Seed 1 plays seed 4 and seed 2 plays seed 3, so 1A plays 2B and 1B plays 2A
+	if expected := []int{1, 4, 2, 5}; !reflect.DeepEqual(seeds, expected) {
+		t.Errorf("expected seeds %v, got %v", expected, seeds)
+	}
+}
+
+func Test_SeedGroupsApartInFirstRound(t *testing.T) {
+	s := scheduler.Scheduler{}
+	groups := [][]int{{1, 2}, {3, 4}, {5, 6}}
+	seeds := s.SeedGroups(groups, 2)
+
+	if len(seeds) != 6 {
+		t.Fatalf("expected 6 seeds, got %v", seeds)
+	}
+	for i, expected := range []int{1, 3, 5} {
+		if seeds[i] != expected {
+			t.Errorf("expected group winner %v at seed %v, got %v", expected, i+1, seeds[i])
+		}
+	}
+
+	group := map[int]int{1: 0, 2: 0, 3: 1, 4: 1, 5: 2, 6: 2}
+	games := getBracketSchedule(t, scheduler.SingleElimination, seeds)
+	for _, game := range games {
+		if game.Team1From == nil && game.Team2From == nil && group[game.Team1Id] == group[game.Team2Id] {
+			t.Errorf("teams %v and %v of the same group play in the first round", game.Team1Id, game.Team2Id)
+		}
+	}
+}
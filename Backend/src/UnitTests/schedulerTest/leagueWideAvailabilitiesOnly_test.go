@@ -19,6 +19,6 @@ func Test_LeagueWideWeeklyAvailabilities(t *testing.T) {
 	s.AddWeeklyAvailability(time.Sunday, 12+5, 0, time.Hour*5)
 	games, _ := s.GetSchedule()
 	for _, game := range games {
-		fmt.Printf("%v vs %v - %v\n", game.Team1Id, game.Team2Id, time.Unix(game.GameTime, 0).Format(time.UnixDate))
+		fmt.Printf("%v vs %v - %v\n", game.Team1Id, game.Team2Id, time.Unix(int64(game.GameTime), 0).Format(time.UnixDate))
 	}
 }
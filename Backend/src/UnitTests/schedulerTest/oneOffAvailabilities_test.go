@@ -0,0 +1,77 @@
+package schedulerTest
+
+import (
+	"Server/scheduler"
+	"testing"
+	"time"
+)
+
+func Test_OneOffAvailabilitiesWithBlackout(t *testing.T) {
+	s := scheduler.Scheduler{}
+	start := time.Date(2018, time.October, 15, 0, 0, 0, 0, time.UTC)
+	s.InitScheduler(scheduler.RoundRobin, 0, 1, time.Hour,
+		start, start.AddDate(0, 1, 0), []int{1, 2, 3, 4})
+
+	// Two one-off windows with three game blocks each
+	firstWindow := start.Add(18 * time.Hour)
+	secondWindow := start.AddDate(0, 0, 2).Add(18 * time.Hour)
+	s.AddAvailability(firstWindow, firstWindow.Add(3*time.Hour))
+	s.AddAvailability(secondWindow, secondWindow.Add(3*time.Hour+30*time.Minute))
+
+	// Team 1 can not play during the whole first window
+	s.AddTeamUnavailability(1, firstWindow, firstWindow.Add(3*time.Hour))
+
+	games, err := s.GetSchedule()
+	if err != nil {
+		t.Fatalf("unexpected scheduling error: %v", err)
+	}
+	if len(games) != 6 {
+		t.Fatalf("expected 6 games, got %v", len(games))
+	}
+
+	for _, game := range games {
+		gameTime := time.Unix(int64(game.GameTime), 0)
+		inFirst := !gameTime.Before(firstWindow) && gameTime.Before(firstWindow.Add(3*time.Hour))
+		inSecond := !gameTime.Before(secondWindow) && gameTime.Before(secondWindow.Add(3*time.Hour))
+		if !inFirst && !inSecond {
+			t.Errorf("game at %v is outside of the availabilities", gameTime)
+		}
+		if inFirst && (game.Team1Id == 1 || game.Team2Id == 1) {
+			t.Errorf("team 1 scheduled during its blackout at %v", gameTime)
+		}
+	}
+}
+
+func Test_OneOffAvailabilitiesClippedToCompetitionPeriod(t *testing.T) {
+	s := scheduler.Scheduler{}
+	start := time.Date(2018, time.October, 15, 18, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+	s.InitScheduler(scheduler.RoundRobin, 0, 2, time.Hour, start, end, []int{1, 2, 3, 4})
+
+	// Each window has two game blocks during the competition period and two outside of it
+	s.AddAvailability(start.Add(-2*time.Hour), start.Add(2*time.Hour))
+	s.AddAvailability(end.Add(-2*time.Hour), end.Add(2*time.Hour))
+
+	games, err := s.GetSchedule()
+	if err != nil {
+		t.Fatalf("unexpected scheduling error: %v", err)
+	}
+	if len(games) != 6 {
+		t.Fatalf("expected 6 games, got %v", len(games))
+	}
+	for _, game := range games {
+		gameTime := time.Unix(int64(game.GameTime), 0)
+		if gameTime.Before(start) || gameTime.Add(time.Hour).After(end) {
+			t.Errorf("game at %v is outside of the competition period", gameTime)
+		}
+	}
+
+	// Windows entirely outside of the competition period add no game blocks
+	outside := scheduler.Scheduler{}
+	outside.InitScheduler(scheduler.RoundRobin, 0, 2, time.Hour, start, end, []int{1, 2, 3, 4})
+	outside.AddAvailability(start.Add(-4*time.Hour), start)
+	outside.AddAvailability(end, end.Add(4*time.Hour))
+	if _, err := outside.GetSchedule(); err == nil {
+		t.Errorf("expected scheduling to fail without game blocks in the competition period")
+	}
+}
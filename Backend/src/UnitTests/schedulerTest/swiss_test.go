@@ -0,0 +1,126 @@
+package schedulerTest
+
+import (
+	"Server/scheduler"
+	"testing"
+	"time"
+)
+
+func newSwissScheduler(teams []int) *scheduler.Scheduler {
+	s := &scheduler.Scheduler{}
+	est, _ := time.LoadLocation("America/New_York")
+	s.InitScheduler(scheduler.Swiss, 1, 2, time.Hour,
+		time.Date(2018, time.October, 15, 0, 0, 0, 0, est),
+		time.Date(2018, time.December, 23, 0, 0, 0, 0, est),
+		teams)
+	s.AddWeeklyAvailability(time.Saturday, 12+4, 0, time.Hour*6)
+	return s
+}
+
+func Test_SwissFirstRound(t *testing.T) {
+	s := newSwissScheduler([]int{1, 2, 3, 4, 5})
+	games, err := s.GetSchedule()
+	if err != nil {
+		t.Fatalf("unexpected scheduling error: %v", err)
+	}
+
+	// Teams are paired in order and the last team gets a bye
+	if len(games) != 2 || games[0].Team1Id != 1 || games[0].Team2Id != 2 ||
+		games[1].Team1Id != 3 || games[1].Team2Id != 4 {
+		t.Errorf("unexpected first round games: %+v", games)
+	}
+	for _, game := range games {
+		if game.Round != 1 {
+			t.Errorf("expected round 1, got %v", game.Round)
+		}
+	}
+}
+
+func Test_SwissPairsByRecordWithoutRematches(t *testing.T) {
+	s := newSwissScheduler([]int{1, 2, 3, 4, 5, 6})
+	// After round 1: 1 beat 2, 3 beat 4, 5 beat 6
+	s.AddPlayedGame(1, 2)
+	s.AddPlayedGame(3, 4)
+	s.AddPlayedGame(5, 6)
+	for _, winner := range []int{1, 3, 5} {
+		s.AddTeamRecord(winner, 1, 0)
+		s.AddTeamRecord(winner+1, 0, 1)
+	}
+
+	games, err := s.GetSchedule()
+	if err != nil {
+		t.Fatalf("unexpected scheduling error: %v", err)
+	}
+
+	played := make(map[int]int)
+	for _, game := range games {
+		if game.Round != 2 {
+			t.Errorf("expected round 2, got %v", game.Round)
+		}
+		if (game.Team1Id+1)/2 == (game.Team2Id+1)/2 {
+			t.Errorf("rematch between %v and %v", game.Team1Id, game.Team2Id)
+		}
+		played[game.Team1Id]++
+		played[game.Team2Id]++
+	}
+	if len(games) != 3 || len(played) != 6 {
+		t.Errorf("expected every team to play once, got %+v", games)
+	}
+
+	// The top two winners play each other
+	if games[0].Team1Id != 1 || games[0].Team2Id != 3 {
+		t.Errorf("expected 1 vs 3 first, got %v vs %v", games[0].Team1Id, games[0].Team2Id)
+	}
+}
+
+func Test_SwissNoPairingWithoutRematch(t *testing.T) {
+	s := newSwissScheduler([]int{1, 2})
+	s.AddPlayedGame(1, 2)
+	if _, err := s.GetSchedule(); err == nil {
+		t.Errorf("expected an error when every pairing is a rematch")
+	}
+}
+
+func Test_SwissLeastRecentRematchWhenSearchExhausted(t *testing.T) {
+	// Team 29 has played every other team, which the backtracking only finds out once it has paired the teams
+	// ranked above it in every possible way
+	var teams []int
+	for team := 1; team <= 14; team++ {
+		teams = append(teams, team)
+	}
+	teams = append(teams, 29)
+	for team := 15; team <= 28; team++ {
+		teams = append(teams, team)
+	}
+	teams = append(teams, 30)
+
+	s := newSwissScheduler(teams)
+	s.AddPlayedGame(29, 20)
+	for _, team := range teams {
+		if team != 29 && team != 20 {
+			s.AddPlayedGame(29, team)
+		}
+	}
+
+	start := time.Now()
+	games, err := s.GetSchedule()
+	if err != nil {
+		t.Fatalf("unexpected scheduling error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("expected the pairing search to give up, took %v", elapsed)
+	}
+
+	played := make(map[int]int)
+	for _, game := range games {
+		played[game.Team1Id]++
+		played[game.Team2Id]++
+		if (game.Team1Id == 29 || game.Team2Id == 29) && game.Team1Id != 20 && game.Team2Id != 20 {
+			t.Errorf("expected 29 to rematch 20 which it played the longest time ago, got %v vs %v",
+				game.Team1Id, game.Team2Id)
+		}
+	}
+	if len(games) != 15 || len(played) != 30 {
+		t.Errorf("expected every team to play once, got %+v", games)
+	}
+}
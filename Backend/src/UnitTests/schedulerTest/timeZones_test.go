@@ -0,0 +1,34 @@
+package schedulerTest
+
+import (
+	"Server/scheduler"
+	"testing"
+	"time"
+)
+
+// Weekly availabilities keep their wall clock time when daylight saving time ends on November 4th 2018
+func Test_WeeklyAvailabilityAcrossDaylightSavingTime(t *testing.T) {
+	s := scheduler.Scheduler{}
+	est, _ := time.LoadLocation("America/New_York")
+	s.InitScheduler(scheduler.RoundRobin, 1, 1, time.Hour,
+		time.Date(2018, time.October, 22, 0, 0, 0, 0, est),
+		time.Date(2018, time.November, 19, 0, 0, 0, 0, est),
+		[]int{1, 2})
+	s.AddWeeklyAvailability(time.Sunday, 10, 0, time.Hour*2)
+	s.AddTeamUnavailability(1, time.Date(2018, time.October, 22, 0, 0, 0, 0, est),
+		time.Date(2018, time.November, 10, 0, 0, 0, 0, est))
+
+	games, err := s.GetSchedule()
+	if err != nil {
+		t.Fatalf("unexpected scheduling error: %v", err)
+	}
+	if len(games) != 1 {
+		t.Fatalf("expected 1 game, got %v", len(games))
+	}
+
+	gameTime := time.Unix(int64(games[0].GameTime), 0).In(est)
+	expected := time.Date(2018, time.November, 11, 10, 0, 0, 0, est)
+	if !gameTime.Equal(expected) {
+		t.Errorf("expected game at %v, got %v", expected, gameTime)
+	}
+}
@@ -0,0 +1,111 @@
+package standingsTest
+
+import (
+	"Server/standings"
+	"testing"
+)
+
+func ranks(ranking []*standings.Standing) map[int]int {
+	teamRanks := make(map[int]int)
+	for _, standing := range ranking {
+		teamRanks[standing.TeamId] = standing.Rank
+	}
+	return teamRanks
+}
+
+func Test_PointsAndRecords(t *testing.T) {
+	ranking := standings.Compute([]int{1, 2, 3}, []standings.Result{
+		{Team1Id: 1, Team2Id: 2, WinnerId: 1, ScoreTeam1: 2, ScoreTeam2: 0},
+		{Team1Id: 2, Team2Id: 3, Draw: true, ScoreTeam1: 1, ScoreTeam2: 1},
+		{Team1Id: 4, Team2Id: 1, WinnerId: 4, ScoreTeam1: 1, ScoreTeam2: 0},
+	}, standings.Configuration{WinPoints: 3, DrawPoints: 1, LossPoints: 0})
+
+	expected := []standings.Standing{
+		{TeamId: 1, Rank: 1, Played: 1, Wins: 1, Points: 3, GameDifferential: 2, StrengthOfSchedule: 1,
+			SonnebornBerger: 1},
+		{TeamId: 2, Rank: 2, Played: 2, Losses: 1, Draws: 1, Points: 1, GameDifferential: -2,
+			StrengthOfSchedule: 2, SonnebornBerger: 0.5},
+		{TeamId: 3, Rank: 2, Played: 1, Draws: 1, Points: 1, StrengthOfSchedule: 1, SonnebornBerger: 0.5},
+	}
+	for i, standing := range ranking {
+		if *standing != expected[i] {
+			t.Errorf("expected %+v at position %v, got %+v", expected[i], i, *standing)
+		}
+	}
+}
+
+// 1, 2 and 3 beat each other in a cycle and all beat 4, so only the game differential separates them
+func Test_TiebreakersAppliedInOrder(t *testing.T) {
+	results := []standings.Result{
+		{Team1Id: 1, Team2Id: 2, WinnerId: 1, ScoreTeam1: 2, ScoreTeam2: 1},
+		{Team1Id: 2, Team2Id: 3, WinnerId: 2, ScoreTeam1: 2, ScoreTeam2: 0},
+		{Team1Id: 3, Team2Id: 1, WinnerId: 3, ScoreTeam1: 2, ScoreTeam2: 1},
+		{Team1Id: 1, Team2Id: 4, WinnerId: 1, ScoreTeam1: 2, ScoreTeam2: 0},
+		{Team1Id: 2, Team2Id: 4, WinnerId: 2, ScoreTeam1: 2, ScoreTeam2: 0},
+		{Team1Id: 3, Team2Id: 4, WinnerId: 3, ScoreTeam1: 2, ScoreTeam2: 0},
+	}
+	config := standings.Configuration{WinPoints: 3, DrawPoints: 1, LossPoints: 0}
+
+	teamRanks := ranks(standings.Compute([]int{1, 2, 3, 4}, results, config))
+	if teamRanks[1] != 1 || teamRanks[2] != 1 || teamRanks[3] != 1 || teamRanks[4] != 4 {
+		t.Errorf("expected teams without tiebreakers to share a rank, got %v", teamRanks)
+	}
+
+	config.Tiebreakers = []string{standings.HeadToHead, standings.GameDifferential}
+	teamRanks = ranks(standings.Compute([]int{1, 2, 3, 4}, results, config))
+	if teamRanks[2] != 1 || teamRanks[1] != 2 || teamRanks[3] != 3 || teamRanks[4] != 4 {
+		t.Errorf("expected ranking by game differential after head-to-head, got %v", teamRanks)
+	}
+}
+
+// 1, 2, 3 and 4 have two wins each. Among them 1 and 2 have two wins, so head-to-head is applied again between
+// just 1 and 2
+func Test_HeadToHeadAppliedAgainAmongTiedTeams(t *testing.T) {
+	results := []standings.Result{
+		{Team1Id: 1, Team2Id: 2, WinnerId: 1},
+		{Team1Id: 1, Team2Id: 3, WinnerId: 1},
+		{Team1Id: 2, Team2Id: 3, WinnerId: 2},
+		{Team1Id: 2, Team2Id: 4, WinnerId: 2},
+		{Team1Id: 3, Team2Id: 4, WinnerId: 3},
+		{Team1Id: 3, Team2Id: 5, WinnerId: 3},
+		{Team1Id: 4, Team2Id: 5, WinnerId: 4},
+		{Team1Id: 4, Team2Id: 6, WinnerId: 4},
+	}
+	teamRanks := ranks(standings.Compute([]int{2, 1, 3, 4, 5, 6}, results, standings.Configuration{
+		WinPoints:   1,
+		Tiebreakers: []string{standings.HeadToHead},
+	}))
+	if teamRanks[1] != 1 || teamRanks[2] != 2 || teamRanks[3] != 3 || teamRanks[4] != 4 {
+		t.Errorf("unexpected head-to-head ranking: %v", teamRanks)
+	}
+}
+
+func Test_SonnebornBergerAndStrengthOfSchedule(t *testing.T) {
+	// 1, 2 and 3 have one win each, and 1 beat a team with a better record than the team 2 beat
+	results := []standings.Result{
+		{Team1Id: 1, Team2Id: 3, WinnerId: 1},
+		{Team1Id: 2, Team2Id: 4, WinnerId: 2},
+		{Team1Id: 3, Team2Id: 4, WinnerId: 3},
+	}
+	for _, tiebreaker := range []string{standings.SonnebornBerger, standings.StrengthOfSchedule} {
+		teamRanks := ranks(standings.Compute([]int{2, 1, 3, 4}, results, standings.Configuration{
+			WinPoints:   1,
+			Tiebreakers: []string{tiebreaker},
+		}))
+		if teamRanks[1] != 1 || teamRanks[2] == 1 {
+			t.Errorf("expected %v to rank team 1 above team 2, got %v", tiebreaker, teamRanks)
+		}
+	}
+}
+
+func Test_DoubleForfeitIsALossForBothTeams(t *testing.T) {
+	ranking := standings.Compute([]int{1, 2}, []standings.Result{
+		{Team1Id: 1, Team2Id: 2},
+	}, standings.Configuration{WinPoints: 3, DrawPoints: 1, LossPoints: 0})
+
+	for _, standing := range ranking {
+		if standing.Losses != 1 || standing.Draws != 0 || standing.Points != 0 || standing.Rank != 1 {
+			t.Errorf("expected a loss for team %v, got %+v", standing.TeamId, *standing)
+		}
+	}
+}
@@ -3,12 +3,27 @@
 package mocks
 
 import mock "github.com/stretchr/testify/mock"
+import time "time"
 
 // Config is an autogenerated mock type for the Config type
 type Config struct {
 	mock.Mock
 }
 
+// GetDataDragonUrl provides a mock function with given fields:
+func (_m *Config) GetDataDragonUrl() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // GetDbConnString provides a mock function with given fields:
 func (_m *Config) GetDbConnString() string {
 	ret := _m.Called()
@@ -76,6 +91,20 @@ func (_m *Config) GetLeagueOfLegendsApiKey() string {
 	return r0
 }
 
+// GetLeagueOfLegendsApiUrl provides a mock function with given fields:
+func (_m *Config) GetLeagueOfLegendsApiUrl() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // GetMarkdownDir provides a mock function with given fields:
 func (_m *Config) GetMarkdownDir() string {
 	ret := _m.Called()
@@ -90,6 +119,20 @@ func (_m *Config) GetMarkdownDir() string {
 	return r0
 }
 
+// GetPersistSummonerCache provides a mock function with given fields:
+func (_m *Config) GetPersistSummonerCache() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // GetPortString provides a mock function with given fields:
 func (_m *Config) GetPortString() string {
 	ret := _m.Called()
@@ -103,3 +146,59 @@ func (_m *Config) GetPortString() string {
 
 	return r0
 }
+
+// GetSummonerCacheTtl provides a mock function with given fields:
+func (_m *Config) GetSummonerCacheTtl() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// GetTournamentCallbackSecret provides a mock function with given fields:
+func (_m *Config) GetTournamentCallbackSecret() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetTournamentCallbackUrl provides a mock function with given fields:
+func (_m *Config) GetTournamentCallbackUrl() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetTournamentCodeLeadTime provides a mock function with given fields:
+func (_m *Config) GetTournamentCodeLeadTime() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
@@ -10,7 +10,6 @@ import {
     GameTime,
     SortedGames
 } from "../interfaces/Game";
-import * as moment from "moment";
 import {Moment} from "moment";
 import {httpOptions} from "./http-options";
 
@@ -37,7 +36,7 @@ export class GamesService {
         return this.http.get<CompetitionWeek[]>('http://localhost:8080/api/v1/gamesByWeek', {
             withCredentials: true,
             params: <any>{
-                timeZoneOffset: moment().utcOffset() * 60
+                timeZone: Intl.DateTimeFormat().resolvedOptions().timeZone
             }
         })
     }